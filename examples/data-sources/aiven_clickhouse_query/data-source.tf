@@ -0,0 +1,9 @@
+data "aiven_clickhouse_query" "tenant_databases" {
+  project      = aiven_clickhouse.clickhouse.project
+  service_name = aiven_clickhouse.clickhouse.service_name
+  query        = "SELECT name FROM system.databases WHERE name LIKE 'tenant_%'"
+}
+
+output "tenant_databases" {
+  value = [for row in data.aiven_clickhouse_query.tenant_databases.rows : row[0]]
+}
@@ -0,0 +1,8 @@
+data "aiven_kafka_connect_plugins" "plugins" {
+  project      = aiven_project.kafka-con-project1.project
+  service_name = aiven_kafka.kafka-service1.service_name
+}
+
+output "sink_plugins" {
+  value = [for p in data.aiven_kafka_connect_plugins.plugins.plugins : p.class if p.type == "sink"]
+}
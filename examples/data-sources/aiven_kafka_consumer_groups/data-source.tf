@@ -0,0 +1,8 @@
+data "aiven_kafka_consumer_groups" "groups" {
+  project      = aiven_project.myproject.project
+  service_name = aiven_kafka.myservice.service_name
+}
+
+output "lagging_groups" {
+  value = [for g in data.aiven_kafka_consumer_groups.groups.consumer_groups : g.group_id if g.lag > 1000]
+}
@@ -0,0 +1,10 @@
+data "aiven_kafka_native_acl" "mytestacl" {
+  project         = aiven_project.myproject.project
+  service_name    = aiven_kafka.mykafka.service_name
+  resource_type   = "Topic"
+  resource_name   = "<TOPIC_NAME_PREFIX>"
+  pattern_type    = "PREFIXED"
+  principal       = "User:<USERNAME>"
+  operation       = "Read"
+  permission_type = "ALLOW"
+}
@@ -0,0 +1,6 @@
+data "aiven_kafka_quota" "myquota" {
+  project      = aiven_project.myproject.project
+  service_name = aiven_kafka.mykafka.service_name
+  user         = "<USERNAME>"
+  client_id    = "<CLIENT_ID>"
+}
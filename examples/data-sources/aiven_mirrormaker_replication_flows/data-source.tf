@@ -0,0 +1,8 @@
+data "aiven_mirrormaker_replication_flows" "flows" {
+  project      = aiven_project.kafka-mm-project1.project
+  service_name = aiven_kafka.mm.service_name
+}
+
+output "replicated_topics" {
+  value = { for f in data.aiven_mirrormaker_replication_flows.flows.replication_flows : "${f.source_cluster}->${f.target_cluster}" => f.matched_topics }
+}
@@ -0,0 +1 @@
+terraform import aiven_clickhouse_quota.daily project/service_name/name
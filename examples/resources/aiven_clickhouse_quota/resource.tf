@@ -0,0 +1,13 @@
+resource "aiven_clickhouse_quota" "daily" {
+  project      = aiven_clickhouse.clickhouse.project
+  service_name = aiven_clickhouse.clickhouse.service_name
+  name         = "daily"
+  key_type     = "user_name"
+  apply_to     = [aiven_clickhouse_user.analyst.username]
+
+  interval {
+    duration       = 86400
+    queries        = 1000
+    execution_time = 3600
+  }
+}
@@ -0,0 +1 @@
+terraform import aiven_clickhouse_row_policy.tenant project/service_name/database/table/name
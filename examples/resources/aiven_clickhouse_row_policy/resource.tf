@@ -0,0 +1,9 @@
+resource "aiven_clickhouse_row_policy" "tenant" {
+  project      = aiven_clickhouse.clickhouse.project
+  service_name = aiven_clickhouse.clickhouse.service_name
+  name         = "tenant"
+  database     = aiven_clickhouse_database.demodb.name
+  table        = "events"
+  condition    = "tenant_id = 42"
+  apply_to     = [aiven_clickhouse_role.reader.role]
+}
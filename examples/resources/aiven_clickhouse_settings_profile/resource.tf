@@ -0,0 +1,19 @@
+resource "aiven_clickhouse_settings_profile" "analysts" {
+  project      = aiven_clickhouse.clickhouse.project
+  service_name = aiven_clickhouse.clickhouse.service_name
+  name         = "analysts"
+  inherit      = ["readonly"]
+  apply_to     = [aiven_clickhouse_role.analyst.role]
+
+  setting {
+    name  = "max_memory_usage"
+    value = "10000000000"
+    max   = "20000000000"
+  }
+
+  setting {
+    name        = "load_balancing"
+    value       = "random"
+    writability = "CONST"
+  }
+}
@@ -0,0 +1,31 @@
+resource "aiven_clickhouse_table" "events" {
+  project      = aiven_clickhouse.clickhouse.project
+  service_name = aiven_clickhouse.clickhouse.service_name
+  database     = aiven_clickhouse_database.analytics.name
+  name         = "events"
+  engine       = "MergeTree"
+  order_by     = "(tenant_id, timestamp)"
+  partition_by = "toYYYYMM(timestamp)"
+  ttl          = "timestamp + INTERVAL 30 DAY"
+
+  column {
+    name    = "timestamp"
+    type    = "DateTime"
+    default = "now()"
+  }
+
+  column {
+    name    = "tenant_id"
+    type    = "UInt64"
+    comment = "The owner of the event"
+  }
+
+  column {
+    name = "payload"
+    type = "String"
+  }
+
+  settings = {
+    index_granularity = "8192"
+  }
+}
@@ -3,3 +3,12 @@ resource "aiven_clickhouse_user" "ch-user" {
   service_name = aiven_clickhouse.myservice.service_name
   username     = "<USERNAME>"
 }
+
+resource "aiven_clickhouse_user" "analyst" {
+  project                   = aiven_project.myproject.project
+  service_name              = aiven_clickhouse.myservice.service_name
+  username                  = "analyst"
+  allowed_ips               = ["10.0.0.0/8"]
+  settings_profile          = aiven_clickhouse_settings_profile.restricted.name
+  password_rotation_trigger = "2024-01-01"
+}
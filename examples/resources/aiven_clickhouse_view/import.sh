@@ -0,0 +1 @@
+terraform import aiven_clickhouse_view.recent_events project/service_name/database/name
@@ -0,0 +1,17 @@
+resource "aiven_clickhouse_view" "recent_events" {
+  project      = aiven_clickhouse.clickhouse.project
+  service_name = aiven_clickhouse.clickhouse.service_name
+  database     = aiven_clickhouse_database.analytics.name
+  name         = "recent_events"
+  query        = "SELECT * FROM analytics.events WHERE timestamp > now() - INTERVAL 1 DAY"
+}
+
+resource "aiven_clickhouse_view" "events_per_tenant" {
+  project      = aiven_clickhouse.clickhouse.project
+  service_name = aiven_clickhouse.clickhouse.service_name
+  database     = aiven_clickhouse_database.analytics.name
+  name         = "events_per_tenant_mv"
+  query        = "SELECT tenant_id, count() AS total FROM analytics.events GROUP BY tenant_id"
+  materialized = true
+  to_table     = aiven_clickhouse_table.events_per_tenant.name
+}
@@ -1 +1,2 @@
 terraform import aiven_kafka_acl.mytestacl project/service_name/id
+terraform import aiven_kafka_acl.mytestacl project/service_name/username/topic/permission
@@ -3,6 +3,9 @@ resource "aiven_kafka_connector" "kafka-os-con1" {
   service_name   = aiven_kafka.kafka-service1.service_name
   connector_name = "kafka-os-con1"
 
+  # Fails with the task traces if the connector doesn't start
+  wait_for_running = true
+
   config = {
     "topics"              = aiven_kafka_topic.kafka-topic1.topic_name
     "connector.class" : "io.aiven.kafka.connect.opensearch.OpensearchSinkConnector"
@@ -10,6 +13,10 @@ resource "aiven_kafka_connector" "kafka-os-con1" {
     "name"                = "kafka-os-con1"
     "connection.url"      = aiven_elasticsearch.os-service1.service_uri
     "connection.username" = aiven_opensearch.os-service1.service_username
+  }
+
+  # Secrets are not shown in the plan output
+  config_sensitive = {
     "connection.password" = aiven_opensearch.os-service1.service_password
   }
 }
@@ -0,0 +1,8 @@
+resource "aiven_kafka_consumer_group_offsets" "replay" {
+  project      = aiven_project.myproject.project
+  service_name = aiven_kafka.myservice.service_name
+  group_id     = "<GROUP_ID>"
+  reset_to     = "timestamp"
+  timestamp    = "2024-01-01T00:00:00Z"
+  topics       = [aiven_kafka_topic.mytopic.topic_name]
+}
@@ -0,0 +1 @@
+terraform import aiven_kafka_native_acl.mytestacl project/service_name/id
@@ -0,0 +1,11 @@
+resource "aiven_kafka_native_acl" "mytestacl" {
+  project         = aiven_project.myproject.project
+  service_name    = aiven_kafka.myservice.service_name
+  resource_type   = "Topic"
+  resource_name   = "<TOPIC_NAME_PREFIX>"
+  pattern_type    = "PREFIXED"
+  principal       = "User:<USERNAME>"
+  host            = "*"
+  operation       = "Read"
+  permission_type = "ALLOW"
+}
@@ -0,0 +1 @@
+terraform import aiven_kafka_quota.myquota project/service_name/user/client_id
@@ -0,0 +1,9 @@
+resource "aiven_kafka_quota" "myquota" {
+  project            = aiven_project.myproject.project
+  service_name       = aiven_kafka.myservice.service_name
+  user               = "<USERNAME>"
+  client_id          = "<CLIENT_ID>"
+  consumer_byte_rate = 1048576
+  producer_byte_rate = 1048576
+  request_percentage = 25
+}
@@ -20,3 +20,29 @@ resource "aiven_kafka_schema" "kafka-schema1" {
     }
     EOT
 }
+
+resource "aiven_kafka_schema" "kafka-schema2" {
+  project      = aiven_project.kafka-schemas-project1.project
+  service_name = aiven_kafka.kafka-service1.service_name
+  subject_name = "kafka-schema2"
+  schema_type  = "AVRO"
+
+  # A new version of kafka-schema1 updates the reference and adds a new version of this schema
+  references {
+    name    = "example.example"
+    subject = aiven_kafka_schema.kafka-schema1.subject_name
+    version = aiven_kafka_schema.kafka-schema1.version
+  }
+
+  schema = <<EOT
+    {
+       "name": "wrapper",
+       "namespace": "example",
+       "type": "record",
+       "fields": [{
+           "name": "inner",
+           "type": "example.example"
+       }]
+    }
+    EOT
+}
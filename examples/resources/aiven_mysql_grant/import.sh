@@ -0,0 +1 @@
+terraform import aiven_mysql_grant.reader project/service_name/user/database/table
@@ -0,0 +1,7 @@
+resource "aiven_mysql_grant" "reader" {
+  project      = aiven_mysql.mysql.project
+  service_name = aiven_mysql.mysql.service_name
+  user         = aiven_mysql_user.reader.username
+  database     = aiven_mysql_database.app.database_name
+  privileges   = ["SELECT", "SHOW VIEW"]
+}
@@ -0,0 +1 @@
+terraform import aiven_opensearch_acl_rules.os_acl_rules project/service_name
@@ -0,0 +1,29 @@
+resource "aiven_opensearch_acl_config" "os_acls_config" {
+  project      = var.aiven_project_name
+  service_name = aiven_opensearch.os_test.service_name
+  enabled      = true
+  extended_acl = false
+}
+
+resource "aiven_opensearch_acl_rules" "os_acl_rules" {
+  project      = aiven_opensearch_acl_config.os_acls_config.project
+  service_name = aiven_opensearch_acl_config.os_acls_config.service_name
+
+  rule {
+    username   = aiven_opensearch_user.os_user.username
+    index      = "index2"
+    permission = "readwrite"
+  }
+
+  rule {
+    username   = aiven_opensearch_user.os_user.username
+    index      = "logs-*"
+    permission = "read"
+  }
+
+  rule {
+    username   = aiven_opensearch_user.os_user_2.username
+    index      = "index3"
+    permission = "write"
+  }
+}
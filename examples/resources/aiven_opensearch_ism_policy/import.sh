@@ -0,0 +1 @@
+AIVEN_OPENSEARCH_SECURITY_ADMIN_PASSWORD=password terraform import aiven_opensearch_ism_policy.delete_old_logs project/service_name/policy_id
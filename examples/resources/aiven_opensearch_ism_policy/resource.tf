@@ -0,0 +1,28 @@
+resource "aiven_opensearch_ism_policy" "delete_old_logs" {
+  project        = aiven_opensearch_security_plugin_config.foo.project
+  service_name   = aiven_opensearch_security_plugin_config.foo.service_name
+  admin_password = aiven_opensearch_security_plugin_config.foo.admin_password
+  policy_id      = "delete-old-logs"
+  policy = jsonencode({
+    description   = "Deletes logs after 30 days"
+    default_state = "hot"
+    states = [
+      {
+        name    = "hot"
+        actions = []
+        transitions = [
+          {
+            state_name = "delete"
+            conditions = { min_index_age = "30d" }
+          }
+        ]
+      },
+      {
+        name        = "delete"
+        actions     = [{ delete = {} }]
+        transitions = []
+      }
+    ]
+    ism_template = [{ index_patterns = ["logs-*"], priority = 100 }]
+  })
+}
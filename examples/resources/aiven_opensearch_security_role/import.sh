@@ -0,0 +1 @@
+AIVEN_OPENSEARCH_SECURITY_ADMIN_PASSWORD=password terraform import aiven_opensearch_security_role.logs_reader project/service_name/role_name
@@ -0,0 +1,15 @@
+resource "aiven_opensearch_security_role" "logs_reader" {
+  project             = aiven_opensearch_security_plugin_config.foo.project
+  service_name        = aiven_opensearch_security_plugin_config.foo.service_name
+  admin_password      = aiven_opensearch_security_plugin_config.foo.admin_password
+  role_name           = "logs-reader"
+  description         = "Reads logs"
+  cluster_permissions = ["cluster_monitor"]
+
+  index_permissions {
+    index_patterns  = ["logs-*"]
+    allowed_actions = ["read"]
+    dls             = jsonencode({ term = { public = true } })
+    masked_fields   = ["client_ip"]
+  }
+}
@@ -0,0 +1,8 @@
+resource "aiven_opensearch_security_role_mapping" "logs_reader" {
+  project        = aiven_opensearch_security_role.logs_reader.project
+  service_name   = aiven_opensearch_security_role.logs_reader.service_name
+  admin_password = aiven_opensearch_security_role.logs_reader.admin_password
+  role_name      = aiven_opensearch_security_role.logs_reader.role_name
+  users          = [aiven_opensearch_user.foo.username]
+  backend_roles  = ["analysts"]
+}
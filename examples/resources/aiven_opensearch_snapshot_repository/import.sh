@@ -0,0 +1 @@
+AIVEN_OPENSEARCH_SECURITY_ADMIN_PASSWORD=password terraform import aiven_opensearch_snapshot_repository.s3 project/service_name/repository_name
@@ -0,0 +1,18 @@
+resource "aiven_opensearch_snapshot_repository" "s3" {
+  project         = aiven_opensearch_security_plugin_config.foo.project
+  service_name    = aiven_opensearch_security_plugin_config.foo.service_name
+  admin_password  = aiven_opensearch_security_plugin_config.foo.admin_password
+  repository_name = "s3-snapshots"
+  type            = "s3"
+
+  settings = {
+    bucket    = "example-snapshots"
+    base_path = "opensearch"
+    region    = "eu-west-1"
+  }
+
+  secure_settings = {
+    access_key = var.snapshot_access_key
+    secret_key = var.snapshot_secret_key
+  }
+}
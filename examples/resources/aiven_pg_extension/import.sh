@@ -0,0 +1 @@
+terraform import aiven_pg_extension.vector project/service_name/database/name
@@ -0,0 +1 @@
+terraform import aiven_pg_grant.reader project/service_name/database/role
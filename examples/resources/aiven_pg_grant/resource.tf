@@ -0,0 +1,30 @@
+resource "aiven_pg_grant" "reader" {
+  project             = aiven_pg.pg.project
+  service_name        = aiven_pg.pg.service_name
+  database            = aiven_pg_database.app.database_name
+  role                = aiven_pg_user.reader.username
+  database_privileges = ["CONNECT"]
+
+  schema {
+    name       = "public"
+    privileges = ["USAGE"]
+  }
+
+  table {
+    schema     = "public"
+    name       = "*"
+    privileges = ["SELECT"]
+  }
+
+  sequence {
+    schema     = "public"
+    name       = "*"
+    privileges = ["USAGE", "SELECT"]
+  }
+
+  default_privileges {
+    schema      = "public"
+    object_type = "tables"
+    privileges  = ["SELECT"]
+  }
+}
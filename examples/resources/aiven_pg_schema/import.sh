@@ -0,0 +1 @@
+terraform import aiven_pg_schema.extensions project/service_name/database/name
@@ -0,0 +1,7 @@
+resource "aiven_pg_schema" "extensions" {
+  project      = aiven_pg.pg.project
+  service_name = aiven_pg.pg.service_name
+  database     = aiven_pg_database.app.database_name
+  name         = "extensions"
+  owner        = aiven_pg_user.app.username
+}
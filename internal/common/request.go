@@ -0,0 +1,70 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/aiven/aiven-go-client/v2"
+)
+
+// defaultAPIURL is the Aiven API v1 URL, can be overwritten with AIVEN_WEB_URL the same way aiven.Client does
+const defaultAPIURL = "https://api.aiven.io"
+
+// apiURL returns the Aiven API v1 URL
+func apiURL() string {
+	if v, ok := os.LookupEnv("AIVEN_WEB_URL"); ok {
+		return v + "/v1"
+	}
+	return defaultAPIURL + "/v1"
+}
+
+// DoRequest calls Aiven API endpoints which are not covered by aiven.Client yet.
+// Uses the client's credentials and http client (with its retries).
+// Marshals "in" as the request body (if not nil), unmarshals the response into "out" (if not nil).
+// Returns aiven.Error on non-2xx status, so aiven.IsNotFound and aiven.IsAlreadyExists can be used.
+func DoRequest(ctx context.Context, client *aiven.Client, method, path string, in, out any) error {
+	var body []byte
+	if in != nil {
+		var err error
+		body, err = json.Marshal(in)
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, apiURL()+path, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", client.UserAgent)
+	req.Header.Set("Authorization", "aivenv1 "+client.APIKey)
+
+	rsp, err := client.Client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := rsp.Body.Close(); err != nil {
+			log.Printf("[WARNING] cannot close response body: %s", err)
+		}
+	}()
+
+	b, err := io.ReadAll(rsp.Body)
+	if err != nil || rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
+		return aiven.Error{Message: string(b), Status: rsp.StatusCode}
+	}
+
+	if out == nil || len(b) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(b, out)
+}
@@ -0,0 +1,20 @@
+package kafkaaclrepository
+
+import (
+	"context"
+
+	"github.com/aiven/aiven-go-client/v2"
+)
+
+// CreateACL creates Kafka ACL and invalidates the service cache
+func (rep *repository) CreateACL(ctx context.Context, project, service string, req aiven.CreateKafkaACLRequest) (*aiven.KafkaACL, error) {
+	// Invalidates even on error: the ACL might have been created anyway
+	defer rep.invalidate(project, service)
+	return rep.client.CreateACL(ctx, project, service, req)
+}
+
+// CreateSchemaRegistryACL creates Kafka Schema Registry ACL and invalidates the service cache
+func (rep *repository) CreateSchemaRegistryACL(ctx context.Context, project, service string, req aiven.CreateKafkaSchemaRegistryACLRequest) (*aiven.KafkaSchemaRegistryACL, error) {
+	defer rep.invalidate(project, service)
+	return rep.client.CreateSchemaRegistryACL(ctx, project, service, req)
+}
@@ -0,0 +1,17 @@
+package kafkaaclrepository
+
+import (
+	"context"
+)
+
+// DeleteACL deletes Kafka ACL and invalidates the service cache
+func (rep *repository) DeleteACL(ctx context.Context, project, service, aclID string) error {
+	defer rep.invalidate(project, service)
+	return rep.client.DeleteACL(ctx, project, service, aclID)
+}
+
+// DeleteSchemaRegistryACL deletes Kafka Schema Registry ACL and invalidates the service cache
+func (rep *repository) DeleteSchemaRegistryACL(ctx context.Context, project, service, aclID string) error {
+	defer rep.invalidate(project, service)
+	return rep.client.DeleteSchemaRegistryACL(ctx, project, service, aclID)
+}
@@ -0,0 +1,120 @@
+package kafkaaclrepository
+
+import (
+	"context"
+	"log"
+
+	"github.com/aiven/aiven-go-client/v2"
+)
+
+// ReadACL returns Kafka ACL by ID.
+// Gets the ACL from the API on cache miss, so the result (or its aiven.Error) is always the remote state
+func (rep *repository) ReadACL(ctx context.Context, project, service, aclID string) (*aiven.KafkaACL, error) {
+	list, err := rep.ListACLs(ctx, project, service)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, acl := range list {
+		if acl.ID == aclID {
+			return acl, nil
+		}
+	}
+
+	log.Printf("[DEBUG] cache miss on Kafka ACL %s, going live to Aiven API", aclID)
+	acl, err := rep.client.GetACL(ctx, project, service, aclID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The cached list is stale
+	rep.invalidate(project, service)
+	return acl, nil
+}
+
+// ListACLs returns all Kafka ACLs of the service
+func (rep *repository) ListACLs(ctx context.Context, project, service string) ([]*aiven.KafkaACL, error) {
+	s, err := rep.fetch(ctx, project, service)
+	if err != nil {
+		return nil, err
+	}
+
+	// Copies values, so the cache can't be modified by the caller
+	list := make([]*aiven.KafkaACL, 0, len(s.acls))
+	for _, acl := range s.acls {
+		v := *acl
+		list = append(list, &v)
+	}
+	return list, nil
+}
+
+// ReadSchemaRegistryACL returns Kafka Schema Registry ACL by ID.
+// Gets the ACL from the API on cache miss, see ReadACL
+func (rep *repository) ReadSchemaRegistryACL(ctx context.Context, project, service, aclID string) (*aiven.KafkaSchemaRegistryACL, error) {
+	list, err := rep.ListSchemaRegistryACLs(ctx, project, service)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, acl := range list {
+		if acl.ID == aclID {
+			return acl, nil
+		}
+	}
+
+	log.Printf("[DEBUG] cache miss on Kafka Schema Registry ACL %s, going live to Aiven API", aclID)
+	acl, err := rep.client.GetSchemaRegistryACL(ctx, project, service, aclID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The cached list is stale
+	rep.invalidate(project, service)
+	return acl, nil
+}
+
+// ListSchemaRegistryACLs returns all Kafka Schema Registry ACLs of the service
+func (rep *repository) ListSchemaRegistryACLs(ctx context.Context, project, service string) ([]*aiven.KafkaSchemaRegistryACL, error) {
+	s, err := rep.fetch(ctx, project, service)
+	if err != nil {
+		return nil, err
+	}
+
+	// Copies values, so the cache can't be modified by the caller
+	list := make([]*aiven.KafkaSchemaRegistryACL, 0, len(s.schemaRegistryACLs))
+	for _, acl := range s.schemaRegistryACLs {
+		v := *acl
+		list = append(list, &v)
+	}
+	return list, nil
+}
+
+// FindACL returns Kafka ACL by its fields or errNotFound
+func (rep *repository) FindACL(ctx context.Context, project, service, permission, topic, username string) (*aiven.KafkaACL, error) {
+	list, err := rep.ListACLs(ctx, project, service)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, acl := range list {
+		if acl.Permission == permission && acl.Topic == topic && acl.Username == username {
+			return acl, nil
+		}
+	}
+	return nil, errNotFound
+}
+
+// FindSchemaRegistryACL returns Kafka Schema Registry ACL by its fields or errNotFound
+func (rep *repository) FindSchemaRegistryACL(ctx context.Context, project, service, permission, resource, username string) (*aiven.KafkaSchemaRegistryACL, error) {
+	list, err := rep.ListSchemaRegistryACLs(ctx, project, service)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, acl := range list {
+		if acl.Permission == permission && acl.Resource == resource && acl.Username == username {
+			return acl, nil
+		}
+	}
+	return nil, errNotFound
+}
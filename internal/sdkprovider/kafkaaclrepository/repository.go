@@ -0,0 +1,139 @@
+package kafkaaclrepository
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/aiven/aiven-go-client/v2"
+
+	"github.com/aiven/terraform-provider-aiven/internal/sdkprovider/ttlcache"
+)
+
+var (
+	initOnce sync.Once
+	// singleRep a singleton for repository to share it across running goroutines
+	singleRep = &repository{}
+	// errNotFound mimics Aiven "not found" error. Never wrap it, so it can be determined by aiven.IsNotFound
+	errNotFound = aiven.Error{Status: http.StatusNotFound, Message: "ACL not found"}
+)
+
+const (
+	// defaultCacheTTL how long the fetched ACLs of a service are considered fresh
+	defaultCacheTTL = 30 * time.Second
+)
+
+// New returns process singleton Repository
+func New(client *aiven.Client) Repository {
+	initOnce.Do(func() {
+		singleRep = newRepository(&aclClientAdapter{client: client})
+	})
+	return singleRep
+}
+
+// Repository CRUD interface for Kafka ACLs and Kafka Schema Registry ACLs.
+// Both kinds are returned by the same service call, so they share the cache.
+type Repository interface {
+	ReadACL(ctx context.Context, project, service, aclID string) (*aiven.KafkaACL, error)
+	ListACLs(ctx context.Context, project, service string) ([]*aiven.KafkaACL, error)
+	FindACL(ctx context.Context, project, service, permission, topic, username string) (*aiven.KafkaACL, error)
+	CreateACL(ctx context.Context, project, service string, req aiven.CreateKafkaACLRequest) (*aiven.KafkaACL, error)
+	DeleteACL(ctx context.Context, project, service, aclID string) error
+
+	ReadSchemaRegistryACL(ctx context.Context, project, service, aclID string) (*aiven.KafkaSchemaRegistryACL, error)
+	ListSchemaRegistryACLs(ctx context.Context, project, service string) ([]*aiven.KafkaSchemaRegistryACL, error)
+	FindSchemaRegistryACL(ctx context.Context, project, service, permission, resource, username string) (*aiven.KafkaSchemaRegistryACL, error)
+	CreateSchemaRegistryACL(ctx context.Context, project, service string, req aiven.CreateKafkaSchemaRegistryACLRequest) (*aiven.KafkaSchemaRegistryACL, error)
+	DeleteSchemaRegistryACL(ctx context.Context, project, service, aclID string) error
+}
+
+// aclClient interface for unit tests
+type aclClient interface {
+	GetService(ctx context.Context, project, service string) (*aiven.Service, error)
+	GetACL(ctx context.Context, project, service, aclID string) (*aiven.KafkaACL, error)
+	GetSchemaRegistryACL(ctx context.Context, project, service, aclID string) (*aiven.KafkaSchemaRegistryACL, error)
+	CreateACL(ctx context.Context, project, service string, req aiven.CreateKafkaACLRequest) (*aiven.KafkaACL, error)
+	DeleteACL(ctx context.Context, project, service, aclID string) error
+	CreateSchemaRegistryACL(ctx context.Context, project, service string, req aiven.CreateKafkaSchemaRegistryACLRequest) (*aiven.KafkaSchemaRegistryACL, error)
+	DeleteSchemaRegistryACL(ctx context.Context, project, service, aclID string) error
+}
+
+// aclClientAdapter implements aclClient with aiven.Client handlers
+type aclClientAdapter struct {
+	client *aiven.Client
+}
+
+func (a *aclClientAdapter) GetService(ctx context.Context, project, service string) (*aiven.Service, error) {
+	return a.client.Services.Get(ctx, project, service)
+}
+
+func (a *aclClientAdapter) GetACL(ctx context.Context, project, service, aclID string) (*aiven.KafkaACL, error) {
+	return a.client.KafkaACLs.Get(ctx, project, service, aclID)
+}
+
+func (a *aclClientAdapter) GetSchemaRegistryACL(ctx context.Context, project, service, aclID string) (*aiven.KafkaSchemaRegistryACL, error) {
+	return a.client.KafkaSchemaRegistryACLs.Get(ctx, project, service, aclID)
+}
+
+func (a *aclClientAdapter) CreateACL(ctx context.Context, project, service string, req aiven.CreateKafkaACLRequest) (*aiven.KafkaACL, error) {
+	return a.client.KafkaACLs.Create(ctx, project, service, req)
+}
+
+func (a *aclClientAdapter) DeleteACL(ctx context.Context, project, service, aclID string) error {
+	return a.client.KafkaACLs.Delete(ctx, project, service, aclID)
+}
+
+func (a *aclClientAdapter) CreateSchemaRegistryACL(ctx context.Context, project, service string, req aiven.CreateKafkaSchemaRegistryACLRequest) (*aiven.KafkaSchemaRegistryACL, error) {
+	return a.client.KafkaSchemaRegistryACLs.Create(ctx, project, service, req)
+}
+
+func (a *aclClientAdapter) DeleteSchemaRegistryACL(ctx context.Context, project, service, aclID string) error {
+	return a.client.KafkaSchemaRegistryACLs.Delete(ctx, project, service, aclID)
+}
+
+func newRepository(client aclClient) *repository {
+	rep := &repository{client: client, now: time.Now}
+	rep.services = ttlcache.New[*serviceACLs](defaultCacheTTL, func() time.Time { return rep.now() })
+	return rep
+}
+
+// repository implements Repository
+// Reading thousands of ACLs one by one is slow, while the service endpoint returns them all at once.
+// This repository caches the service ACLs for defaultCacheTTL, and invalidates them on create and delete.
+// A read that misses the cache goes to the API, the ACL might have been created by another process.
+// Must be used as a singleton. See singleRep.
+type repository struct {
+	client aclClient
+	now    func() time.Time
+
+	// services stores ACLs by service key, see newKey
+	services *ttlcache.Cache[*serviceACLs]
+}
+
+// serviceACLs ACLs of a service
+type serviceACLs struct {
+	acls               []*aiven.KafkaACL
+	schemaRegistryACLs []*aiven.KafkaSchemaRegistryACL
+}
+
+// fetch returns the service ACLs from the cache, or calls the API if the cache is missing or expired
+func (rep *repository) fetch(ctx context.Context, project, service string) (*serviceACLs, error) {
+	return rep.services.Get(ctx, newKey(project, service), func(ctx context.Context) (*serviceACLs, error) {
+		s, err := rep.client.GetService(ctx, project, service)
+		if err != nil {
+			return nil, err
+		}
+		return &serviceACLs{acls: s.ACL, schemaRegistryACLs: s.SchemaRegistryACL}, nil
+	})
+}
+
+// invalidate removes the service ACLs from the cache, so the next read gets the fresh state
+func (rep *repository) invalidate(project, service string) {
+	rep.services.Invalidate(newKey(project, service))
+}
+
+// newKey build path-like "key" from given strings.
+func newKey(parts ...string) string {
+	return ttlcache.Key(parts...)
+}
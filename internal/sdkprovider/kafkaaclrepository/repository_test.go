@@ -0,0 +1,425 @@
+package kafkaaclrepository
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRepositoryRead tests repository read methods.
+// Uses fakeACLClient to emulate API responses.
+func TestRepositoryRead(t *testing.T) {
+	cases := []struct {
+		name              string
+		project           string
+		service           string
+		aclID             string
+		expectACL         *aiven.KafkaACL
+		expectErr         error
+		storage           map[string]*aiven.Service
+		getServiceErr     error
+		getServiceCalled  int32
+		parallelReadCount int
+	}{
+		{
+			name:    "gets existing ACL",
+			project: "a",
+			service: "b",
+			aclID:   "c",
+			storage: map[string]*aiven.Service{
+				"a/b": {ACL: []*aiven.KafkaACL{{ID: "c", Username: "d"}}},
+			},
+			expectACL:         &aiven.KafkaACL{ID: "c", Username: "d"},
+			getServiceCalled:  1,
+			parallelReadCount: 1,
+		},
+		{
+			name:    "parallel reads call the API once",
+			project: "a",
+			service: "b",
+			aclID:   "c",
+			storage: map[string]*aiven.Service{
+				"a/b": {ACL: []*aiven.KafkaACL{{ID: "c"}}},
+			},
+			expectACL:         &aiven.KafkaACL{ID: "c"},
+			getServiceCalled:  1,
+			parallelReadCount: 100,
+		},
+		{
+			name:    "unknown ACL returns 404",
+			project: "a",
+			service: "b",
+			aclID:   "d",
+			storage: map[string]*aiven.Service{
+				"a/b": {ACL: []*aiven.KafkaACL{{ID: "c"}}},
+			},
+			expectErr:         errNotFound,
+			getServiceCalled:  1,
+			parallelReadCount: 1,
+		},
+		{
+			name:    "keys do not collide",
+			project: "a",
+			service: "bc",
+			aclID:   "d",
+			storage: map[string]*aiven.Service{
+				"a/bc": {ACL: []*aiven.KafkaACL{}},
+				"ab/c": {ACL: []*aiven.KafkaACL{{ID: "d"}}},
+			},
+			expectErr:         errNotFound,
+			getServiceCalled:  1,
+			parallelReadCount: 1,
+		},
+		{
+			name:              "returns service error as is",
+			project:           "a",
+			service:           "b",
+			aclID:             "c",
+			storage:           map[string]*aiven.Service{},
+			getServiceErr:     fmt.Errorf("bla bla bla"),
+			expectErr:         fmt.Errorf("bla bla bla"),
+			getServiceCalled:  1,
+			parallelReadCount: 1,
+		},
+	}
+
+	for _, opt := range cases {
+		t.Run(opt.name, func(t *testing.T) {
+			client := &fakeACLClient{
+				storage:       opt.storage,
+				getServiceErr: opt.getServiceErr,
+			}
+			rep := newRepository(client)
+			ctx := context.Background()
+
+			var wg sync.WaitGroup
+			for i := 0; i < opt.parallelReadCount; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					acl, err := rep.ReadACL(ctx, opt.project, opt.service, opt.aclID)
+					assert.Equal(t, opt.expectACL, acl)
+					if opt.expectErr == nil {
+						assert.NoError(t, err)
+					} else {
+						assert.EqualError(t, err, opt.expectErr.Error())
+					}
+				}()
+			}
+			wg.Wait()
+			assert.Equal(t, opt.getServiceCalled, client.getServiceCalled)
+		})
+	}
+}
+
+// TestRepositorySharedCache tests that Kafka ACLs and Schema Registry ACLs share the cache
+// TestRepositoryReadMiss an ACL created by another process after the list call is read from the API
+func TestRepositoryReadMiss(t *testing.T) {
+	client := &fakeACLClient{
+		storage: map[string]*aiven.Service{
+			"a/b": {
+				ACL:               []*aiven.KafkaACL{{ID: "c"}},
+				SchemaRegistryACL: []*aiven.KafkaSchemaRegistryACL{{ID: "d"}},
+			},
+		},
+	}
+	rep := newRepository(client)
+	ctx := context.Background()
+
+	_, err := rep.ReadACL(ctx, "a", "b", "c")
+	assert.NoError(t, err)
+
+	client.storage["a/b"].ACL = append(client.storage["a/b"].ACL, &aiven.KafkaACL{ID: "e"})
+	client.storage["a/b"].SchemaRegistryACL = append(client.storage["a/b"].SchemaRegistryACL, &aiven.KafkaSchemaRegistryACL{ID: "f"})
+
+	acl, err := rep.ReadACL(ctx, "a", "b", "e")
+	assert.NoError(t, err)
+	assert.Equal(t, &aiven.KafkaACL{ID: "e"}, acl)
+
+	// The stale list is fetched again
+	srACL, err := rep.ReadSchemaRegistryACL(ctx, "a", "b", "f")
+	assert.NoError(t, err)
+	assert.Equal(t, &aiven.KafkaSchemaRegistryACL{ID: "f"}, srACL)
+	assert.EqualValues(t, 2, client.getServiceCalled)
+	assert.EqualValues(t, 1, client.getACLCalled)
+
+	// A missing ACL is the API 404
+	_, err = rep.ReadACL(ctx, "a", "b", "g")
+	assert.True(t, aiven.IsNotFound(err))
+	assert.EqualValues(t, 2, client.getACLCalled)
+}
+
+func TestRepositorySharedCache(t *testing.T) {
+	client := &fakeACLClient{
+		storage: map[string]*aiven.Service{
+			"a/b": {
+				ACL:               []*aiven.KafkaACL{{ID: "c"}},
+				SchemaRegistryACL: []*aiven.KafkaSchemaRegistryACL{{ID: "d"}},
+			},
+		},
+	}
+	rep := newRepository(client)
+	ctx := context.Background()
+
+	acl, err := rep.ReadACL(ctx, "a", "b", "c")
+	assert.NoError(t, err)
+	assert.Equal(t, "c", acl.ID)
+
+	srACL, err := rep.ReadSchemaRegistryACL(ctx, "a", "b", "d")
+	assert.NoError(t, err)
+	assert.Equal(t, "d", srACL.ID)
+	assert.EqualValues(t, 1, client.getServiceCalled)
+}
+
+// TestRepositoryTTL tests that expired cache is fetched again
+func TestRepositoryTTL(t *testing.T) {
+	client := &fakeACLClient{
+		storage: map[string]*aiven.Service{
+			"a/b": {ACL: []*aiven.KafkaACL{{ID: "c"}}},
+		},
+	}
+	now := time.Now()
+	rep := newRepository(client)
+	rep.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	_, err := rep.ReadACL(ctx, "a", "b", "c")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, client.getServiceCalled)
+
+	// Still fresh
+	now = now.Add(defaultCacheTTL - time.Second)
+	_, err = rep.ReadACL(ctx, "a", "b", "c")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, client.getServiceCalled)
+
+	// The ACL has been removed outside, and the cache has expired
+	client.storage["a/b"].ACL = nil
+	now = now.Add(time.Second)
+	_, err = rep.ReadACL(ctx, "a", "b", "c")
+	assert.ErrorIs(t, err, errNotFound)
+	assert.EqualValues(t, 2, client.getServiceCalled)
+}
+
+// TestRepositoryCreateDeleteInvalidate tests that create and delete invalidate the service cache
+func TestRepositoryCreateDeleteInvalidate(t *testing.T) {
+	client := &fakeACLClient{
+		storage: map[string]*aiven.Service{
+			"a/b": {},
+		},
+	}
+	rep := newRepository(client)
+	ctx := context.Background()
+
+	list, err := rep.ListACLs(ctx, "a", "b")
+	assert.NoError(t, err)
+	assert.Empty(t, list)
+	assert.EqualValues(t, 1, client.getServiceCalled)
+
+	acl, err := rep.CreateACL(ctx, "a", "b", aiven.CreateKafkaACLRequest{Username: "c"})
+	assert.NoError(t, err)
+
+	read, err := rep.ReadACL(ctx, "a", "b", acl.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, acl, read)
+	assert.EqualValues(t, 2, client.getServiceCalled)
+
+	srACL, err := rep.CreateSchemaRegistryACL(ctx, "a", "b", aiven.CreateKafkaSchemaRegistryACLRequest{Username: "c"})
+	assert.NoError(t, err)
+
+	srRead, err := rep.ReadSchemaRegistryACL(ctx, "a", "b", srACL.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, srACL, srRead)
+	assert.EqualValues(t, 3, client.getServiceCalled)
+
+	assert.NoError(t, rep.DeleteACL(ctx, "a", "b", acl.ID))
+	_, err = rep.ReadACL(ctx, "a", "b", acl.ID)
+	assert.ErrorIs(t, err, errNotFound)
+	assert.EqualValues(t, 4, client.getServiceCalled)
+
+	assert.NoError(t, rep.DeleteSchemaRegistryACL(ctx, "a", "b", srACL.ID))
+	_, err = rep.ReadSchemaRegistryACL(ctx, "a", "b", srACL.ID)
+	assert.ErrorIs(t, err, errNotFound)
+	assert.EqualValues(t, 5, client.getServiceCalled)
+}
+
+// TestRepositoryListCopies tests that the cache can't be modified by the caller
+func TestRepositoryListCopies(t *testing.T) {
+	client := &fakeACLClient{
+		storage: map[string]*aiven.Service{
+			"a/b": {ACL: []*aiven.KafkaACL{{ID: "c", Username: "d"}}},
+		},
+	}
+	rep := newRepository(client)
+	ctx := context.Background()
+
+	acl, err := rep.ReadACL(ctx, "a", "b", "c")
+	assert.NoError(t, err)
+	acl.Username = "e"
+
+	acl, err = rep.ReadACL(ctx, "a", "b", "c")
+	assert.NoError(t, err)
+	assert.Equal(t, "d", acl.Username)
+}
+
+// TestRepositoryFind tests that ACLs are found by their fields
+func TestRepositoryFind(t *testing.T) {
+	client := &fakeACLClient{
+		storage: map[string]*aiven.Service{
+			"a/b": {
+				ACL: []*aiven.KafkaACL{
+					{ID: "c", Permission: "read", Topic: "t", Username: "u"},
+					{ID: "d", Permission: "write", Topic: "t", Username: "u"},
+				},
+				SchemaRegistryACL: []*aiven.KafkaSchemaRegistryACL{
+					{ID: "e", Permission: "schema_registry_read", Resource: "Subject:s", Username: "u"},
+				},
+			},
+		},
+	}
+	rep := newRepository(client)
+	ctx := context.Background()
+
+	acl, err := rep.FindACL(ctx, "a", "b", "write", "t", "u")
+	assert.NoError(t, err)
+	assert.Equal(t, "d", acl.ID)
+
+	_, err = rep.FindACL(ctx, "a", "b", "admin", "t", "u")
+	assert.ErrorIs(t, err, errNotFound)
+
+	srACL, err := rep.FindSchemaRegistryACL(ctx, "a", "b", "schema_registry_read", "Subject:s", "u")
+	assert.NoError(t, err)
+	assert.Equal(t, "e", srACL.ID)
+
+	_, err = rep.FindSchemaRegistryACL(ctx, "a", "b", "schema_registry_write", "Subject:s", "u")
+	assert.ErrorIs(t, err, errNotFound)
+	assert.EqualValues(t, 1, client.getServiceCalled)
+}
+
+var _ aclClient = &fakeACLClient{}
+
+// fakeACLClient fake Aiven client ACL handlers
+type fakeACLClient struct {
+	sync.Mutex
+	// stores services as if they stored at Aiven
+	// key format: project/service
+	storage       map[string]*aiven.Service
+	getServiceErr error
+	lastID        int
+	// counters per method
+	getServiceCalled int32
+	getACLCalled     int32
+}
+
+func (f *fakeACLClient) GetService(_ context.Context, project, service string) (*aiven.Service, error) {
+	time.Sleep(time.Millisecond * 10) // we need some lag to simulate races
+	atomic.AddInt32(&f.getServiceCalled, 1)
+	if f.getServiceErr != nil {
+		return nil, f.getServiceErr
+	}
+
+	f.Lock()
+	defer f.Unlock()
+	s, ok := f.storage[newKey(project, service)]
+	if !ok {
+		return nil, aiven.Error{Status: 404}
+	}
+
+	// Returns a copy, as the API would do
+	return &aiven.Service{
+		ACL:               append([]*aiven.KafkaACL(nil), s.ACL...),
+		SchemaRegistryACL: append([]*aiven.KafkaSchemaRegistryACL(nil), s.SchemaRegistryACL...),
+	}, nil
+}
+
+func (f *fakeACLClient) GetACL(_ context.Context, project, service, aclID string) (*aiven.KafkaACL, error) {
+	atomic.AddInt32(&f.getACLCalled, 1)
+	f.Lock()
+	defer f.Unlock()
+	if s, ok := f.storage[newKey(project, service)]; ok {
+		for _, acl := range s.ACL {
+			if acl.ID == aclID {
+				v := *acl
+				return &v, nil
+			}
+		}
+	}
+	return nil, errNotFound
+}
+
+func (f *fakeACLClient) GetSchemaRegistryACL(_ context.Context, project, service, aclID string) (*aiven.KafkaSchemaRegistryACL, error) {
+	atomic.AddInt32(&f.getACLCalled, 1)
+	f.Lock()
+	defer f.Unlock()
+	if s, ok := f.storage[newKey(project, service)]; ok {
+		for _, acl := range s.SchemaRegistryACL {
+			if acl.ID == aclID {
+				v := *acl
+				return &v, nil
+			}
+		}
+	}
+	return nil, errNotFound
+}
+
+func (f *fakeACLClient) CreateACL(_ context.Context, project, service string, req aiven.CreateKafkaACLRequest) (*aiven.KafkaACL, error) {
+	f.Lock()
+	defer f.Unlock()
+	f.lastID++
+	acl := &aiven.KafkaACL{
+		ID:         fmt.Sprintf("acl%d", f.lastID),
+		Permission: req.Permission,
+		Topic:      req.Topic,
+		Username:   req.Username,
+	}
+	s := f.storage[newKey(project, service)]
+	s.ACL = append(s.ACL, acl)
+	return &aiven.KafkaACL{ID: acl.ID, Permission: acl.Permission, Topic: acl.Topic, Username: acl.Username}, nil
+}
+
+func (f *fakeACLClient) DeleteACL(_ context.Context, project, service, aclID string) error {
+	f.Lock()
+	defer f.Unlock()
+	s := f.storage[newKey(project, service)]
+	for i, acl := range s.ACL {
+		if acl.ID == aclID {
+			s.ACL = append(s.ACL[:i], s.ACL[i+1:]...)
+			return nil
+		}
+	}
+	return aiven.Error{Status: 404}
+}
+
+func (f *fakeACLClient) CreateSchemaRegistryACL(_ context.Context, project, service string, req aiven.CreateKafkaSchemaRegistryACLRequest) (*aiven.KafkaSchemaRegistryACL, error) {
+	f.Lock()
+	defer f.Unlock()
+	f.lastID++
+	acl := &aiven.KafkaSchemaRegistryACL{
+		ID:         fmt.Sprintf("acl%d", f.lastID),
+		Permission: req.Permission,
+		Resource:   req.Resource,
+		Username:   req.Username,
+	}
+	s := f.storage[newKey(project, service)]
+	s.SchemaRegistryACL = append(s.SchemaRegistryACL, acl)
+	return &aiven.KafkaSchemaRegistryACL{ID: acl.ID, Permission: acl.Permission, Resource: acl.Resource, Username: acl.Username}, nil
+}
+
+func (f *fakeACLClient) DeleteSchemaRegistryACL(_ context.Context, project, service, aclID string) error {
+	f.Lock()
+	defer f.Unlock()
+	s := f.storage[newKey(project, service)]
+	for i, acl := range s.SchemaRegistryACL {
+		if acl.ID == aclID {
+			s.SchemaRegistryACL = append(s.SchemaRegistryACL[:i], s.SchemaRegistryACL[i+1:]...)
+			return nil
+		}
+	}
+	return aiven.Error{Status: 404}
+}
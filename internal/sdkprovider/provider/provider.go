@@ -125,22 +125,28 @@ func Provider(version string) *schema.Provider {
 			"aiven_opensearch_security_plugin_config": opensearch.DatasourceOpenSearchSecurityPluginConfig(),
 
 			// kafka
-			"aiven_kafka":                        kafka.DatasourceKafka(),
-			"aiven_kafka_user":                   kafka.DatasourceKafkaUser(),
-			"aiven_kafka_acl":                    kafka.DatasourceKafkaACL(),
-			"aiven_kafka_schema_registry_acl":    kafkaschema.DatasourceKafkaSchemaRegistryACL(),
-			"aiven_kafka_topic":                  kafkatopic.DatasourceKafkaTopic(),
-			"aiven_kafka_schema":                 kafkaschema.DatasourceKafkaSchema(),
-			"aiven_kafka_schema_configuration":   kafkaschema.DatasourceKafkaSchemaConfiguration(),
-			"aiven_kafka_connector":              kafka.DatasourceKafkaConnector(),
-			"aiven_mirrormaker_replication_flow": kafka.DatasourceMirrorMakerReplicationFlowTopic(),
-			"aiven_kafka_connect":                kafka.DatasourceKafkaConnect(),
-			"aiven_kafka_mirrormaker":            kafka.DatasourceKafkaMirrormaker(),
+			"aiven_kafka":                         kafka.DatasourceKafka(),
+			"aiven_kafka_user":                    kafka.DatasourceKafkaUser(),
+			"aiven_kafka_acl":                     kafka.DatasourceKafkaACL(),
+			"aiven_kafka_native_acl":              kafka.DatasourceKafkaNativeACL(),
+			"aiven_kafka_quota":                   kafka.DatasourceKafkaQuota(),
+			"aiven_kafka_schema_registry_acl":     kafkaschema.DatasourceKafkaSchemaRegistryACL(),
+			"aiven_kafka_topic":                   kafkatopic.DatasourceKafkaTopic(),
+			"aiven_kafka_schema":                  kafkaschema.DatasourceKafkaSchema(),
+			"aiven_kafka_schema_configuration":    kafkaschema.DatasourceKafkaSchemaConfiguration(),
+			"aiven_kafka_connector":               kafka.DatasourceKafkaConnector(),
+			"aiven_kafka_connect_plugins":         kafka.DatasourceKafkaConnectPlugins(),
+			"aiven_kafka_consumer_groups":         kafka.DatasourceKafkaConsumerGroups(),
+			"aiven_mirrormaker_replication_flow":  kafka.DatasourceMirrorMakerReplicationFlowTopic(),
+			"aiven_mirrormaker_replication_flows": kafka.DatasourceMirrorMakerReplicationFlows(),
+			"aiven_kafka_connect":                 kafka.DatasourceKafkaConnect(),
+			"aiven_kafka_mirrormaker":             kafka.DatasourceKafkaMirrormaker(),
 
 			// clickhouse
 			"aiven_clickhouse":          clickhouse.DatasourceClickhouse(),
 			"aiven_clickhouse_database": clickhouse.DatasourceClickhouseDatabase(),
 			"aiven_clickhouse_user":     clickhouse.DatasourceClickhouseUser(),
+			"aiven_clickhouse_query":    clickhouse.DatasourceClickhouseQuery(),
 
 			// dragonfly
 			// TODO: uncomment when dragonfly is supported
@@ -163,15 +169,19 @@ func Provider(version string) *schema.Provider {
 			"aiven_mysql":          mysql.ResourceMySQL(),
 			"aiven_mysql_user":     mysql.ResourceMySQLUser(),
 			"aiven_mysql_database": mysql.ResourceMySQLDatabase(),
+			"aiven_mysql_grant":    mysql.ResourceMySQLGrant(),
 
 			// redis
 			"aiven_redis":      redis.ResourceRedis(),
 			"aiven_redis_user": redis.ResourceRedisUser(),
 
 			// pg
-			"aiven_pg":          pg.ResourcePG(),
-			"aiven_pg_user":     pg.ResourcePGUser(),
-			"aiven_pg_database": pg.ResourcePGDatabase(),
+			"aiven_pg":           pg.ResourcePG(),
+			"aiven_pg_user":      pg.ResourcePGUser(),
+			"aiven_pg_database":  pg.ResourcePGDatabase(),
+			"aiven_pg_grant":     pg.ResourcePGGrant(),
+			"aiven_pg_extension": pg.ResourcePGExtension(),
+			"aiven_pg_schema":    pg.ResourcePGSchema(),
 
 			// cassandra
 			"aiven_cassandra":      cassandra.ResourceCassandra(),
@@ -226,27 +236,40 @@ func Provider(version string) *schema.Provider {
 			"aiven_opensearch_user":                   opensearch.ResourceOpenSearchUser(),
 			"aiven_opensearch_acl_config":             opensearch.ResourceOpenSearchACLConfig(),
 			"aiven_opensearch_acl_rule":               opensearch.ResourceOpenSearchACLRule(),
+			"aiven_opensearch_acl_rules":              opensearch.ResourceOpenSearchACLRules(),
 			"aiven_opensearch_security_plugin_config": opensearch.ResourceOpenSearchSecurityPluginConfig(),
+			"aiven_opensearch_security_role":          opensearch.ResourceOpenSearchSecurityRole(),
+			"aiven_opensearch_security_role_mapping":  opensearch.ResourceOpenSearchSecurityRoleMapping(),
+			"aiven_opensearch_snapshot_repository":    opensearch.ResourceOpenSearchSnapshotRepository(),
+			"aiven_opensearch_ism_policy":             opensearch.ResourceOpenSearchISMPolicy(),
 
 			// kafka
 			"aiven_kafka":                        kafka.ResourceKafka(),
 			"aiven_kafka_user":                   kafka.ResourceKafkaUser(),
 			"aiven_kafka_acl":                    kafka.ResourceKafkaACL(),
+			"aiven_kafka_native_acl":             kafka.ResourceKafkaNativeACL(),
+			"aiven_kafka_quota":                  kafka.ResourceKafkaQuota(),
 			"aiven_kafka_schema_registry_acl":    kafkaschema.ResourceKafkaSchemaRegistryACL(),
 			"aiven_kafka_topic":                  kafkatopic.ResourceKafkaTopic(),
 			"aiven_kafka_schema":                 kafkaschema.ResourceKafkaSchema(),
 			"aiven_kafka_schema_configuration":   kafkaschema.ResourceKafkaSchemaConfiguration(),
 			"aiven_kafka_connector":              kafka.ResourceKafkaConnector(),
+			"aiven_kafka_consumer_group_offsets": kafka.ResourceKafkaConsumerGroupOffsets(),
 			"aiven_mirrormaker_replication_flow": kafka.ResourceMirrorMakerReplicationFlow(),
 			"aiven_kafka_connect":                kafka.ResourceKafkaConnect(),
 			"aiven_kafka_mirrormaker":            kafka.ResourceKafkaMirrormaker(),
 
 			// clickhouse
-			"aiven_clickhouse":          clickhouse.ResourceClickhouse(),
-			"aiven_clickhouse_database": clickhouse.ResourceClickhouseDatabase(),
-			"aiven_clickhouse_user":     clickhouse.ResourceClickhouseUser(),
-			"aiven_clickhouse_role":     clickhouse.ResourceClickhouseRole(),
-			"aiven_clickhouse_grant":    clickhouse.ResourceClickhouseGrant(),
+			"aiven_clickhouse":                  clickhouse.ResourceClickhouse(),
+			"aiven_clickhouse_database":         clickhouse.ResourceClickhouseDatabase(),
+			"aiven_clickhouse_user":             clickhouse.ResourceClickhouseUser(),
+			"aiven_clickhouse_role":             clickhouse.ResourceClickhouseRole(),
+			"aiven_clickhouse_grant":            clickhouse.ResourceClickhouseGrant(),
+			"aiven_clickhouse_row_policy":       clickhouse.ResourceClickhouseRowPolicy(),
+			"aiven_clickhouse_settings_profile": clickhouse.ResourceClickhouseSettingsProfile(),
+			"aiven_clickhouse_quota":            clickhouse.ResourceClickhouseQuota(),
+			"aiven_clickhouse_table":            clickhouse.ResourceClickhouseTable(),
+			"aiven_clickhouse_view":             clickhouse.ResourceClickhouseView(),
 
 			// dragonfly
 			// TODO: uncomment when dragonfly is supported
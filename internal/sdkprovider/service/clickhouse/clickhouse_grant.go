@@ -13,8 +13,6 @@ import (
 	"github.com/aiven/terraform-provider-aiven/internal/schemautil/userconfig"
 )
 
-// TODO: set 'ForceNew' for now so we recreate the whole thing to manage updates, not great but at least converges
-
 var aivenClickhouseGrantSchema = map[string]*schema.Schema{
 	"project":      schemautil.CommonSchemaProjectReference,
 	"service_name": schemautil.CommonSchemaServiceNameReference,
@@ -34,59 +32,51 @@ var aivenClickhouseGrantSchema = map[string]*schema.Schema{
 		ConflictsWith: []string{"user"},
 	},
 	"privilege_grant": {
-		Description: userconfig.Desc("Configuration to grant a privilege.").ForceNew().Build(),
+		Description: userconfig.Desc("Configuration to grant a privilege.").Build(),
 		Type:        schema.TypeSet,
 		Optional:    true,
-		ForceNew:    true,
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"privilege": {
-					Description:  userconfig.Desc("The privilege to grant, i.e. 'INSERT', 'SELECT', etc.").ForceNew().Build(),
+					Description:  userconfig.Desc("The privilege to grant, i.e. 'INSERT', 'SELECT', etc.").Build(),
 					Type:         schema.TypeString,
 					Optional:     true,
-					ForceNew:     true,
 					ValidateFunc: validation.StringMatch(regexp.MustCompile("^[A-Z ]+$"), "Must be a phrase of words that contain only uppercase letters."),
 				},
 				"database": {
-					Description: userconfig.Desc("The database that the grant refers to.").Referenced().ForceNew().Build(),
+					Description: userconfig.Desc("The database that the grant refers to.").Referenced().Build(),
 					Type:        schema.TypeString,
 					Required:    true,
-					ForceNew:    true,
 				},
 				"table": {
-					Description: userconfig.Desc("The table that the grant refers to.").ForceNew().Build(),
+					Description: userconfig.Desc("The table that the grant refers to.").Build(),
 					Type:        schema.TypeString,
 					Optional:    true,
-					ForceNew:    true,
 				},
 				"column": {
-					Description: userconfig.Desc("The column that the grant refers to.").ForceNew().Build(),
+					Description: userconfig.Desc("The column that the grant refers to.").Build(),
 					Type:        schema.TypeString,
 					Optional:    true,
-					ForceNew:    true,
 				},
 				"with_grant": {
-					Description: userconfig.Desc("If true then the grantee gets the ability to grant the privileges he received too").ForceNew().Build(),
+					Description: userconfig.Desc("If true then the grantee gets the ability to grant the privileges he received too").Build(),
 					Type:        schema.TypeBool,
 					Optional:    true,
-					ForceNew:    true,
 					Default:     false,
 				},
 			},
 		},
 	},
 	"role_grant": {
-		Description: userconfig.Desc("Configuration to grant a role.").ForceNew().Build(),
+		Description: userconfig.Desc("Configuration to grant a role.").Build(),
 		Type:        schema.TypeSet,
 		Optional:    true,
-		ForceNew:    true,
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"role": {
-					Description: userconfig.Desc("The role that is to be granted.").Referenced().ForceNew().Build(),
+					Description: userconfig.Desc("The role that is to be granted.").Referenced().Build(),
 					Type:        schema.TypeString,
 					Optional:    true,
-					ForceNew:    true,
 				},
 			},
 		},
@@ -100,10 +90,11 @@ func ResourceClickhouseGrant() *schema.Resource {
 Notes:
 * Due to a ambiguity in the GRANT syntax in clickhouse you should not have users and roles with the same name. It is not clear if a grant refers to the user or the role.
 * To grant a privilege on all tables of a database, do not write table = "*". Instead, omit the table and only keep the database.
-* Currently changes will first revoke all grants and then reissue the remaining grants for convergence.
+* Changes revoke the removed grants first and then issue the added grants, the grants that are kept are not touched, unless a revoke on their database or table removes them too, then they are granted again.
 `,
 		CreateContext: resourceClickhouseGrantCreate,
 		ReadContext:   resourceClickhouseGrantRead,
+		UpdateContext: resourceClickhouseGrantUpdate,
 		DeleteContext: resourceClickhouseGrantDelete,
 		Schema:        aivenClickhouseGrantSchema,
 		Timeouts:      schemautil.DefaultResourceTimeouts(),
@@ -175,7 +166,7 @@ func resourceClickhouseGrantRead(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
-	grantee := Grantee{User: d.Get("user").(string), Role: d.Get("role").(string)}
+	grantee := granteeFromSchema(d)
 
 	privilegeGrants, err := ReadPrivilegeGrants(ctx, client, projectName, serviceName, grantee)
 	if err != nil {
@@ -195,6 +186,60 @@ func resourceClickhouseGrantRead(ctx context.Context, d *schema.ResourceData, m
 	return nil
 }
 
+func resourceClickhouseGrantUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	grantee := granteeFromSchema(d)
+
+	// Revokes go first, e.g. revoking a privilege on a database also revokes the privilege on its tables
+	if d.HasChange("privilege_grant") {
+		o, n := d.GetChange("privilege_grant")
+		diff := diffPrivilegeGrants(
+			privilegeGrantsFromSet(grantee, o.(*schema.Set)),
+			privilegeGrantsFromSet(grantee, n.(*schema.Set)),
+		)
+
+		for _, grant := range diff.Revoke {
+			if err := RevokePrivilegeGrant(ctx, client, projectName, serviceName, grant); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		for _, grant := range diff.RevokeGrantOption {
+			if err := RevokePrivilegeGrantOption(ctx, client, projectName, serviceName, grant); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		for _, grant := range diff.Grant {
+			if err := CreatePrivilegeGrant(ctx, client, projectName, serviceName, grant); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	if d.HasChange("role_grant") {
+		o, n := d.GetChange("role_grant")
+		revoke, grant := diffRoleGrants(
+			roleGrantsFromSet(grantee, o.(*schema.Set)),
+			roleGrantsFromSet(grantee, n.(*schema.Set)),
+		)
+
+		for _, g := range revoke {
+			if err := RevokeRoleGrant(ctx, client, projectName, serviceName, g); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		for _, g := range grant {
+			if err := CreateRoleGrant(ctx, client, projectName, serviceName, g); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	return resourceClickhouseGrantRead(ctx, d, m)
+}
+
 func resourceClickhouseGrantDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*aiven.Client)
 
@@ -216,17 +261,25 @@ func resourceClickhouseGrantDelete(ctx context.Context, d *schema.ResourceData,
 	return nil
 }
 
-func readPrivilegeGrantsFromSchema(d *schema.ResourceData) (grants []PrivilegeGrant) {
+func granteeFromSchema(d *schema.ResourceData) Grantee {
+	return Grantee{
+		User: d.Get("user").(string),
+		Role: d.Get("role").(string),
+	}
+}
+
+func readPrivilegeGrantsFromSchema(d *schema.ResourceData) []PrivilegeGrant {
+	return privilegeGrantsFromSet(granteeFromSchema(d), d.Get("privilege_grant").(*schema.Set))
+}
+
+func privilegeGrantsFromSet(grantee Grantee, set *schema.Set) (grants []PrivilegeGrant) {
 	grants = make([]PrivilegeGrant, 0)
 
-	for _, grant := range d.Get("privilege_grant").(*schema.Set).List() {
+	for _, grant := range set.List() {
 		grantVal := grant.(map[string]interface{})
 
 		grants = append(grants, PrivilegeGrant{
-			Grantee: Grantee{
-				User: d.Get("user").(string),
-				Role: d.Get("role").(string),
-			},
+			Grantee:   grantee,
 			Database:  grantVal["database"].(string),
 			Table:     grantVal["table"].(string),
 			Column:    grantVal["column"].(string),
@@ -255,18 +308,19 @@ func privilegeGrantToSchema(grant PrivilegeGrant) map[string]interface{} {
 	}
 }
 
-func readRoleGrantsFromSchema(d *schema.ResourceData) (grants []RoleGrant) {
+func readRoleGrantsFromSchema(d *schema.ResourceData) []RoleGrant {
+	return roleGrantsFromSet(granteeFromSchema(d), d.Get("role_grant").(*schema.Set))
+}
+
+func roleGrantsFromSet(grantee Grantee, set *schema.Set) (grants []RoleGrant) {
 	grants = make([]RoleGrant, 0)
 
-	for _, grant := range d.Get("role_grant").(*schema.Set).List() {
+	for _, grant := range set.List() {
 		grantVal := grant.(map[string]interface{})
 
 		grants = append(grants, RoleGrant{
-			Grantee: Grantee{
-				User: d.Get("user").(string),
-				Role: d.Get("role").(string),
-			},
-			Role: grantVal["role"].(string),
+			Grantee: grantee,
+			Role:    grantVal["role"].(string),
 		})
 	}
 	return grants
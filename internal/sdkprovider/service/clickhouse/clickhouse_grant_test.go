@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/aiven/aiven-go-client/v2"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 
 	acc "github.com/aiven/terraform-provider-aiven/internal/acctest"
@@ -71,6 +73,18 @@ resource "aiven_clickhouse_grant" "foo-user-grant" {
   }
 }`, projectName, serviceName)
 
+	// Adds a privilege in place, the INSERT grant is kept
+	updatedManifest := strings.Replace(manifest, `    column    = "test-column"
+  }
+`, `    column    = "test-column"
+  }
+
+  privilege_grant {
+    privilege = "SELECT"
+    database  = aiven_clickhouse_database.testdb.name
+  }
+`, 1)
+
 	resource.ParallelTest(t, resource.TestCase{
 		PreCheck:                 func() { acc.TestAccPreCheck(t) },
 		ProtoV6ProviderFactories: acc.TestProtoV6ProviderFactories,
@@ -89,6 +103,26 @@ resource "aiven_clickhouse_grant" "foo-user-grant" {
 					resource.TestCheckResourceAttr("aiven_clickhouse_grant.foo-user-grant", "role_grant.0.role", "foo-role"),
 				),
 			},
+			{
+				Config: updatedManifest,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("aiven_clickhouse_grant.foo-role-grant", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aiven_clickhouse_grant.foo-role-grant", "privilege_grant.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("aiven_clickhouse_grant.foo-role-grant", "privilege_grant.*", map[string]string{
+						"privilege": "SELECT",
+						"database":  "test-db",
+						"table":     "",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("aiven_clickhouse_grant.foo-role-grant", "privilege_grant.*", map[string]string{
+						"privilege": "INSERT",
+						"column":    "test-column",
+					}),
+				),
+			},
 		},
 	})
 }
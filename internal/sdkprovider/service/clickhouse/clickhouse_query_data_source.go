@@ -0,0 +1,156 @@
+package clickhouse
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil/userconfig"
+)
+
+func DatasourceClickhouseQuery() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceClickhouseQueryRead,
+		Description: "The Clickhouse Query data source runs a read-only query in an Aiven Clickhouse service " +
+			"and returns its columns and rows. Only SELECT, SHOW and DESCRIBE statements are allowed.",
+		Schema: map[string]*schema.Schema{
+			"project":      schemautil.CommonSchemaProjectReference,
+			"service_name": schemautil.CommonSchemaServiceNameReference,
+
+			"database": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     defaultDatabase,
+				Description: userconfig.Desc("The default database of the query.").DefaultValue(defaultDatabase).Build(),
+			},
+			"query": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The SELECT, SHOW or DESCRIBE statement to run, e.g. `SELECT name FROM system.databases`.",
+			},
+			"meta": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The columns of the result.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the column.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ClickHouse type of the column, e.g. `UInt64`.",
+						},
+					},
+				},
+			},
+			"rows": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Description: "The rows of the result, the values are strings in the order of `meta`. " +
+					"Arrays, tuples and maps are JSON encoded, nulls are empty strings.",
+				Elem: &schema.Schema{
+					Type: schema.TypeList,
+					Elem: &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+func datasourceClickhouseQueryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	database := d.Get("database").(string)
+	query := d.Get("query").(string)
+
+	if err := validateReadOnlyStatement(query); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Println("[DEBUG] Clickhouse: data source query: ", query)
+	r, err := client.ClickHouseQuery.Query(ctx, projectName, serviceName, database, query)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	meta := make([]map[string]interface{}, len(r.Meta))
+	for i, md := range r.Meta {
+		meta[i] = map[string]interface{}{
+			"name": md.Name,
+			"type": md.Type,
+		}
+	}
+
+	rows, err := flattenQueryRows(r.Data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(clickhouseQueryID(projectName, serviceName, database, query))
+
+	if err := d.Set("meta", meta); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("rows", rows); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// flattenQueryRows converts the values of the rows to strings
+func flattenQueryRows(data []interface{}) ([][]string, error) {
+	rows := make([][]string, len(data))
+	for i := range data {
+		columns, ok := data[i].([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("row %d is not a list of columns", i)
+		}
+
+		rows[i] = make([]string, len(columns))
+		for j, v := range columns {
+			s, err := queryValueString(v)
+			if err != nil {
+				return nil, fmt.Errorf("row %d column %d: %w", i, j, err)
+			}
+			rows[i][j] = s
+		}
+	}
+	return rows, nil
+}
+
+func queryValueString(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// clickhouseQueryID the data sources of the same database with different queries must have different IDs
+func clickhouseQueryID(projectName, serviceName, database, query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return schemautil.BuildResourceID(projectName, serviceName, database, hex.EncodeToString(sum[:]))
+}
@@ -0,0 +1,65 @@
+package clickhouse_test
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	acc "github.com/aiven/terraform-provider-aiven/internal/acctest"
+)
+
+func TestAccAivenClickhouseQueryDataSource(t *testing.T) {
+	serviceName := fmt.Sprintf("test-acc-ch-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	projectName := os.Getenv("AIVEN_PROJECT_NAME")
+	dataSourceName := "data.aiven_clickhouse_query.foo"
+
+	manifest := func(query string) string {
+		return fmt.Sprintf(`
+resource "aiven_clickhouse" "bar" {
+  project                 = "%s"
+  cloud_name              = "google-europe-west1"
+  plan                    = "startup-16"
+  service_name            = "%s"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+}
+
+resource "aiven_clickhouse_database" "foo" {
+  service_name = aiven_clickhouse.bar.service_name
+  project      = aiven_clickhouse.bar.project
+  name         = "tenant_42"
+}
+
+data "aiven_clickhouse_query" "foo" {
+  service_name = aiven_clickhouse_database.foo.service_name
+  project      = aiven_clickhouse_database.foo.project
+  query        = "%s"
+}`, projectName, serviceName, query)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acc.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acc.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: manifest("SELECT name, 1 AS one FROM system.databases WHERE name LIKE 'tenant_%'"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "meta.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "meta.0.name", "name"),
+					resource.TestCheckResourceAttr(dataSourceName, "meta.1.type", "UInt8"),
+					resource.TestCheckResourceAttr(dataSourceName, "rows.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "rows.0.0", "tenant_42"),
+					resource.TestCheckResourceAttr(dataSourceName, "rows.0.1", "1"),
+				),
+			},
+			{
+				Config:      manifest("DROP DATABASE tenant_42"),
+				ExpectError: regexp.MustCompile(`only SELECT, SHOW, DESCRIBE, DESC statements are allowed`),
+			},
+		},
+	})
+}
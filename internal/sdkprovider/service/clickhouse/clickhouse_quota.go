@@ -0,0 +1,202 @@
+package clickhouse
+
+import (
+	"context"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil/userconfig"
+)
+
+// quotaIntervalSchema the interval with its limits, one field per limit
+func quotaIntervalSchema() map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"duration": {
+			Type:         schema.TypeInt,
+			Required:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  "The length of the interval in seconds.",
+		},
+		"randomized": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: userconfig.Desc("If true, the interval starts at a random time.").DefaultValue(false).Build(),
+		},
+	}
+
+	for _, l := range quotaLimits {
+		s[l] = &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  userconfig.Desc("The maximum `" + l + "` in the interval, zero means no limit.").Build(),
+		}
+	}
+	return s
+}
+
+var aivenClickhouseQuotaSchema = map[string]*schema.Schema{
+	"project":      schemautil.CommonSchemaProjectReference,
+	"service_name": schemautil.CommonSchemaServiceNameReference,
+
+	"name": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: userconfig.Desc("The name of the quota.").ForceNew().Build(),
+	},
+	"key_type": {
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validation.StringInSlice(quotaKeyTypes, false),
+		Description: userconfig.Desc("How the usage is tracked, e.g. by user name. " +
+			"If not set, the usage of all the users the quota applies to is tracked together.").
+			PossibleValues(schemautil.StringSliceToInterfaceSlice(quotaKeyTypes)...).
+			Build(),
+	},
+	"interval": {
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Description: "The limits per interval. An interval without limits only tracks the usage.",
+		Elem: &schema.Resource{
+			Schema: quotaIntervalSchema(),
+		},
+	},
+	"apply_to": applyToSchema("quota"),
+}
+
+func ResourceClickhouseQuota() *schema.Resource {
+	return &schema.Resource{
+		Description:   "The Clickhouse Quota resource allows the creation and management of Quotas in Aiven Clickhouse services.",
+		CreateContext: resourceClickhouseQuotaCreate,
+		ReadContext:   resourceClickhouseQuotaRead,
+		UpdateContext: resourceClickhouseQuotaUpdate,
+		DeleteContext: resourceClickhouseQuotaDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: schemautil.DefaultResourceTimeouts(),
+
+		Schema: aivenClickhouseQuotaSchema,
+	}
+}
+
+func resourceClickhouseQuotaCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	quota := readQuotaFromSchema(d, d.Get("interval"))
+
+	if err := CreateQuota(ctx, client, projectName, serviceName, quota); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(projectName, serviceName, quota.Name))
+
+	return resourceClickhouseQuotaRead(ctx, d, m)
+}
+
+func resourceClickhouseQuotaRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, name, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	quota, err := ReadQuota(ctx, client, projectName, serviceName, name)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	intervals := make([]map[string]interface{}, len(quota.Intervals))
+	for i, interval := range quota.Intervals {
+		v := map[string]interface{}{
+			"duration":   interval.Duration,
+			"randomized": interval.Randomized,
+		}
+		for _, l := range quotaLimits {
+			v[l] = interval.Limits[l]
+		}
+		intervals[i] = v
+	}
+
+	if err := d.Set("project", projectName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("name", quota.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("key_type", quota.KeyType); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("interval", intervals); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("apply_to", quota.ApplyTo); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceClickhouseQuotaUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+
+	o, n := d.GetChange("interval")
+	err := AlterQuota(ctx, client, projectName, serviceName, readQuotaFromSchema(d, o), readQuotaFromSchema(d, n))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceClickhouseQuotaRead(ctx, d, m)
+}
+
+func resourceClickhouseQuotaDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, name, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := DropQuota(ctx, client, projectName, serviceName, name); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// readQuotaFromSchema returns the quota with the given intervals, e.g. the old ones on update
+func readQuotaFromSchema(d *schema.ResourceData, intervals interface{}) Quota {
+	quota := Quota{
+		Name:      d.Get("name").(string),
+		KeyType:   d.Get("key_type").(string),
+		Intervals: make([]QuotaInterval, 0),
+		ApplyTo:   readApplyToFromSchema(d),
+	}
+
+	for _, v := range intervals.(*schema.Set).List() {
+		i := v.(map[string]interface{})
+		interval := QuotaInterval{
+			Duration:   i["duration"].(int),
+			Randomized: i["randomized"].(bool),
+			Limits:     make(map[string]int),
+		}
+		for _, l := range quotaLimits {
+			interval.Limits[l] = i[l].(int)
+		}
+		quota.Intervals = append(quota.Intervals, interval)
+	}
+	return quota
+}
@@ -0,0 +1,97 @@
+package clickhouse_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	acc "github.com/aiven/terraform-provider-aiven/internal/acctest"
+)
+
+func TestAccAivenClickhouseQuota(t *testing.T) {
+	serviceName := fmt.Sprintf("test-acc-ch-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	projectName := os.Getenv("AIVEN_PROJECT_NAME")
+	resourceName := "aiven_clickhouse_quota.foo"
+
+	manifest := func(intervals string) string {
+		return fmt.Sprintf(`
+resource "aiven_clickhouse" "bar" {
+  project                 = "%s"
+  cloud_name              = "google-europe-west1"
+  plan                    = "startup-16"
+  service_name            = "%s"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+}
+
+resource "aiven_clickhouse_user" "foo" {
+  service_name = aiven_clickhouse.bar.service_name
+  project      = aiven_clickhouse.bar.project
+  username     = "analyst"
+}
+
+resource "aiven_clickhouse_quota" "foo" {
+  service_name = aiven_clickhouse.bar.service_name
+  project      = aiven_clickhouse.bar.project
+  name         = "daily"
+  key_type     = "user_name"
+  apply_to     = [aiven_clickhouse_user.foo.username]
+  %s
+}`, projectName, serviceName, intervals)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acc.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acc.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: manifest(`
+  interval {
+    duration = 86400
+    queries  = 1000
+    errors   = 10
+  }
+
+  interval {
+    duration   = 3600
+    randomized = true
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "daily"),
+					resource.TestCheckResourceAttr(resourceName, "key_type", "user_name"),
+					resource.TestCheckResourceAttr(resourceName, "interval.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "interval.*", map[string]string{
+						"duration": "86400",
+						"queries":  "1000",
+						"errors":   "10",
+					}),
+					resource.TestCheckTypeSetElemAttr(resourceName, "apply_to.*", "analyst"),
+				),
+			},
+			{
+				// Removes the hourly interval and the errors limit
+				Config: manifest(`
+  interval {
+    duration = 86400
+    queries  = 500
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "interval.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "interval.*", map[string]string{
+						"duration": "86400",
+						"queries":  "500",
+						"errors":   "0",
+					}),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,183 @@
+package clickhouse
+
+import (
+	"context"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil/userconfig"
+)
+
+var aivenClickhouseRowPolicySchema = map[string]*schema.Schema{
+	"project":      schemautil.CommonSchemaProjectReference,
+	"service_name": schemautil.CommonSchemaServiceNameReference,
+
+	"name": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: userconfig.Desc("The name of the row policy.").ForceNew().Build(),
+	},
+	"database": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: userconfig.Desc("The database of the table.").Referenced().ForceNew().Build(),
+	},
+	"table": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: userconfig.Desc("The table the policy filters the rows of.").ForceNew().Build(),
+	},
+	"condition": {
+		Type:     schema.TypeString,
+		Required: true,
+		Description: "The SQL expression the rows must match to be selected, e.g. `tenant_id = 42`. " +
+			"ClickHouse reformats it, see `formatted_condition`.",
+	},
+	"formatted_condition": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The condition as formatted by ClickHouse, a change of it outside of Terraform is shown as drift.",
+	},
+	"restrictive": {
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+		Description: userconfig.Desc("If true, the policy is combined with the other policies of the table with AND, " +
+			"otherwise with OR.").DefaultValue(false).Build(),
+	},
+	"apply_to": applyToSchema("policy"),
+}
+
+func ResourceClickhouseRowPolicy() *schema.Resource {
+	return &schema.Resource{
+		Description: `The Clickhouse Row Policy resource allows the creation and management of row-level security policies in Aiven Clickhouse services.
+
+Notes:
+* A user that has no policy on a table that has policies, doesn't see any rows of it. Use a permissive policy with condition "1" to allow all rows.
+`,
+		CreateContext: resourceClickhouseRowPolicyCreate,
+		ReadContext:   resourceClickhouseRowPolicyRead,
+		UpdateContext: resourceClickhouseRowPolicyUpdate,
+		DeleteContext: resourceClickhouseRowPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: schemautil.DefaultResourceTimeouts(),
+
+		Schema: aivenClickhouseRowPolicySchema,
+	}
+}
+
+func resourceClickhouseRowPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	policy := readRowPolicyFromSchema(d)
+
+	if err := CreateRowPolicy(ctx, client, projectName, serviceName, policy); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(projectName, serviceName, policy.Database, policy.Table, policy.Name))
+
+	return resourceClickhouseRowPolicyRead(ctx, d, m)
+}
+
+func resourceClickhouseRowPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	parts, err := schemautil.SplitResourceID(d.Id(), 5)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	projectName, serviceName, database, table, name := parts[0], parts[1], parts[2], parts[3], parts[4]
+
+	policy, err := ReadRowPolicy(ctx, client, projectName, serviceName, name, database, table)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	// The condition is kept as it is in the config, unless it is changed outside of Terraform or imported
+	formatted := d.Get("formatted_condition").(string)
+	if d.Get("condition").(string) == "" || (formatted != "" && formatted != policy.Condition) {
+		if err := d.Set("condition", policy.Condition); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err := d.Set("project", projectName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("name", policy.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("database", policy.Database); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("table", policy.Table); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("formatted_condition", policy.Condition); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("restrictive", policy.Restrictive); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("apply_to", policy.ApplyTo); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceClickhouseRowPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+
+	if err := AlterRowPolicy(ctx, client, projectName, serviceName, readRowPolicyFromSchema(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The new condition is formatted differently, it is not a change outside of Terraform
+	if err := d.Set("formatted_condition", ""); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceClickhouseRowPolicyRead(ctx, d, m)
+}
+
+func resourceClickhouseRowPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	parts, err := schemautil.SplitResourceID(d.Id(), 5)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := DropRowPolicy(ctx, client, parts[0], parts[1], parts[4], parts[2], parts[3]); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func readRowPolicyFromSchema(d *schema.ResourceData) RowPolicy {
+	return RowPolicy{
+		Name:        d.Get("name").(string),
+		Database:    d.Get("database").(string),
+		Table:       d.Get("table").(string),
+		Condition:   d.Get("condition").(string),
+		Restrictive: d.Get("restrictive").(bool),
+		ApplyTo:     readApplyToFromSchema(d),
+	}
+}
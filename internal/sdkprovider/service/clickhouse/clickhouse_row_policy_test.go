@@ -0,0 +1,88 @@
+package clickhouse_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	acc "github.com/aiven/terraform-provider-aiven/internal/acctest"
+)
+
+func TestAccAivenClickhouseRowPolicy(t *testing.T) {
+	serviceName := fmt.Sprintf("test-acc-ch-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	projectName := os.Getenv("AIVEN_PROJECT_NAME")
+	resourceName := "aiven_clickhouse_row_policy.foo"
+
+	manifest := func(condition string) string {
+		return fmt.Sprintf(`
+resource "aiven_clickhouse" "bar" {
+  project                 = "%s"
+  cloud_name              = "google-europe-west1"
+  plan                    = "startup-16"
+  service_name            = "%s"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+}
+
+resource "aiven_clickhouse_database" "testdb" {
+  project      = aiven_clickhouse.bar.project
+  service_name = aiven_clickhouse.bar.service_name
+  name         = "test-db"
+}
+
+resource "aiven_clickhouse_role" "reader" {
+  service_name = aiven_clickhouse.bar.service_name
+  project      = aiven_clickhouse.bar.project
+  role         = "reader"
+}
+
+resource "aiven_clickhouse_row_policy" "foo" {
+  service_name = aiven_clickhouse.bar.service_name
+  project      = aiven_clickhouse.bar.project
+  name         = "tenant"
+  database     = aiven_clickhouse_database.testdb.name
+  table        = "events"
+  condition    = "%s"
+  apply_to     = [aiven_clickhouse_role.reader.role]
+}`, projectName, serviceName, condition)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acc.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acc.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: manifest("tenant_id=42"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tenant"),
+					resource.TestCheckResourceAttr(resourceName, "database", "test-db"),
+					resource.TestCheckResourceAttr(resourceName, "condition", "tenant_id=42"),
+					resource.TestCheckResourceAttr(resourceName, "formatted_condition", "tenant_id = 42"),
+					resource.TestCheckResourceAttr(resourceName, "restrictive", "false"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "apply_to.*", "reader"),
+				),
+			},
+			{
+				// The formatted condition is not a change
+				Config:             manifest("tenant_id=42"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				Config: manifest("tenant_id = 43"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "condition", "tenant_id = 43"),
+					resource.TestCheckResourceAttr(resourceName, "formatted_condition", "tenant_id = 43"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,194 @@
+package clickhouse
+
+import (
+	"context"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil/userconfig"
+)
+
+var aivenClickhouseSettingsProfileSchema = map[string]*schema.Schema{
+	"project":      schemautil.CommonSchemaProjectReference,
+	"service_name": schemautil.CommonSchemaServiceNameReference,
+
+	"name": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: userconfig.Desc("The name of the settings profile.").ForceNew().Build(),
+	},
+	"inherit": {
+		Type:        schema.TypeList,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "The profiles to inherit the settings from, the settings of this profile override them.",
+	},
+	"setting": {
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "The settings of the profile and their constraints.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "The name of the setting, e.g. `max_memory_usage`.",
+				},
+				"value": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The value of the setting.",
+				},
+				"min": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The minimum value the user can set.",
+				},
+				"max": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The maximum value the user can set.",
+				},
+				"writability": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringInSlice(settingsProfileWritabilities, false),
+					Description: userconfig.Desc("Whether the user can change the setting.").
+						PossibleValues(schemautil.StringSliceToInterfaceSlice(settingsProfileWritabilities)...).
+						Build(),
+				},
+			},
+		},
+	},
+	"apply_to": applyToSchema("settings profile"),
+}
+
+func ResourceClickhouseSettingsProfile() *schema.Resource {
+	return &schema.Resource{
+		Description:   "The Clickhouse Settings Profile resource allows the creation and management of Settings Profiles in Aiven Clickhouse services.",
+		CreateContext: resourceClickhouseSettingsProfileCreate,
+		ReadContext:   resourceClickhouseSettingsProfileRead,
+		UpdateContext: resourceClickhouseSettingsProfileUpdate,
+		DeleteContext: resourceClickhouseSettingsProfileDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: schemautil.DefaultResourceTimeouts(),
+
+		Schema: aivenClickhouseSettingsProfileSchema,
+	}
+}
+
+func resourceClickhouseSettingsProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	profile := readSettingsProfileFromSchema(d)
+
+	if err := CreateSettingsProfile(ctx, client, projectName, serviceName, profile); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(projectName, serviceName, profile.Name))
+
+	return resourceClickhouseSettingsProfileRead(ctx, d, m)
+}
+
+func resourceClickhouseSettingsProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, name, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	profile, err := ReadSettingsProfile(ctx, client, projectName, serviceName, name)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	settings := make([]map[string]interface{}, len(profile.Settings))
+	for i, s := range profile.Settings {
+		settings[i] = map[string]interface{}{
+			"name":        s.Name,
+			"value":       s.Value,
+			"min":         s.Min,
+			"max":         s.Max,
+			"writability": s.Writability,
+		}
+	}
+
+	if err := d.Set("project", projectName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("name", profile.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("inherit", profile.Inherit); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("setting", settings); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("apply_to", profile.ApplyTo); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceClickhouseSettingsProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+
+	if err := AlterSettingsProfile(ctx, client, projectName, serviceName, readSettingsProfileFromSchema(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceClickhouseSettingsProfileRead(ctx, d, m)
+}
+
+func resourceClickhouseSettingsProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, name, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := DropSettingsProfile(ctx, client, projectName, serviceName, name); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func readSettingsProfileFromSchema(d *schema.ResourceData) SettingsProfile {
+	profile := SettingsProfile{
+		Name:     d.Get("name").(string),
+		Settings: make([]SettingsProfileSetting, 0),
+		Inherit:  schemautil.FlattenToString(d.Get("inherit").([]interface{})),
+		ApplyTo:  readApplyToFromSchema(d),
+	}
+
+	for _, v := range d.Get("setting").([]interface{}) {
+		s := v.(map[string]interface{})
+		profile.Settings = append(profile.Settings, SettingsProfileSetting{
+			Name:        s["name"].(string),
+			Value:       s["value"].(string),
+			Min:         s["min"].(string),
+			Max:         s["max"].(string),
+			Writability: s["writability"].(string),
+		})
+	}
+	return profile
+}
@@ -0,0 +1,83 @@
+package clickhouse_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	acc "github.com/aiven/terraform-provider-aiven/internal/acctest"
+)
+
+func TestAccAivenClickhouseSettingsProfile(t *testing.T) {
+	serviceName := fmt.Sprintf("test-acc-ch-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	projectName := os.Getenv("AIVEN_PROJECT_NAME")
+	resourceName := "aiven_clickhouse_settings_profile.foo"
+
+	manifest := func(maxMemoryUsage string) string {
+		return fmt.Sprintf(`
+resource "aiven_clickhouse" "bar" {
+  project                 = "%s"
+  cloud_name              = "google-europe-west1"
+  plan                    = "startup-16"
+  service_name            = "%s"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+}
+
+resource "aiven_clickhouse_role" "analyst" {
+  service_name = aiven_clickhouse.bar.service_name
+  project      = aiven_clickhouse.bar.project
+  role         = "analyst"
+}
+
+resource "aiven_clickhouse_settings_profile" "foo" {
+  service_name = aiven_clickhouse.bar.service_name
+  project      = aiven_clickhouse.bar.project
+  name         = "analysts"
+  apply_to     = [aiven_clickhouse_role.analyst.role]
+
+  setting {
+    name  = "max_memory_usage"
+    value = "%s"
+    max   = "20000000000"
+  }
+
+  setting {
+    name        = "load_balancing"
+    value       = "random"
+    writability = "CONST"
+  }
+}`, projectName, serviceName, maxMemoryUsage)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acc.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acc.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: manifest("10000000000"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "analysts"),
+					resource.TestCheckResourceAttr(resourceName, "setting.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "setting.0.value", "10000000000"),
+					resource.TestCheckResourceAttr(resourceName, "setting.1.writability", "CONST"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "apply_to.*", "analyst"),
+				),
+			},
+			{
+				Config: manifest("5000000000"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "setting.0.value", "5000000000"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
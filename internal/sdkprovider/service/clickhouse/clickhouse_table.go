@@ -0,0 +1,366 @@
+package clickhouse
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil/userconfig"
+)
+
+var whitespaceRegExp = regexp.MustCompile(`\s+`)
+
+// diffSuppressSQLWhitespace ClickHouse formats the types and the expressions, e.g. "Decimal(10,2)" is "Decimal(10, 2)"
+func diffSuppressSQLWhitespace(_, old, new string, _ *schema.ResourceData) bool {
+	return whitespaceRegExp.ReplaceAllString(old, "") == whitespaceRegExp.ReplaceAllString(new, "")
+}
+
+// tableEngineName returns the engine without its parameters and the "Replicated" prefix,
+// Aiven replicates the MergeTree tables on its own
+func tableEngineName(engine string) string {
+	name, _, _ := strings.Cut(strings.TrimSpace(engine), "(")
+	return strings.TrimPrefix(strings.TrimSpace(name), "Replicated")
+}
+
+var intervalRegExp = regexp.MustCompile(`(?i)\bINTERVAL\s+(\d+)\s+(SECOND|MINUTE|HOUR|DAY|WEEK|MONTH|QUARTER|YEAR)S?\b`)
+
+// normalizeSQLExpression removes the whitespace and writes the intervals as ClickHouse formats them,
+// e.g. "INTERVAL 30 DAY" is "toIntervalDay(30)"
+func normalizeSQLExpression(s string) string {
+	s = intervalRegExp.ReplaceAllStringFunc(s, func(m string) string {
+		p := intervalRegExp.FindStringSubmatch(m)
+		return fmt.Sprintf("toInterval%s%s(%s)", strings.ToUpper(p[2][:1]), strings.ToLower(p[2][1:]), p[1])
+	})
+	return whitespaceRegExp.ReplaceAllString(s, "")
+}
+
+func diffSuppressSQLExpression(_, old, new string, _ *schema.ResourceData) bool {
+	return normalizeSQLExpression(old) == normalizeSQLExpression(new)
+}
+
+// trimTupleParentheses returns the expression without the parentheses around the whole of it,
+// ClickHouse writes "ORDER BY (a)" as "ORDER BY a"
+func trimTupleParentheses(s string) string {
+	if !strings.HasPrefix(s, "(") || !strings.HasSuffix(s, ")") {
+		return s
+	}
+
+	depth := 0
+	for i, c := range s {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 && i != len(s)-1 {
+				return s
+			}
+		}
+	}
+	return s[1 : len(s)-1]
+}
+
+func diffSuppressTableKey(_, old, new string, _ *schema.ResourceData) bool {
+	return trimTupleParentheses(normalizeSQLExpression(old)) == trimTupleParentheses(normalizeSQLExpression(new))
+}
+
+func diffSuppressTableEngine(_, old, new string, _ *schema.ResourceData) bool {
+	return old == new || (old != "" && tableEngineName(old) == tableEngineName(new))
+}
+
+var aivenClickhouseTableSchema = map[string]*schema.Schema{
+	"project":      schemautil.CommonSchemaProjectReference,
+	"service_name": schemautil.CommonSchemaServiceNameReference,
+
+	"database": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: userconfig.Desc("The database of the table.").Referenced().ForceNew().Build(),
+	},
+	"name": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: userconfig.Desc("The name of the table.").ForceNew().Build(),
+	},
+	"engine": {
+		Type:             schema.TypeString,
+		Required:         true,
+		ForceNew:         true,
+		DiffSuppressFunc: diffSuppressTableEngine,
+		Description: userconfig.Desc("The table engine with its parameters, e.g. `MergeTree` or " +
+			"`Kafka('kafka:9092', 'topic', 'group', 'JSONEachRow')`. " +
+			"MergeTree tables are replicated by Aiven, so `ReplicatedMergeTree` and `MergeTree` are the same.").
+			ForceNew().Build(),
+	},
+	"column": {
+		Type:        schema.TypeList,
+		Required:    true,
+		MinItems:    1,
+		Description: "The columns of the table. Columns can be added, dropped and modified in place, but the order of the kept columns can't be changed.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "The name of the column.",
+				},
+				"type": {
+					Type:             schema.TypeString,
+					Required:         true,
+					DiffSuppressFunc: diffSuppressSQLWhitespace,
+					Description:      "The type of the column, e.g. `LowCardinality(String)`.",
+				},
+				"default": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					DiffSuppressFunc: diffSuppressSQLExpression,
+					Description:      "The DEFAULT value expression, e.g. `now()`. MATERIALIZED and ALIAS columns are not supported.",
+				},
+				"comment": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The comment of the column.",
+				},
+			},
+		},
+	},
+	"order_by": {
+		Type:             schema.TypeString,
+		Optional:         true,
+		ForceNew:         true,
+		DiffSuppressFunc: diffSuppressTableKey,
+		Description:      userconfig.Desc("The ORDER BY expression of the MergeTree table, e.g. `(tenant_id, timestamp)`.").ForceNew().Build(),
+	},
+	"partition_by": {
+		Type:             schema.TypeString,
+		Optional:         true,
+		ForceNew:         true,
+		DiffSuppressFunc: diffSuppressTableKey,
+		Description:      userconfig.Desc("The PARTITION BY expression of the MergeTree table, e.g. `toYYYYMM(timestamp)`.").ForceNew().Build(),
+	},
+	"ttl": {
+		Type:             schema.TypeString,
+		Optional:         true,
+		DiffSuppressFunc: diffSuppressSQLExpression,
+		Description:      "The TTL expression of the MergeTree table, e.g. `timestamp + INTERVAL 30 DAY`.",
+	},
+	"settings": {
+		Type:        schema.TypeMap,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "The settings of the table engine, e.g. `index_granularity` or `kafka_num_consumers`.",
+	},
+}
+
+func ResourceClickhouseTable() *schema.Resource {
+	return &schema.Resource{
+		Description: `The Clickhouse Table resource allows the creation and management of Tables in Aiven Clickhouse services.
+
+Notes:
+* The table is read from ` + "`system.tables`" + ` and ` + "`system.columns`" + `, a change outside of Terraform is shown as drift. The expressions are compared as ClickHouse formats them, e.g. ` + "`INTERVAL 30 DAY`" + ` is ` + "`toIntervalDay(30)`" + `.
+* The columns with a MATERIALIZED or ALIAS expression are not supported.
+* Changes of ` + "`engine`" + `, ` + "`order_by`" + ` and ` + "`partition_by`" + ` recreate the table, its data is lost.
+`,
+		CreateContext: resourceClickhouseTableCreate,
+		ReadContext:   resourceClickhouseTableRead,
+		UpdateContext: resourceClickhouseTableUpdate,
+		DeleteContext: resourceClickhouseTableDelete,
+		CustomizeDiff: resourceClickhouseTableCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: schemautil.DefaultResourceTimeouts(),
+
+		Schema: aivenClickhouseTableSchema,
+	}
+}
+
+func resourceClickhouseTableCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	table := readTableFromSchema(d)
+
+	if err := CreateTable(ctx, client, projectName, serviceName, table); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(projectName, serviceName, table.Database, table.Name))
+
+	return resourceClickhouseTableRead(ctx, d, m)
+}
+
+func resourceClickhouseTableRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, database, name, err := schemautil.SplitResourceID4(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	table, err := ReadTable(ctx, client, projectName, serviceName, database, name)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	if err := d.Set("project", projectName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("database", table.Database); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("name", table.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	// The expressions are formatted by ClickHouse, see the diff suppress functions
+	if err := d.Set("engine", table.Engine); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("column", flattenTableColumns(table.Columns)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("order_by", table.OrderBy); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("partition_by", table.PartitionBy); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("ttl", table.TTL); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("settings", flattenTableSettings(table.Settings, expandTableSettings(d.Get("settings")))); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceClickhouseTableUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, database, name, err := schemautil.SplitResourceID4(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	commands := make([]string, 0)
+	if d.HasChange("column") {
+		o, n := d.GetChange("column")
+		commands = append(commands, alterTableColumnsCommands(expandTableColumns(o), expandTableColumns(n))...)
+	}
+	if d.HasChange("ttl") {
+		o, n := d.GetChange("ttl")
+		commands = append(commands, alterTableTTLCommands(o.(string), n.(string))...)
+	}
+	if d.HasChange("settings") {
+		o, n := d.GetChange("settings")
+		commands = append(commands, alterTableSettingsCommands(expandTableSettings(o), expandTableSettings(n))...)
+	}
+
+	if err := AlterTable(ctx, client, projectName, serviceName, database, name, commands); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceClickhouseTableRead(ctx, d, m)
+}
+
+func resourceClickhouseTableDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, database, name, err := schemautil.SplitResourceID4(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := DropTable(ctx, client, projectName, serviceName, database, name); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// resourceClickhouseTableCustomizeDiff ALTER TABLE can't reorder the columns in a safe way
+func resourceClickhouseTableCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChange("column") {
+		return nil
+	}
+
+	o, n := d.GetChange("column")
+	if tableColumnsReordered(expandTableColumns(o), expandTableColumns(n)) {
+		return fmt.Errorf("the order of the existing columns can't be changed, only columns can be added or dropped")
+	}
+	return nil
+}
+
+func readTableFromSchema(d *schema.ResourceData) Table {
+	return Table{
+		Database:    d.Get("database").(string),
+		Name:        d.Get("name").(string),
+		Engine:      d.Get("engine").(string),
+		Columns:     expandTableColumns(d.Get("column")),
+		OrderBy:     d.Get("order_by").(string),
+		PartitionBy: d.Get("partition_by").(string),
+		TTL:         d.Get("ttl").(string),
+		Settings:    expandTableSettings(d.Get("settings")),
+	}
+}
+
+func expandTableColumns(v interface{}) []TableColumn {
+	columns := make([]TableColumn, 0)
+	for _, c := range v.([]interface{}) {
+		column := c.(map[string]interface{})
+		columns = append(columns, TableColumn{
+			Name:    column["name"].(string),
+			Type:    column["type"].(string),
+			Default: column["default"].(string),
+			Comment: column["comment"].(string),
+		})
+	}
+	return columns
+}
+
+func flattenTableColumns(columns []TableColumn) []map[string]interface{} {
+	res := make([]map[string]interface{}, len(columns))
+	for i, c := range columns {
+		res[i] = map[string]interface{}{
+			"name":    c.Name,
+			"type":    c.Type,
+			"default": c.Default,
+			"comment": c.Comment,
+		}
+	}
+	return res
+}
+
+func expandTableSettings(v interface{}) map[string]string {
+	settings := make(map[string]string)
+	for k, s := range v.(map[string]interface{}) {
+		settings[k] = s.(string)
+	}
+	return settings
+}
+
+// defaultTableSettings the settings ClickHouse adds to every MergeTree table
+var defaultTableSettings = map[string]string{"index_granularity": "8192"}
+
+// flattenTableSettings returns the settings of the table, the default ones only if they are in the state
+func flattenTableSettings(remote, state map[string]string) map[string]string {
+	settings := make(map[string]string, len(remote))
+	for k, v := range remote {
+		if _, ok := state[k]; !ok && defaultTableSettings[k] == v {
+			continue
+		}
+		settings[k] = v
+	}
+	return settings
+}
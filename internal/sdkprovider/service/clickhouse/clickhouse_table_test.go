@@ -0,0 +1,122 @@
+package clickhouse_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+
+	acc "github.com/aiven/terraform-provider-aiven/internal/acctest"
+)
+
+func TestAccAivenClickhouseTable(t *testing.T) {
+	serviceName := fmt.Sprintf("test-acc-ch-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	projectName := os.Getenv("AIVEN_PROJECT_NAME")
+	resourceName := "aiven_clickhouse_table.foo"
+
+	manifest := func(columns string) string {
+		return fmt.Sprintf(`
+resource "aiven_clickhouse" "bar" {
+  project                 = "%s"
+  cloud_name              = "google-europe-west1"
+  plan                    = "startup-16"
+  service_name            = "%s"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+}
+
+resource "aiven_clickhouse_database" "foo" {
+  service_name = aiven_clickhouse.bar.service_name
+  project      = aiven_clickhouse.bar.project
+  name         = "analytics"
+}
+
+resource "aiven_clickhouse_table" "foo" {
+  service_name = aiven_clickhouse.bar.service_name
+  project      = aiven_clickhouse.bar.project
+  database     = aiven_clickhouse_database.foo.name
+  name         = "events"
+  engine       = "MergeTree"
+  order_by     = "(tenant_id, timestamp)"
+  partition_by = "toYYYYMM(timestamp)"
+  ttl          = "timestamp + INTERVAL 30 DAY"
+
+  settings = {
+    merge_with_ttl_timeout = "3600"
+  }
+  %s
+}`, projectName, serviceName, columns)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acc.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acc.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: manifest(`
+  column {
+    name    = "timestamp"
+    type    = "DateTime"
+    default = "now()"
+  }
+
+  column {
+    name = "tenant_id"
+    type = "UInt32"
+  }
+
+  column {
+    name = "payload"
+    type = "String"
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "events"),
+					resource.TestCheckResourceAttr(resourceName, "column.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "column.0.default", "now()"),
+					resource.TestCheckResourceAttr(resourceName, "column.1.type", "UInt32"),
+				),
+			},
+			{
+				// Drops, adds and modifies the columns in place
+				Config: manifest(`
+  column {
+    name = "timestamp"
+    type = "DateTime"
+  }
+
+  column {
+    name    = "tenant_id"
+    type    = "UInt64"
+    comment = "owner"
+  }
+
+  column {
+    name    = "level"
+    type    = "LowCardinality(String)"
+    default = "'info'"
+  }`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "column.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "column.0.default", ""),
+					resource.TestCheckResourceAttr(resourceName, "column.1.type", "UInt64"),
+					resource.TestCheckResourceAttr(resourceName, "column.1.comment", "owner"),
+					resource.TestCheckResourceAttr(resourceName, "column.2.name", "level"),
+				),
+			},
+			{
+				// The engine, the keys, TTL and settings are read as ClickHouse formats them
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -2,6 +2,8 @@ package clickhouse
 
 import (
 	"context"
+	"crypto/rand"
+	"math/big"
 
 	"github.com/aiven/aiven-go-client/v2"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -39,6 +41,37 @@ var aivenClickhouseUserSchema = map[string]*schema.Schema{
 		Computed:    true,
 		Description: "Indicates if a clickhouse user is required",
 	},
+	"password_rotation_trigger": {
+		Type:     schema.TypeString,
+		Optional: true,
+		Description: "Any value, e.g. a timestamp. " +
+			"Changing it resets the password of the user to a new random one.",
+	},
+	"allowed_ips": {
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+		Description: "The IP addresses and networks the user can connect from, e.g. `10.0.0.0/8`. " +
+			"If neither `allowed_ips` nor `allowed_host_names` is set, the user can connect from any host.",
+	},
+	"allowed_host_names": {
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "The host names the user can connect from.",
+	},
+	"default_roles": {
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+		Description: userconfig.Desc("The roles enabled when the user logs in, the roles must be granted to the user. " +
+			"If not set, all the granted roles are enabled.").Referenced().Build(),
+	},
+	"settings_profile": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: userconfig.Desc("The settings profile of the user.").Referenced().Build(),
+	},
 }
 
 func ResourceClickhouseUser() *schema.Resource {
@@ -46,6 +79,7 @@ func ResourceClickhouseUser() *schema.Resource {
 		Description:   "The Clickhouse User resource allows the creation and management of Aiven Clikhouse Users.",
 		CreateContext: resourceClickhouseUserCreate,
 		ReadContext:   resourceClickhouseUserRead,
+		UpdateContext: resourceClickhouseUserUpdate,
 		DeleteContext: resourceClickhouseUserDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -78,6 +112,14 @@ func resourceClickhouseUserCreate(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
+	// The defaults of a new user are the same as of the empty config
+	user := readUserFromSchema(d)
+	if len(user.AllowedIPs)+len(user.AllowedHostNames)+len(user.DefaultRoles) > 0 || user.SettingsProfile != "" {
+		if err := AlterUser(ctx, client, projectName, serviceName, user); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceClickhouseUserRead(ctx, d, m)
 }
 
@@ -110,9 +152,59 @@ func resourceClickhouseUserRead(ctx context.Context, d *schema.ResourceData, m i
 		return diag.FromErr(err)
 	}
 
+	settings, err := ReadUser(ctx, client, projectName, serviceName, user.Name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("allowed_ips", settings.AllowedIPs); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("allowed_host_names", settings.AllowedHostNames); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("default_roles", settings.DefaultRoles); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("settings_profile", settings.SettingsProfile); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return nil
 }
 
+func resourceClickhouseUserUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, uuid, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChanges("allowed_ips", "allowed_host_names", "default_roles", "settings_profile") {
+		if err := AlterUser(ctx, client, projectName, serviceName, readUserFromSchema(d)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("password_rotation_trigger") {
+		password, err := generateUserPassword()
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		password, err = client.ClickhouseUser.ResetPassword(ctx, projectName, serviceName, uuid, password)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("password", password); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceClickhouseUserRead(ctx, d, m)
+}
+
 func resourceClickhouseUserDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*aiven.Client)
 
@@ -121,6 +213,9 @@ func resourceClickhouseUserDelete(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
+	// The grants of the user are dropped too
+	defer invalidateGrantCache(projectName, serviceName)
+
 	err = client.ClickhouseUser.Delete(ctx, projectName, serviceName, uuid)
 	if common.IsCritical(err) {
 		return diag.FromErr(err)
@@ -128,3 +223,28 @@ func resourceClickhouseUserDelete(ctx context.Context, d *schema.ResourceData, m
 
 	return nil
 }
+
+func readUserFromSchema(d *schema.ResourceData) User {
+	return User{
+		Name:             d.Get("username").(string),
+		AllowedIPs:       schemautil.FlattenToString(d.Get("allowed_ips").(*schema.Set).List()),
+		AllowedHostNames: schemautil.FlattenToString(d.Get("allowed_host_names").(*schema.Set).List()),
+		DefaultRoles:     schemautil.FlattenToString(d.Get("default_roles").(*schema.Set).List()),
+		SettingsProfile:  d.Get("settings_profile").(string),
+	}
+}
+
+const userPasswordChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// generateUserPassword returns a random password for the password reset
+func generateUserPassword() (string, error) {
+	b := make([]byte, 32)
+	for i := range b {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(userPasswordChars))))
+		if err != nil {
+			return "", err
+		}
+		b[i] = userPasswordChars[n.Int64()]
+	}
+	return string(b), nil
+}
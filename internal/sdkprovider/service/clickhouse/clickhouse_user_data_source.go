@@ -14,11 +14,18 @@ func DatasourceClickhouseUser() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: datasourceClickhouseUserRead,
 		Description: "The Clickhouse User data source provides information about the existing Aiven Clickhouse User.",
-		Schema: schemautil.ResourceSchemaAsDatasourceSchema(aivenClickhouseUserSchema,
-			"project", "service_name", "username"),
+		Schema:      datasourceClickhouseUserSchema(),
 	}
 }
 
+// datasourceClickhouseUserSchema doesn't have the password reset trigger
+func datasourceClickhouseUserSchema() map[string]*schema.Schema {
+	s := schemautil.ResourceSchemaAsDatasourceSchema(aivenClickhouseUserSchema,
+		"project", "service_name", "username")
+	delete(s, "password_rotation_trigger")
+	return s
+}
+
 func datasourceClickhouseUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*aiven.Client)
 
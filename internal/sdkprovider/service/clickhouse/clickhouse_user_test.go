@@ -42,6 +42,98 @@ func TestAccAivenClickhouseUser_basic(t *testing.T) {
 	})
 }
 
+func TestAccAivenClickhouseUser_settings(t *testing.T) {
+	serviceName := fmt.Sprintf("test-acc-ch-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	projectName := os.Getenv("AIVEN_PROJECT_NAME")
+	resourceName := "aiven_clickhouse_user.foo"
+
+	manifest := func(user string) string {
+		return fmt.Sprintf(`
+resource "aiven_clickhouse" "bar" {
+  project                 = "%s"
+  cloud_name              = "google-europe-west1"
+  plan                    = "startup-16"
+  service_name            = "%s"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+}
+
+resource "aiven_clickhouse_role" "reader" {
+  service_name = aiven_clickhouse.bar.service_name
+  project      = aiven_clickhouse.bar.project
+  role         = "reader"
+}
+
+resource "aiven_clickhouse_settings_profile" "restricted" {
+  service_name = aiven_clickhouse.bar.service_name
+  project      = aiven_clickhouse.bar.project
+  name         = "restricted"
+
+  setting {
+    name  = "max_memory_usage"
+    value = "10000000000"
+  }
+}
+
+resource "aiven_clickhouse_user" "foo" {
+  service_name = aiven_clickhouse.bar.service_name
+  project      = aiven_clickhouse.bar.project
+  username     = "analyst"
+  %s
+}
+
+resource "aiven_clickhouse_grant" "foo" {
+  service_name = aiven_clickhouse.bar.service_name
+  project      = aiven_clickhouse.bar.project
+  user         = aiven_clickhouse_user.foo.username
+
+  role_grant {
+    role = aiven_clickhouse_role.reader.role
+  }
+}`, projectName, serviceName, user)
+	}
+
+	var password string
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acc.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acc.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: manifest(`
+  allowed_ips      = ["10.0.0.0/8"]
+  settings_profile = aiven_clickhouse_settings_profile.restricted.name`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "allowed_ips.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "allowed_ips.*", "10.0.0.0/8"),
+					resource.TestCheckResourceAttr(resourceName, "settings_profile", "restricted"),
+					resource.TestCheckResourceAttr(resourceName, "default_roles.#", "0"),
+					func(s *terraform.State) error {
+						password = s.RootModule().Resources[resourceName].Primary.Attributes["password"]
+						return nil
+					},
+				),
+			},
+			{
+				// The default role is set after the grant, the password is reset
+				Config: manifest(`
+  default_roles             = ["reader"]
+  password_rotation_trigger = "2024-01-01"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "allowed_ips.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "settings_profile", ""),
+					resource.TestCheckTypeSetElemAttr(resourceName, "default_roles.*", "reader"),
+					func(s *terraform.State) error {
+						if s.RootModule().Resources[resourceName].Primary.Attributes["password"] == password {
+							return fmt.Errorf("expected the password to be reset")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckAivenClickhouseUserResourceDestroy(s *terraform.State) error {
 	c := acc.GetTestAivenClient()
 
@@ -0,0 +1,194 @@
+package clickhouse
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil/userconfig"
+)
+
+var aivenClickhouseViewSchema = map[string]*schema.Schema{
+	"project":      schemautil.CommonSchemaProjectReference,
+	"service_name": schemautil.CommonSchemaServiceNameReference,
+
+	"database": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: userconfig.Desc("The database of the view.").Referenced().ForceNew().Build(),
+	},
+	"name": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: userconfig.Desc("The name of the view.").ForceNew().Build(),
+	},
+	"query": {
+		Type:     schema.TypeString,
+		Required: true,
+		Description: "The SELECT query of the view. ClickHouse reformats it, see `formatted_query`. " +
+			"A change of the query of a materialized view recreates it.",
+	},
+	"formatted_query": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The query as formatted by ClickHouse, a change of it outside of Terraform is shown as drift.",
+	},
+	"materialized": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		ForceNew:    true,
+		Default:     false,
+		Description: userconfig.Desc("If true, the view is a materialized view that writes to `to_table`.").ForceNew().DefaultValue(false).Build(),
+	},
+	"to_table": {
+		Type:     schema.TypeString,
+		Optional: true,
+		ForceNew: true,
+		Description: userconfig.Desc("The table the materialized view writes to, `table` of the same database or `database.table`. " +
+			"Required if `materialized` is true.").ForceNew().Build(),
+	},
+}
+
+func ResourceClickhouseView() *schema.Resource {
+	return &schema.Resource{
+		Description: `The Clickhouse View resource allows the creation and management of Views and Materialized Views in Aiven Clickhouse services.
+
+Notes:
+* The query of a view is replaced in place, a materialized view is recreated.
+* ` + "`to_table`" + ` is not read, it must be set in the config after import.
+`,
+		CreateContext: resourceClickhouseViewCreate,
+		ReadContext:   resourceClickhouseViewRead,
+		UpdateContext: resourceClickhouseViewUpdate,
+		DeleteContext: resourceClickhouseViewDelete,
+		CustomizeDiff: resourceClickhouseViewCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: schemautil.DefaultResourceTimeouts(),
+
+		Schema: aivenClickhouseViewSchema,
+	}
+}
+
+func resourceClickhouseViewCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	view := readViewFromSchema(d)
+
+	if err := CreateView(ctx, client, projectName, serviceName, view); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(projectName, serviceName, view.Database, view.Name))
+
+	return resourceClickhouseViewRead(ctx, d, m)
+}
+
+func resourceClickhouseViewRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, database, name, err := schemautil.SplitResourceID4(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	view, err := ReadView(ctx, client, projectName, serviceName, database, name)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	// The query is kept as it is in the config, unless it is changed outside of Terraform or imported
+	formatted := d.Get("formatted_query").(string)
+	if d.Get("query").(string) == "" || (formatted != "" && formatted != view.Query) {
+		if err := d.Set("query", view.Query); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err := d.Set("project", projectName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("database", view.Database); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("name", view.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("formatted_query", view.Query); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("materialized", view.Materialized); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceClickhouseViewUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+
+	if err := ReplaceView(ctx, client, projectName, serviceName, readViewFromSchema(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The new query is formatted differently, it is not a change outside of Terraform
+	if err := d.Set("formatted_query", ""); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceClickhouseViewRead(ctx, d, m)
+}
+
+func resourceClickhouseViewDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, database, name, err := schemautil.SplitResourceID4(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := DropView(ctx, client, projectName, serviceName, database, name); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// resourceClickhouseViewCustomizeDiff materialized views can't be replaced, they are recreated instead
+func resourceClickhouseViewCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	materialized := d.Get("materialized").(bool)
+	if materialized && d.Get("to_table").(string) == "" {
+		return fmt.Errorf("to_table is required for a materialized view")
+	}
+	if !materialized && d.Get("to_table").(string) != "" {
+		return fmt.Errorf("to_table can be set for a materialized view only")
+	}
+
+	if materialized && d.Id() != "" && d.HasChange("query") {
+		return d.ForceNew("query")
+	}
+	return nil
+}
+
+func readViewFromSchema(d *schema.ResourceData) View {
+	return View{
+		Database:     d.Get("database").(string),
+		Name:         d.Get("name").(string),
+		Query:        d.Get("query").(string),
+		Materialized: d.Get("materialized").(bool),
+		ToTable:      d.Get("to_table").(string),
+	}
+}
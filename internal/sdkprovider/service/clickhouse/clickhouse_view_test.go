@@ -0,0 +1,129 @@
+package clickhouse_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+
+	acc "github.com/aiven/terraform-provider-aiven/internal/acctest"
+)
+
+func TestAccAivenClickhouseView(t *testing.T) {
+	serviceName := fmt.Sprintf("test-acc-ch-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	projectName := os.Getenv("AIVEN_PROJECT_NAME")
+	resourceName := "aiven_clickhouse_view.foo"
+
+	manifest := func(query string) string {
+		return fmt.Sprintf(`
+resource "aiven_clickhouse" "bar" {
+  project                 = "%s"
+  cloud_name              = "google-europe-west1"
+  plan                    = "startup-16"
+  service_name            = "%s"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+}
+
+resource "aiven_clickhouse_database" "foo" {
+  service_name = aiven_clickhouse.bar.service_name
+  project      = aiven_clickhouse.bar.project
+  name         = "analytics"
+}
+
+resource "aiven_clickhouse_table" "events" {
+  service_name = aiven_clickhouse.bar.service_name
+  project      = aiven_clickhouse.bar.project
+  database     = aiven_clickhouse_database.foo.name
+  name         = "events"
+  engine       = "MergeTree"
+  order_by     = "timestamp"
+
+  column {
+    name = "timestamp"
+    type = "DateTime"
+  }
+
+  column {
+    name = "tenant_id"
+    type = "UInt64"
+  }
+}
+
+resource "aiven_clickhouse_table" "totals" {
+  service_name = aiven_clickhouse.bar.service_name
+  project      = aiven_clickhouse.bar.project
+  database     = aiven_clickhouse_database.foo.name
+  name         = "events_per_tenant"
+  engine       = "SummingMergeTree"
+  order_by     = "tenant_id"
+
+  column {
+    name = "tenant_id"
+    type = "UInt64"
+  }
+
+  column {
+    name = "total"
+    type = "UInt64"
+  }
+}
+
+resource "aiven_clickhouse_view" "foo" {
+  service_name = aiven_clickhouse.bar.service_name
+  project      = aiven_clickhouse.bar.project
+  database     = aiven_clickhouse_database.foo.name
+  name         = "recent_events"
+  query        = "%s"
+
+  depends_on = [aiven_clickhouse_table.events]
+}
+
+resource "aiven_clickhouse_view" "bar" {
+  service_name = aiven_clickhouse.bar.service_name
+  project      = aiven_clickhouse.bar.project
+  database     = aiven_clickhouse_database.foo.name
+  name         = "events_mv"
+  query        = "SELECT tenant_id, count() AS total FROM analytics.events GROUP BY tenant_id"
+  materialized = true
+  to_table     = aiven_clickhouse_table.totals.name
+}`, projectName, serviceName, query)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acc.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acc.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: manifest("SELECT * FROM analytics.events WHERE timestamp > now() - INTERVAL 1 DAY"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "recent_events"),
+					resource.TestCheckResourceAttr(resourceName, "materialized", "false"),
+					resource.TestCheckResourceAttrSet(resourceName, "formatted_query"),
+					resource.TestCheckResourceAttr("aiven_clickhouse_view.bar", "materialized", "true"),
+				),
+			},
+			{
+				// The view is replaced in place
+				Config: manifest("SELECT * FROM analytics.events WHERE timestamp > now() - INTERVAL 7 DAY"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "query", "SELECT * FROM analytics.events WHERE timestamp > now() - INTERVAL 7 DAY"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"query"},
+			},
+		},
+	})
+}
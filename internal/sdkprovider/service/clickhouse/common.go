@@ -1,5 +1,26 @@
 package clickhouse
 
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil/userconfig"
+)
+
 // default database used for statements that do not target a particular database
 // think CREATE ROLE / GRANT / etc...
 const defaultDatabase = "system"
+
+// applyToSchema the users and roles of the TO clause, e.g. of a row policy or a quota
+func applyToSchema(what string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: userconfig.Desc("The users and roles the " + what + " applies to.").Referenced().Build(),
+	}
+}
+
+func readApplyToFromSchema(d *schema.ResourceData) []string {
+	return schemautil.FlattenToString(d.Get("apply_to").(*schema.Set).List())
+}
@@ -3,6 +3,7 @@ package clickhouse
 import (
 	"bytes"
 	"fmt"
+	"strconv"
 )
 
 func escape(identifier string) string {
@@ -10,23 +11,32 @@ func escape(identifier string) string {
 }
 
 func escapeBytes(identifier []byte) string {
+	return quoteBytes(identifier, '`')
+}
+
+// escapeString returns a string literal, e.g. a value to compare with in a WHERE clause
+func escapeString(value string) string {
+	return quoteBytes([]byte(value), '\'')
+}
+
+func quoteBytes(value []byte, quote byte) string {
 	var (
 		escapeMap = map[byte]string{
-			0:    "\\0",
-			'\b': "\\b",
-			'\f': "\\f",
-			'\r': "\\r",
-			'\n': "\\n",
-			'\t': "\\t",
-			'\\': "\\\\",
-			'`':  "\\`",
+			0:     "\\0",
+			'\b':  "\\b",
+			'\f':  "\\f",
+			'\r':  "\\r",
+			'\n':  "\\n",
+			'\t':  "\\t",
+			'\\':  "\\\\",
+			quote: "\\" + string(quote),
 		}
 	)
 	buf := new(bytes.Buffer)
-	buf.WriteByte('`')
+	buf.WriteByte(quote)
 
-	for i := range identifier {
-		b := identifier[i]
+	for i := range value {
+		b := value[i]
 
 		escaped, ok := escapeMap[b]
 		if ok {
@@ -38,6 +48,39 @@ func escapeBytes(identifier []byte) string {
 		}
 	}
 
-	buf.WriteByte('`')
+	buf.WriteByte(quote)
+	return buf.String()
+}
+
+// unescapeString returns the value of a string literal as escapeString writes it, other values are returned as they are
+func unescapeString(literal string) string {
+	if len(literal) < 2 || literal[0] != '\'' || literal[len(literal)-1] != '\'' {
+		return literal
+	}
+
+	unescapeMap := map[byte]byte{'0': 0, 'b': '\b', 'f': '\f', 'r': '\r', 'n': '\n', 't': '\t'}
+	value := literal[1 : len(literal)-1]
+	buf := new(bytes.Buffer)
+	for i := 0; i < len(value); i++ {
+		b := value[i]
+		if b != '\\' || i+1 == len(value) {
+			buf.WriteByte(b)
+			continue
+		}
+
+		i++
+		if u, ok := unescapeMap[value[i]]; ok {
+			buf.WriteByte(u)
+			continue
+		}
+		if value[i] == 'x' && i+2 < len(value) {
+			if h, err := strconv.ParseUint(value[i+1:i+3], 16, 8); err == nil {
+				buf.WriteByte(byte(h))
+				i += 2
+				continue
+			}
+		}
+		buf.WriteByte(value[i])
+	}
 	return buf.String()
 }
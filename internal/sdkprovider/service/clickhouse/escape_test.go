@@ -40,3 +40,16 @@ func TestEscapeBytes(t *testing.T) {
 		})
 	}
 }
+
+func TestEscapeString(t *testing.T) {
+	assert.Equal(t, `'O\'sullivan'`, escapeString("O'sullivan"))
+	assert.Equal(t, "'back`tick'", escapeString("back`tick"))
+	assert.Equal(t, `'new\nline'`, escapeString("new\nline"))
+}
+
+func TestUnescapeString(t *testing.T) {
+	for _, s := range []string{"O'sullivan", "new\nline\ttab", "back\\slash", "😀"} {
+		assert.Equal(t, s, unescapeString(escapeString(s)))
+	}
+	assert.Equal(t, "8192", unescapeString("8192"))
+}
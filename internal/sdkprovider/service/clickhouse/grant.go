@@ -8,6 +8,8 @@ import (
 	"strings"
 
 	"github.com/aiven/aiven-go-client/v2"
+
+	"github.com/aiven/terraform-provider-aiven/internal/sdkprovider/ttlcache"
 )
 
 type Grantee struct {
@@ -28,11 +30,44 @@ type PrivilegeGrant struct {
 	WithGrant bool
 }
 
+// onSameObject returns true if both grants are for the same privilege on the same object,
+// the grant option can differ
+func (g PrivilegeGrant) onSameObject(other PrivilegeGrant) bool {
+	return g.Grantee.equals(other.Grantee) &&
+		g.Database == other.Database &&
+		g.Table == other.Table &&
+		g.Column == other.Column &&
+		g.Privilege == other.Privilege
+}
+
+func (g PrivilegeGrant) equals(other PrivilegeGrant) bool {
+	return g.onSameObject(other) && g.WithGrant == other.WithGrant
+}
+
+// covers returns true if revoking the grant also revokes the other one:
+// the same privilege or ALL, on a broader object, e.g. the database of a table or the table of a column
+func (g PrivilegeGrant) covers(other PrivilegeGrant) bool {
+	if !g.Grantee.equals(other.Grantee) || g.Database != other.Database || g.onSameObject(other) {
+		return false
+	}
+	if g.Privilege != other.Privilege && g.Privilege != "ALL" {
+		return false
+	}
+	if g.Table == "" {
+		return true
+	}
+	return g.Table == other.Table && g.Column == ""
+}
+
 type RoleGrant struct {
 	Grantee Grantee
 	Role    string
 }
 
+func (g RoleGrant) equals(other RoleGrant) bool {
+	return g.Grantee.equals(other.Grantee) && g.Role == other.Role
+}
+
 func userOrRole(g Grantee) string {
 	if g.User != "" {
 		return g.User
@@ -47,6 +82,8 @@ func CreateRoleGrant(
 	serviceName string,
 	grant RoleGrant,
 ) error {
+	defer invalidateGrantCache(projectName, serviceName)
+
 	query := createRoleGrantStatement(grant)
 
 	log.Println("[DEBUG] Clickhouse: create role grant query: ", query)
@@ -61,6 +98,8 @@ func RevokeRoleGrant(
 	serviceName string,
 	grant RoleGrant,
 ) error {
+	defer invalidateGrantCache(projectName, serviceName)
+
 	query := revokeRoleGrantStatement(grant)
 
 	log.Println("[DEBUG] privilege revocation query: ", query)
@@ -68,6 +107,7 @@ func RevokeRoleGrant(
 	return err
 }
 
+// ReadRoleGrants returns the role grants of the grantee, the grants of the service are cached, see singleGrantCache
 func ReadRoleGrants(
 	ctx context.Context,
 	client *aiven.Client,
@@ -75,27 +115,21 @@ func ReadRoleGrants(
 	serviceName string,
 	grantee Grantee,
 ) ([]RoleGrant, error) {
-	query := readRoleGrantsStatement()
-
-	log.Println("[DEBUG] Clickhouse: read role grant query: ", query)
-	r, err := client.ClickHouseQuery.Query(ctx, projectName, serviceName, defaultDatabase, query)
-	if err != nil {
-		return nil, err
-	}
-
-	roleGrants, err := roleGrantsFromAPIResponse(r)
+	grants, err := singleGrantCache.Get(ctx, ttlcache.Key(projectName, serviceName), func(ctx context.Context) (*grantsOfService, error) {
+		return readGrantsOfService(ctx, client, projectName, serviceName)
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	res := make([]RoleGrant, 0)
-	for _, grant := range roleGrants {
+	for _, grant := range grants.roleGrants {
 		if !grant.Grantee.equals(grantee) {
 			continue
 		}
 		res = append(res, grant)
 	}
-	return res, err
+	return res, nil
 }
 
 func CreatePrivilegeGrant(
@@ -105,6 +139,8 @@ func CreatePrivilegeGrant(
 	serviceName string,
 	grant PrivilegeGrant,
 ) error {
+	defer invalidateGrantCache(projectName, serviceName)
+
 	query := createPrivilegeGrantStatement(grant)
 
 	log.Println("[DEBUG] Clickhouse: create privilege grant query: ", query)
@@ -112,6 +148,7 @@ func CreatePrivilegeGrant(
 	return err
 }
 
+// ReadPrivilegeGrants returns the privilege grants of the grantee, the grants of the service are cached, see singleGrantCache
 func ReadPrivilegeGrants(
 	ctx context.Context,
 	client *aiven.Client,
@@ -119,26 +156,68 @@ func ReadPrivilegeGrants(
 	serviceName string,
 	grantee Grantee,
 ) ([]PrivilegeGrant, error) {
-	query := readPrivilegeGrantsStatement()
-
-	log.Println("[DEBUG] Clickhouse: read privilege grant query: ", query)
-	r, err := client.ClickHouseQuery.Query(ctx, projectName, serviceName, defaultDatabase, query)
+	grants, err := singleGrantCache.Get(ctx, ttlcache.Key(projectName, serviceName), func(ctx context.Context) (*grantsOfService, error) {
+		return readGrantsOfService(ctx, client, projectName, serviceName)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	privilegeGrants, err := privilegeGrantsFromAPIResponse(r)
-	if err != nil {
-		return nil, err
-	}
 	res := make([]PrivilegeGrant, 0)
-	for _, grant := range privilegeGrants {
+	for _, grant := range grants.privilegeGrants {
 		if !grant.Grantee.equals(grantee) {
 			continue
 		}
 		res = append(res, grant)
 	}
-	return res, err
+	return res, nil
+}
+
+// readGrantsOfService reads the role and the privilege grants of all the grantees
+func readGrantsOfService(ctx context.Context, client *aiven.Client, projectName, serviceName string) (*grantsOfService, error) {
+	query := readRoleGrantsStatement()
+
+	log.Println("[DEBUG] Clickhouse: read role grant query: ", query)
+	r, err := client.ClickHouseQuery.Query(ctx, projectName, serviceName, defaultDatabase, query)
+	if err != nil {
+		return nil, err
+	}
+
+	roleGrants, err := roleGrantsFromAPIResponse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	query = readPrivilegeGrantsStatement()
+
+	log.Println("[DEBUG] Clickhouse: read privilege grant query: ", query)
+	r, err = client.ClickHouseQuery.Query(ctx, projectName, serviceName, defaultDatabase, query)
+	if err != nil {
+		return nil, err
+	}
+
+	privilegeGrants, err := privilegeGrantsFromAPIResponse(r)
+	if err != nil {
+		return nil, err
+	}
+	return &grantsOfService{roleGrants: roleGrants, privilegeGrants: privilegeGrants}, nil
+}
+
+// RevokePrivilegeGrantOption keeps the privilege, but the grantee can't grant it anymore
+func RevokePrivilegeGrantOption(
+	ctx context.Context,
+	client *aiven.Client,
+	projectName string,
+	serviceName string,
+	grant PrivilegeGrant,
+) error {
+	defer invalidateGrantCache(projectName, serviceName)
+
+	query := revokePrivilegeGrantOptionStatement(grant)
+
+	log.Println("[DEBUG] grant option revocation query: ", query)
+	_, err := client.ClickHouseQuery.Query(ctx, projectName, serviceName, defaultDatabase, query)
+	return err
 }
 
 func RevokePrivilegeGrant(
@@ -148,6 +227,8 @@ func RevokePrivilegeGrant(
 	serviceName string,
 	grant PrivilegeGrant,
 ) error {
+	defer invalidateGrantCache(projectName, serviceName)
+
 	query := revokePrivilegeGrantStatement(grant)
 
 	log.Println("[DEBUG] privilege revocation query: ", query)
@@ -196,9 +277,17 @@ func createPrivilegeGrantStatement(grant PrivilegeGrant) string {
 }
 
 func revokePrivilegeGrantStatement(grant PrivilegeGrant) string {
+	return revokeStatement("REVOKE", grant)
+}
+
+func revokePrivilegeGrantOptionStatement(grant PrivilegeGrant) string {
+	return revokeStatement("REVOKE GRANT OPTION FOR", grant)
+}
+
+func revokeStatement(prefix string, grant PrivilegeGrant) string {
 	b := new(strings.Builder)
 
-	b.WriteString("REVOKE")
+	b.WriteString(prefix)
 	b.WriteString(" ")
 	b.WriteString(grant.Privilege)
 
@@ -218,6 +307,100 @@ func revokePrivilegeGrantStatement(grant PrivilegeGrant) string {
 	return b.String()
 }
 
+// privilegeGrantsDiff the statements to turn the old grants into the new ones,
+// the grants that are in both are not touched
+type privilegeGrantsDiff struct {
+	Revoke            []PrivilegeGrant
+	RevokeGrantOption []PrivilegeGrant
+	Grant             []PrivilegeGrant
+}
+
+func diffPrivilegeGrants(old, new []PrivilegeGrant) privilegeGrantsDiff {
+	var diff privilegeGrantsDiff
+	for _, o := range old {
+		found := false
+		for _, n := range new {
+			if o.onSameObject(n) {
+				found = true
+				// GRANT ... WITH GRANT OPTION adds the option, it must be revoked separately
+				if o.WithGrant && !n.WithGrant {
+					diff.RevokeGrantOption = append(diff.RevokeGrantOption, n)
+				}
+				break
+			}
+		}
+		if !found {
+			diff.Revoke = append(diff.Revoke, o)
+		}
+	}
+
+	for _, n := range new {
+		found := false
+		for _, o := range old {
+			if n.equals(o) || (n.onSameObject(o) && !n.WithGrant) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			diff.Grant = append(diff.Grant, n)
+		}
+	}
+
+	// The kept grants on the narrower objects are revoked with the broader ones, so those are granted again
+	for _, n := range new {
+		if containsPrivilegeGrant(diff.Grant, n) {
+			continue
+		}
+		if coveredByAny(diff.Revoke, n) || (n.WithGrant && coveredByAny(diff.RevokeGrantOption, n)) {
+			diff.Grant = append(diff.Grant, n)
+		}
+	}
+	return diff
+}
+
+func containsPrivilegeGrant(grants []PrivilegeGrant, grant PrivilegeGrant) bool {
+	for _, g := range grants {
+		if g.equals(grant) {
+			return true
+		}
+	}
+	return false
+}
+
+func coveredByAny(grants []PrivilegeGrant, grant PrivilegeGrant) bool {
+	for _, g := range grants {
+		if g.covers(grant) {
+			return true
+		}
+	}
+	return false
+}
+
+// diffRoleGrants returns the role grants to revoke and to grant
+func diffRoleGrants(old, new []RoleGrant) (revoke, grant []RoleGrant) {
+	contains := func(grants []RoleGrant, g RoleGrant) bool {
+		for _, v := range grants {
+			if v.equals(g) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, o := range old {
+		if !contains(new, o) {
+			revoke = append(revoke, o)
+		}
+	}
+	for _, n := range new {
+		if !contains(old, n) {
+			grant = append(grant, n)
+		}
+	}
+	return revoke, grant
+}
+
 func readPrivilegeGrantsStatement() string {
 	return "SELECT * FROM system.grants"
 }
@@ -0,0 +1,27 @@
+package clickhouse
+
+import (
+	"time"
+
+	"github.com/aiven/terraform-provider-aiven/internal/sdkprovider/ttlcache"
+)
+
+// defaultGrantCacheTTL how long the grants of a service are served from the cache,
+// long enough for a refresh of all the grant resources of a plan
+const defaultGrantCacheTTL = 30 * time.Second
+
+// grantsOfService the grants of all the grantees of a service
+type grantsOfService struct {
+	roleGrants      []RoleGrant
+	privilegeGrants []PrivilegeGrant
+}
+
+// singleGrantCache reads system.role_grants and system.grants once per service and serves every grantee from them.
+// Each grant resource would run both queries on each refresh otherwise.
+// Must be invalidated after GRANT and REVOKE, see invalidateGrantCache.
+var singleGrantCache = ttlcache.New[*grantsOfService](defaultGrantCacheTTL, time.Now)
+
+// invalidateGrantCache must be called after the grants of the service are changed
+func invalidateGrantCache(projectName, serviceName string) {
+	singleGrantCache.Invalidate(ttlcache.Key(projectName, serviceName))
+}
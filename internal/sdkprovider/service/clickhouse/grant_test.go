@@ -0,0 +1,110 @@
+package clickhouse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffPrivilegeGrants(t *testing.T) {
+	user := Grantee{User: "alice"}
+	selectDB := PrivilegeGrant{Grantee: user, Database: "db", Privilege: "SELECT"}
+	selectDBWithGrant := PrivilegeGrant{Grantee: user, Database: "db", Privilege: "SELECT", WithGrant: true}
+	insertTable := PrivilegeGrant{Grantee: user, Database: "db", Table: "t", Privilege: "INSERT"}
+	insertColumn := PrivilegeGrant{Grantee: user, Database: "db", Table: "t", Column: "c", Privilege: "INSERT"}
+	selectTable := PrivilegeGrant{Grantee: user, Database: "db", Table: "t", Privilege: "SELECT"}
+	selectTableWithGrant := PrivilegeGrant{Grantee: user, Database: "db", Table: "t", Privilege: "SELECT", WithGrant: true}
+	allDB := PrivilegeGrant{Grantee: user, Database: "db", Privilege: "ALL"}
+	selectOtherDB := PrivilegeGrant{Grantee: user, Database: "other", Table: "t", Privilege: "SELECT"}
+
+	testdata := []struct {
+		name     string
+		old      []PrivilegeGrant
+		new      []PrivilegeGrant
+		expected privilegeGrantsDiff
+	}{
+		{
+			name:     "unchanged grants are kept",
+			old:      []PrivilegeGrant{selectDB, insertTable},
+			new:      []PrivilegeGrant{insertTable, selectDB},
+			expected: privilegeGrantsDiff{},
+		},
+		{
+			name:     "added and removed",
+			old:      []PrivilegeGrant{selectDB, insertTable},
+			new:      []PrivilegeGrant{selectDB, insertColumn},
+			expected: privilegeGrantsDiff{Revoke: []PrivilegeGrant{insertTable}, Grant: []PrivilegeGrant{insertColumn}},
+		},
+		{
+			name:     "grant option added",
+			old:      []PrivilegeGrant{selectDB},
+			new:      []PrivilegeGrant{selectDBWithGrant},
+			expected: privilegeGrantsDiff{Grant: []PrivilegeGrant{selectDBWithGrant}},
+		},
+		{
+			name:     "grant option removed keeps the privilege",
+			old:      []PrivilegeGrant{selectDBWithGrant},
+			new:      []PrivilegeGrant{selectDB},
+			expected: privilegeGrantsDiff{RevokeGrantOption: []PrivilegeGrant{selectDB}},
+		},
+		{
+			name: "database revoke grants the kept table privilege again",
+			old:  []PrivilegeGrant{selectDB, selectTable, insertTable, selectOtherDB},
+			new:  []PrivilegeGrant{selectTable, insertTable, selectOtherDB},
+			expected: privilegeGrantsDiff{
+				Revoke: []PrivilegeGrant{selectDB},
+				Grant:  []PrivilegeGrant{selectTable},
+			},
+		},
+		{
+			name: "table revoke grants the kept column privilege again",
+			old:  []PrivilegeGrant{insertTable, insertColumn},
+			new:  []PrivilegeGrant{insertColumn},
+			expected: privilegeGrantsDiff{
+				Revoke: []PrivilegeGrant{insertTable},
+				Grant:  []PrivilegeGrant{insertColumn},
+			},
+		},
+		{
+			name: "ALL revoke grants every kept privilege of the database again",
+			old:  []PrivilegeGrant{allDB, selectTable, insertColumn},
+			new:  []PrivilegeGrant{selectTable, insertColumn},
+			expected: privilegeGrantsDiff{
+				Revoke: []PrivilegeGrant{allDB},
+				Grant:  []PrivilegeGrant{selectTable, insertColumn},
+			},
+		},
+		{
+			name: "grant option revoke grants the kept table grant option again",
+			old:  []PrivilegeGrant{selectDBWithGrant, selectTableWithGrant},
+			new:  []PrivilegeGrant{selectDB, selectTableWithGrant},
+			expected: privilegeGrantsDiff{
+				RevokeGrantOption: []PrivilegeGrant{selectDB},
+				Grant:             []PrivilegeGrant{selectTableWithGrant},
+			},
+		},
+	}
+
+	for _, tt := range testdata {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, diffPrivilegeGrants(tt.old, tt.new))
+		})
+	}
+}
+
+func TestDiffRoleGrants(t *testing.T) {
+	user := Grantee{User: "alice"}
+	reader := RoleGrant{Grantee: user, Role: "reader"}
+	writer := RoleGrant{Grantee: user, Role: "writer"}
+	admin := RoleGrant{Grantee: user, Role: "admin"}
+
+	revoke, grant := diffRoleGrants([]RoleGrant{reader, writer}, []RoleGrant{writer, admin})
+	assert.Equal(t, []RoleGrant{reader}, revoke)
+	assert.Equal(t, []RoleGrant{admin}, grant)
+}
+
+func TestRevokePrivilegeGrantOptionStatement(t *testing.T) {
+	grant := PrivilegeGrant{Grantee: Grantee{Role: "reader"}, Database: "db", Table: "t", Privilege: "SELECT", WithGrant: true}
+	assert.Equal(t, "REVOKE GRANT OPTION FOR SELECT ON `db`.`t` FROM `reader`", revokePrivilegeGrantOptionStatement(grant))
+	assert.Equal(t, "REVOKE SELECT ON `db`.`t` FROM `reader`", revokePrivilegeGrantStatement(grant))
+}
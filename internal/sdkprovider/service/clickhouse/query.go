@@ -0,0 +1,106 @@
+package clickhouse
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aiven/aiven-go-client/v2"
+)
+
+// queryResultRow a row of the query response by the column name.
+// Nullable columns are nil, 64-bit integers may come as strings.
+type queryResultRow map[string]any
+
+// queryResultRows maps the rows of the response by the column names,
+// returns an error if any of the expected columns is missing
+func queryResultRows(r *aiven.ClickhouseQueryResponse, table string, columns ...string) ([]queryResultRow, error) {
+	columnNameMap := make(map[string]int)
+	for i, md := range r.Meta {
+		columnNameMap[md.Name] = i
+	}
+	for _, columnName := range columns {
+		if _, ok := columnNameMap[columnName]; !ok {
+			return nil, fmt.Errorf("'%s' metadata is missing the '%s' column", table, columnName)
+		}
+	}
+
+	rows := make([]queryResultRow, 0, len(r.Data))
+	for i := range r.Data {
+		column, ok := r.Data[i].([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("'%s' row %d is not a list of columns", table, i)
+		}
+
+		row := make(queryResultRow, len(columnNameMap))
+		for name, j := range columnNameMap {
+			if j < len(column) {
+				row[name] = column[j]
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func (r queryResultRow) string(column string) string {
+	s, _ := r[column].(string)
+	return s
+}
+
+// bool returns a UInt8 column as a boolean
+func (r queryResultRow) bool(column string) bool {
+	switch v := r[column].(type) {
+	case json.Number:
+		return v.String() == "1"
+	case bool:
+		return v
+	}
+	return false
+}
+
+// int returns a nullable number column, false if it is null
+func (r queryResultRow) int(column string) (int, bool) {
+	var s string
+	switch v := r[column].(type) {
+	case json.Number:
+		s = v.String()
+	case string:
+		s = v
+	default:
+		return 0, false
+	}
+
+	// Float64 columns, e.g. execution time in seconds
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false
+	}
+	return int(f), true
+}
+
+// strings returns an Array(String) column
+func (r queryResultRow) strings(column string) []string {
+	list, _ := r[column].([]interface{})
+	res := make([]string, 0, len(list))
+	for _, v := range list {
+		if s, ok := v.(string); ok {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+// rolesOrUsersStatement returns the users and roles of the TO clause
+func rolesOrUsersStatement(names []string) string {
+	if len(names) == 0 {
+		return "NONE"
+	}
+
+	escaped := make([]string, len(names))
+	for i, n := range names {
+		escaped[i] = escape(n)
+	}
+	return strings.Join(escaped, ", ")
+}
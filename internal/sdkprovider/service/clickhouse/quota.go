@@ -0,0 +1,228 @@
+package clickhouse
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"golang.org/x/exp/slices"
+)
+
+// quotaKeyTypes how the usage is tracked, empty means the usage of all users is tracked together
+var quotaKeyTypes = []string{
+	"user_name",
+	"ip_address",
+	"forwarded_ip_address",
+	"client_key",
+	"client_key,user_name",
+	"client_key,ip_address",
+}
+
+// quotaLimits the limits of an interval, the max_<limit> columns of system.quota_limits
+var quotaLimits = []string{
+	"queries",
+	"query_selects",
+	"query_inserts",
+	"errors",
+	"result_rows",
+	"result_bytes",
+	"read_rows",
+	"read_bytes",
+	"execution_time",
+}
+
+// QuotaInterval the limits for the duration in seconds, zero limit means no limit
+type QuotaInterval struct {
+	Duration   int
+	Randomized bool
+	Limits     map[string]int
+}
+
+type Quota struct {
+	Name      string
+	KeyType   string
+	Intervals []QuotaInterval
+	ApplyTo   []string
+}
+
+func CreateQuota(ctx context.Context, client *aiven.Client, projectName, serviceName string, quota Quota) error {
+	query := createQuotaStatement(quota)
+
+	log.Println("[DEBUG] Clickhouse: create quota query: ", query)
+	_, err := client.ClickHouseQuery.Query(ctx, projectName, serviceName, defaultDatabase, query)
+	return err
+}
+
+// AlterQuota changes the quota in place, so the usage tracked so far is kept
+func AlterQuota(ctx context.Context, client *aiven.Client, projectName, serviceName string, oldQuota, newQuota Quota) error {
+	query := alterQuotaStatement(oldQuota, newQuota)
+
+	log.Println("[DEBUG] Clickhouse: alter quota query: ", query)
+	_, err := client.ClickHouseQuery.Query(ctx, projectName, serviceName, defaultDatabase, query)
+	return err
+}
+
+// ReadQuota returns the quota from system.quotas and its intervals from system.quota_limits.
+// Returns aiven.Error with 404 status if the quota doesn't exist.
+func ReadQuota(ctx context.Context, client *aiven.Client, projectName, serviceName, name string) (*Quota, error) {
+	query := readQuotaStatement(name)
+
+	log.Println("[DEBUG] Clickhouse: read quota query: ", query)
+	r, err := client.ClickHouseQuery.Query(ctx, projectName, serviceName, defaultDatabase, query)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := queryResultRows(r, "system.quotas", "name", "keys", "apply_to_list")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, aiven.Error{Status: http.StatusNotFound, Message: fmt.Sprintf("quota %q not found", name)}
+	}
+
+	quota := &Quota{
+		Name:      rows[0].string("name"),
+		KeyType:   strings.Join(rows[0].strings("keys"), ","),
+		Intervals: make([]QuotaInterval, 0),
+		ApplyTo:   rows[0].strings("apply_to_list"),
+	}
+
+	query = readQuotaLimitsStatement(name)
+
+	log.Println("[DEBUG] Clickhouse: read quota limits query: ", query)
+	r, err = client.ClickHouseQuery.Query(ctx, projectName, serviceName, defaultDatabase, query)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := []string{"duration", "is_randomized_interval"}
+	for _, l := range quotaLimits {
+		columns = append(columns, "max_"+l)
+	}
+
+	limits, err := queryResultRows(r, "system.quota_limits", columns...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range limits {
+		duration, _ := row.int("duration")
+		interval := QuotaInterval{
+			Duration:   duration,
+			Randomized: row.bool("is_randomized_interval"),
+			Limits:     make(map[string]int),
+		}
+
+		for _, l := range quotaLimits {
+			if v, ok := row.int("max_" + l); ok {
+				interval.Limits[l] = v
+			}
+		}
+		quota.Intervals = append(quota.Intervals, interval)
+	}
+	return quota, nil
+}
+
+func DropQuota(ctx context.Context, client *aiven.Client, projectName, serviceName, name string) error {
+	query := dropQuotaStatement(name)
+
+	log.Println("[DEBUG] Clickhouse: drop quota query: ", query)
+	_, err := client.ClickHouseQuery.Query(ctx, projectName, serviceName, defaultDatabase, query)
+	return err
+}
+
+// quotaKeyStatement the key types are keywords, they are validated in the schema
+func quotaKeyStatement(keyType string) string {
+	if keyType == "" {
+		return " NOT KEYED"
+	}
+	return " KEYED BY " + keyType
+}
+
+func quotaIntervalStatement(interval QuotaInterval, withZeroLimits bool) string {
+	b := new(strings.Builder)
+	b.WriteString("FOR")
+
+	if interval.Randomized {
+		b.WriteString(" RANDOMIZED")
+	}
+	b.WriteString(fmt.Sprintf(" INTERVAL %d second", interval.Duration))
+
+	limits := make([]string, 0, len(quotaLimits))
+	for _, l := range quotaLimits {
+		// Zero limits remove the limits that are not in the config anymore
+		if v := interval.Limits[l]; v != 0 || withZeroLimits {
+			limits = append(limits, fmt.Sprintf("%s = %d", l, v))
+		}
+	}
+
+	if len(limits) == 0 {
+		b.WriteString(" TRACKING ONLY")
+	} else {
+		b.WriteString(" MAX " + strings.Join(limits, ", "))
+	}
+	return b.String()
+}
+
+func createQuotaStatement(quota Quota) string {
+	b := new(strings.Builder)
+	b.WriteString("CREATE QUOTA ")
+	b.WriteString(escape(quota.Name))
+	b.WriteString(quotaKeyStatement(quota.KeyType))
+
+	intervals := make([]string, len(quota.Intervals))
+	for i, interval := range quota.Intervals {
+		intervals[i] = quotaIntervalStatement(interval, false)
+	}
+	if len(intervals) > 0 {
+		b.WriteString(" " + strings.Join(intervals, ", "))
+	}
+
+	b.WriteString(" TO " + rolesOrUsersStatement(quota.ApplyTo))
+	return b.String()
+}
+
+// alterQuotaStatement sets all limits of the new intervals and removes the old intervals,
+// ALTER QUOTA changes only the limits and the intervals it mentions
+func alterQuotaStatement(oldQuota, newQuota Quota) string {
+	b := new(strings.Builder)
+	b.WriteString("ALTER QUOTA ")
+	b.WriteString(escape(newQuota.Name))
+	b.WriteString(quotaKeyStatement(newQuota.KeyType))
+
+	intervals := make([]string, 0, len(oldQuota.Intervals)+len(newQuota.Intervals))
+	for _, o := range oldQuota.Intervals {
+		removed := !slices.ContainsFunc(newQuota.Intervals, func(n QuotaInterval) bool {
+			return n.Duration == o.Duration
+		})
+		if removed {
+			intervals = append(intervals, fmt.Sprintf("FOR INTERVAL %d second NO LIMITS", o.Duration))
+		}
+	}
+	for _, n := range newQuota.Intervals {
+		intervals = append(intervals, quotaIntervalStatement(n, true))
+	}
+	if len(intervals) > 0 {
+		b.WriteString(" " + strings.Join(intervals, ", "))
+	}
+
+	b.WriteString(" TO " + rolesOrUsersStatement(newQuota.ApplyTo))
+	return b.String()
+}
+
+func readQuotaStatement(name string) string {
+	return fmt.Sprintf("SELECT * FROM system.quotas WHERE name = %s", escapeString(name))
+}
+
+func readQuotaLimitsStatement(name string) string {
+	return fmt.Sprintf("SELECT * FROM system.quota_limits WHERE quota_name = %s ORDER BY duration", escapeString(name))
+}
+
+func dropQuotaStatement(name string) string {
+	return fmt.Sprintf("DROP QUOTA IF EXISTS %s", escape(name))
+}
@@ -0,0 +1,41 @@
+package clickhouse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuotaStatements(t *testing.T) {
+	quota := Quota{
+		Name:    "daily",
+		KeyType: "user_name",
+		Intervals: []QuotaInterval{
+			{Duration: 86400, Limits: map[string]int{"queries": 1000, "errors": 10}},
+			{Duration: 3600, Randomized: true, Limits: map[string]int{}},
+		},
+		ApplyTo: []string{"analyst"},
+	}
+
+	assert.Equal(
+		t,
+		"CREATE QUOTA `daily` KEYED BY user_name "+
+			"FOR INTERVAL 86400 second MAX queries = 1000, errors = 10, "+
+			"FOR RANDOMIZED INTERVAL 3600 second TRACKING ONLY TO `analyst`",
+		createQuotaStatement(quota),
+	)
+
+	// The hourly interval is removed, the limits not in the config are reset
+	updated := Quota{
+		Name:      "daily",
+		Intervals: []QuotaInterval{{Duration: 86400, Limits: map[string]int{"queries": 500}}},
+	}
+	assert.Equal(
+		t,
+		"ALTER QUOTA `daily` NOT KEYED "+
+			"FOR INTERVAL 3600 second NO LIMITS, "+
+			"FOR INTERVAL 86400 second MAX queries = 500, query_selects = 0, query_inserts = 0, errors = 0, "+
+			"result_rows = 0, result_bytes = 0, read_rows = 0, read_bytes = 0, execution_time = 0 TO NONE",
+		alterQuotaStatement(quota, updated),
+	)
+}
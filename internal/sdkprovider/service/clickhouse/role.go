@@ -40,7 +40,10 @@ func RoleExists(ctx context.Context, client *aiven.Client, projectName, serviceN
 	return len(r.Data) > 0, nil
 }
 
+// DropRole the grants of the role and to the role are dropped too
 func DropRole(ctx context.Context, client *aiven.Client, projectName, serviceName, roleName string) error {
+	defer invalidateGrantCache(projectName, serviceName)
+
 	query := dropRoleStatement(roleName)
 
 	log.Println("[DEBUG] Clickhouse: drop role query: ", query)
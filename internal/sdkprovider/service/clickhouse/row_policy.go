@@ -0,0 +1,121 @@
+package clickhouse
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/aiven/aiven-go-client/v2"
+)
+
+type RowPolicy struct {
+	Name        string
+	Database    string
+	Table       string
+	Condition   string
+	Restrictive bool
+	ApplyTo     []string
+}
+
+func CreateRowPolicy(ctx context.Context, client *aiven.Client, projectName, serviceName string, policy RowPolicy) error {
+	query := createRowPolicyStatement(policy)
+
+	log.Println("[DEBUG] Clickhouse: create row policy query: ", query)
+	_, err := client.ClickHouseQuery.Query(ctx, projectName, serviceName, defaultDatabase, query)
+	return err
+}
+
+func AlterRowPolicy(ctx context.Context, client *aiven.Client, projectName, serviceName string, policy RowPolicy) error {
+	query := alterRowPolicyStatement(policy)
+
+	log.Println("[DEBUG] Clickhouse: alter row policy query: ", query)
+	_, err := client.ClickHouseQuery.Query(ctx, projectName, serviceName, defaultDatabase, query)
+	return err
+}
+
+// ReadRowPolicy returns the policy from system.row_policies, the condition is formatted by ClickHouse.
+// Returns aiven.Error with 404 status if the policy doesn't exist.
+func ReadRowPolicy(
+	ctx context.Context,
+	client *aiven.Client,
+	projectName, serviceName, name, database, table string,
+) (*RowPolicy, error) {
+	query := readRowPolicyStatement(name, database, table)
+
+	log.Println("[DEBUG] Clickhouse: read row policy query: ", query)
+	r, err := client.ClickHouseQuery.Query(ctx, projectName, serviceName, defaultDatabase, query)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := queryResultRows(
+		r, "system.row_policies",
+		"short_name", "database", "table", "select_filter", "is_restrictive", "apply_to_list",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, aiven.Error{Status: http.StatusNotFound, Message: fmt.Sprintf("row policy %q on %s.%s not found", name, database, table)}
+	}
+
+	row := rows[0]
+	return &RowPolicy{
+		Name:        row.string("short_name"),
+		Database:    row.string("database"),
+		Table:       row.string("table"),
+		Condition:   row.string("select_filter"),
+		Restrictive: row.bool("is_restrictive"),
+		ApplyTo:     row.strings("apply_to_list"),
+	}, nil
+}
+
+func DropRowPolicy(ctx context.Context, client *aiven.Client, projectName, serviceName, name, database, table string) error {
+	query := dropRowPolicyStatement(name, database, table)
+
+	log.Println("[DEBUG] Clickhouse: drop row policy query: ", query)
+	_, err := client.ClickHouseQuery.Query(ctx, projectName, serviceName, defaultDatabase, query)
+	return err
+}
+
+func rowPolicyNameStatement(name, database, table string) string {
+	return fmt.Sprintf("%s ON %s.%s", escape(name), escape(database), escape(table))
+}
+
+// rowPolicyBodyStatement the part that is the same in CREATE and ALTER,
+// the condition is an SQL expression, so it can't be escaped
+func rowPolicyBodyStatement(policy RowPolicy) string {
+	b := new(strings.Builder)
+
+	if policy.Restrictive {
+		b.WriteString(" AS RESTRICTIVE")
+	} else {
+		b.WriteString(" AS PERMISSIVE")
+	}
+
+	b.WriteString(fmt.Sprintf(" FOR SELECT USING %s", policy.Condition))
+	b.WriteString(fmt.Sprintf(" TO %s", rolesOrUsersStatement(policy.ApplyTo)))
+	return b.String()
+}
+
+func createRowPolicyStatement(policy RowPolicy) string {
+	return "CREATE ROW POLICY " + rowPolicyNameStatement(policy.Name, policy.Database, policy.Table) + rowPolicyBodyStatement(policy)
+}
+
+func alterRowPolicyStatement(policy RowPolicy) string {
+	return "ALTER ROW POLICY " + rowPolicyNameStatement(policy.Name, policy.Database, policy.Table) + rowPolicyBodyStatement(policy)
+}
+
+func readRowPolicyStatement(name, database, table string) string {
+	return fmt.Sprintf(
+		"SELECT * FROM system.row_policies WHERE short_name = %s AND database = %s AND table = %s",
+		escapeString(name), escapeString(database), escapeString(table),
+	)
+}
+
+func dropRowPolicyStatement(name, database, table string) string {
+	return "DROP ROW POLICY IF EXISTS " + rowPolicyNameStatement(name, database, table)
+}
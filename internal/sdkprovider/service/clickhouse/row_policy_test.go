@@ -0,0 +1,74 @@
+package clickhouse
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRowPolicyStatements(t *testing.T) {
+	policy := RowPolicy{
+		Name:      "tenant",
+		Database:  "db",
+		Table:     "events",
+		Condition: "tenant_id = 42",
+		ApplyTo:   []string{"reader", "o'neil"},
+	}
+
+	assert.Equal(
+		t,
+		"CREATE ROW POLICY `tenant` ON `db`.`events` AS PERMISSIVE FOR SELECT USING tenant_id = 42 TO `reader`, `o'neil`",
+		createRowPolicyStatement(policy),
+	)
+
+	policy.Restrictive = true
+	policy.ApplyTo = nil
+	assert.Equal(
+		t,
+		"ALTER ROW POLICY `tenant` ON `db`.`events` AS RESTRICTIVE FOR SELECT USING tenant_id = 42 TO NONE",
+		alterRowPolicyStatement(policy),
+	)
+
+	assert.Equal(
+		t,
+		"SELECT * FROM system.row_policies WHERE short_name = 'tenant' AND database = 'db' AND table = 'o\\'neil'",
+		readRowPolicyStatement("tenant", "db", "o'neil"),
+	)
+	assert.Equal(t, "DROP ROW POLICY IF EXISTS `tenant` ON `db`.`events`", dropRowPolicyStatement("tenant", "db", "events"))
+}
+
+func TestQueryResultRows(t *testing.T) {
+	r := &aiven.ClickhouseQueryResponse{
+		Meta: []aiven.ClickhouseQueryColumnMeta{
+			{Name: "short_name"},
+			{Name: "is_restrictive"},
+			{Name: "apply_to_list"},
+			{Name: "max_queries"},
+			{Name: "max_execution_time"},
+		},
+		Data: []interface{}{
+			[]interface{}{"tenant", json.Number("1"), []interface{}{"reader"}, "100", nil},
+		},
+	}
+
+	rows, err := queryResultRows(r, "test", "short_name", "is_restrictive")
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+
+	assert.Equal(t, "tenant", rows[0].string("short_name"))
+	assert.True(t, rows[0].bool("is_restrictive"))
+	assert.Equal(t, []string{"reader"}, rows[0].strings("apply_to_list"))
+
+	// UInt64 is quoted, null is no value
+	v, ok := rows[0].int("max_queries")
+	assert.True(t, ok)
+	assert.Equal(t, 100, v)
+	_, ok = rows[0].int("max_execution_time")
+	assert.False(t, ok)
+
+	_, err = queryResultRows(r, "test", "select_filter")
+	assert.EqualError(t, err, "'test' metadata is missing the 'select_filter' column")
+}
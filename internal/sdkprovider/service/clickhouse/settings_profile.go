@@ -0,0 +1,182 @@
+package clickhouse
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/aiven/aiven-go-client/v2"
+)
+
+// settingsProfileWritabilities the constraints of a setting, empty means the profile doesn't constrain it
+var settingsProfileWritabilities = []string{"WRITABLE", "CONST", "CHANGEABLE_IN_READONLY"}
+
+type SettingsProfileSetting struct {
+	Name        string
+	Value       string
+	Min         string
+	Max         string
+	Writability string
+}
+
+type SettingsProfile struct {
+	Name     string
+	Settings []SettingsProfileSetting
+	Inherit  []string
+	ApplyTo  []string
+}
+
+func CreateSettingsProfile(ctx context.Context, client *aiven.Client, projectName, serviceName string, profile SettingsProfile) error {
+	query := createSettingsProfileStatement(profile)
+
+	log.Println("[DEBUG] Clickhouse: create settings profile query: ", query)
+	_, err := client.ClickHouseQuery.Query(ctx, projectName, serviceName, defaultDatabase, query)
+	return err
+}
+
+func AlterSettingsProfile(ctx context.Context, client *aiven.Client, projectName, serviceName string, profile SettingsProfile) error {
+	query := alterSettingsProfileStatement(profile)
+
+	log.Println("[DEBUG] Clickhouse: alter settings profile query: ", query)
+	_, err := client.ClickHouseQuery.Query(ctx, projectName, serviceName, defaultDatabase, query)
+	return err
+}
+
+// ReadSettingsProfile returns the profile from system.settings_profiles and its settings and inherited profiles
+// from system.settings_profile_elements. Returns aiven.Error with 404 status if the profile doesn't exist.
+func ReadSettingsProfile(ctx context.Context, client *aiven.Client, projectName, serviceName, name string) (*SettingsProfile, error) {
+	query := readSettingsProfileStatement(name)
+
+	log.Println("[DEBUG] Clickhouse: read settings profile query: ", query)
+	r, err := client.ClickHouseQuery.Query(ctx, projectName, serviceName, defaultDatabase, query)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := queryResultRows(r, "system.settings_profiles", "name", "apply_to_list")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, aiven.Error{Status: http.StatusNotFound, Message: fmt.Sprintf("settings profile %q not found", name)}
+	}
+
+	profile := &SettingsProfile{
+		Name:     rows[0].string("name"),
+		Settings: make([]SettingsProfileSetting, 0),
+		Inherit:  make([]string, 0),
+		ApplyTo:  rows[0].strings("apply_to_list"),
+	}
+
+	query = readSettingsProfileElementsStatement(name)
+
+	log.Println("[DEBUG] Clickhouse: read settings profile elements query: ", query)
+	r, err = client.ClickHouseQuery.Query(ctx, projectName, serviceName, defaultDatabase, query)
+	if err != nil {
+		return nil, err
+	}
+
+	elements, err := queryResultRows(
+		r, "system.settings_profile_elements",
+		"setting_name", "value", "min", "max", "inherit_profile",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range elements {
+		if inherit := e.string("inherit_profile"); inherit != "" {
+			profile.Inherit = append(profile.Inherit, inherit)
+			continue
+		}
+
+		// writability replaced the readonly column in ClickHouse 22.11
+		profile.Settings = append(profile.Settings, SettingsProfileSetting{
+			Name:        e.string("setting_name"),
+			Value:       e.string("value"),
+			Min:         e.string("min"),
+			Max:         e.string("max"),
+			Writability: e.string("writability"),
+		})
+	}
+	return profile, nil
+}
+
+func DropSettingsProfile(ctx context.Context, client *aiven.Client, projectName, serviceName, name string) error {
+	query := dropSettingsProfileStatement(name)
+
+	log.Println("[DEBUG] Clickhouse: drop settings profile query: ", query)
+	_, err := client.ClickHouseQuery.Query(ctx, projectName, serviceName, defaultDatabase, query)
+	return err
+}
+
+// settingsProfileElementsStatement returns the SETTINGS clause, inherited profiles go first,
+// so the settings of the profile override them. The values are string literals, ClickHouse converts them.
+func settingsProfileElementsStatement(profile SettingsProfile) string {
+	elements := make([]string, 0, len(profile.Inherit)+len(profile.Settings))
+	for _, p := range profile.Inherit {
+		elements = append(elements, "INHERIT "+escape(p))
+	}
+
+	for _, s := range profile.Settings {
+		b := new(strings.Builder)
+		b.WriteString(escape(s.Name))
+
+		if s.Value != "" {
+			b.WriteString(" = " + escapeString(s.Value))
+		}
+		if s.Min != "" {
+			b.WriteString(" MIN " + escapeString(s.Min))
+		}
+		if s.Max != "" {
+			b.WriteString(" MAX " + escapeString(s.Max))
+		}
+		if s.Writability != "" {
+			b.WriteString(" " + s.Writability)
+		}
+		elements = append(elements, b.String())
+	}
+
+	if len(elements) == 0 {
+		return ""
+	}
+	return " SETTINGS " + strings.Join(elements, ", ")
+}
+
+func createSettingsProfileStatement(profile SettingsProfile) string {
+	return fmt.Sprintf(
+		"CREATE SETTINGS PROFILE %s%s TO %s",
+		escape(profile.Name), settingsProfileElementsStatement(profile), rolesOrUsersStatement(profile.ApplyTo),
+	)
+}
+
+// alterSettingsProfileStatement replaces all settings of the profile
+func alterSettingsProfileStatement(profile SettingsProfile) string {
+	elements := settingsProfileElementsStatement(profile)
+	if elements == "" {
+		elements = " SETTINGS NONE"
+	}
+
+	return fmt.Sprintf(
+		"ALTER SETTINGS PROFILE %s%s TO %s",
+		escape(profile.Name), elements, rolesOrUsersStatement(profile.ApplyTo),
+	)
+}
+
+func readSettingsProfileStatement(name string) string {
+	return fmt.Sprintf("SELECT * FROM system.settings_profiles WHERE name = %s", escapeString(name))
+}
+
+func readSettingsProfileElementsStatement(name string) string {
+	return fmt.Sprintf(
+		"SELECT * FROM system.settings_profile_elements WHERE profile_name = %s ORDER BY index",
+		escapeString(name),
+	)
+}
+
+func dropSettingsProfileStatement(name string) string {
+	return fmt.Sprintf("DROP SETTINGS PROFILE IF EXISTS %s", escape(name))
+}
@@ -0,0 +1,32 @@
+package clickhouse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSettingsProfileStatements(t *testing.T) {
+	profile := SettingsProfile{
+		Name:    "analysts",
+		Inherit: []string{"readonly"},
+		Settings: []SettingsProfileSetting{
+			{Name: "max_memory_usage", Value: "10000000000", Max: "20000000000"},
+			{Name: "load_balancing", Value: "random", Writability: "CONST"},
+		},
+		ApplyTo: []string{"analyst"},
+	}
+
+	assert.Equal(
+		t,
+		"CREATE SETTINGS PROFILE `analysts` SETTINGS INHERIT `readonly`, "+
+			"`max_memory_usage` = '10000000000' MAX '20000000000', `load_balancing` = 'random' CONST TO `analyst`",
+		createSettingsProfileStatement(profile),
+	)
+
+	assert.Equal(
+		t,
+		"ALTER SETTINGS PROFILE `analysts` SETTINGS NONE TO NONE",
+		alterSettingsProfileStatement(SettingsProfile{Name: "analysts"}),
+	)
+}
@@ -0,0 +1,165 @@
+package clickhouse
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/exp/slices"
+)
+
+type sqlTokenKind int
+
+const (
+	sqlTokenWord sqlTokenKind = iota
+	sqlTokenString
+	sqlTokenQuotedIdentifier
+	sqlTokenNumber
+	sqlTokenSymbol
+)
+
+type sqlToken struct {
+	kind  sqlTokenKind
+	value string
+}
+
+// keyword returns the upper-cased word, empty for the other tokens
+func (t sqlToken) keyword() string {
+	if t.kind != sqlTokenWord {
+		return ""
+	}
+	return strings.ToUpper(t.value)
+}
+
+// readOnlyStatements the statements the query data source runs
+var readOnlyStatements = []string{"SELECT", "SHOW", "DESCRIBE", "DESC"}
+
+// tokenizeStatement splits the query into tokens, comments and whitespace are skipped.
+// Quoted strings and identifiers are kept as one token, so keywords and semicolons in them are ignored.
+func tokenizeStatement(query string) ([]sqlToken, error) {
+	runes := []rune(query)
+	tokens := make([]sqlToken, 0)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-', r == '#':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			j := i + 2
+			for j+1 < len(runes) && (runes[j] != '*' || runes[j+1] != '/') {
+				j++
+			}
+			if j+1 >= len(runes) {
+				return nil, fmt.Errorf("unterminated comment")
+			}
+			i = j + 2
+		case r == '\'' || r == '"' || r == '`':
+			j := i + 1
+			for ; j < len(runes) && runes[j] != r; j++ {
+				if runes[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(runes) {
+				return nil, fmt.Errorf("unterminated quote %c", r)
+			}
+
+			kind := sqlTokenQuotedIdentifier
+			if r == '\'' {
+				kind = sqlTokenString
+			}
+			tokens = append(tokens, sqlToken{kind: kind, value: string(runes[i+1 : j])})
+			i = j + 1
+		case r == '_' || unicode.IsLetter(r):
+			j := i
+			for j < len(runes) && (runes[j] == '_' || unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j])) {
+				j++
+			}
+			tokens = append(tokens, sqlToken{kind: sqlTokenWord, value: string(runes[i:j])})
+			i = j
+		case unicode.IsDigit(r):
+			j := i
+			for j < len(runes) && (runes[j] == '.' || runes[j] == '_' || unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j])) {
+				j++
+			}
+			tokens = append(tokens, sqlToken{kind: sqlTokenNumber, value: string(runes[i:j])})
+			i = j
+		default:
+			tokens = append(tokens, sqlToken{kind: sqlTokenSymbol, value: string(r)})
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+// validateReadOnlyStatement returns an error if the query is not a single SELECT, SHOW or DESCRIBE statement.
+// A WITH clause must be followed by a SELECT, INTO OUTFILE is rejected as it writes a file.
+func validateReadOnlyStatement(query string) error {
+	tokens, err := tokenizeStatement(query)
+	if err != nil {
+		return fmt.Errorf("invalid query: %w", err)
+	}
+
+	// A single trailing semicolon is allowed
+	for i, t := range tokens {
+		if t.kind == sqlTokenSymbol && t.value == ";" {
+			if i != len(tokens)-1 {
+				return fmt.Errorf("only a single statement is allowed")
+			}
+			tokens = tokens[:i]
+		}
+	}
+
+	// Parenthesized queries, e.g. "(SELECT 1) UNION ALL (SELECT 2)"
+	start := 0
+	for start < len(tokens) && tokens[start].kind == sqlTokenSymbol && tokens[start].value == "(" {
+		start++
+	}
+	if start == len(tokens) {
+		return fmt.Errorf("the query is empty")
+	}
+
+	first := tokens[start].keyword()
+	switch {
+	case first == "WITH":
+		if firstTopLevelStatement(tokens[start+1:]) != "SELECT" {
+			return fmt.Errorf("WITH must be followed by a SELECT statement")
+		}
+	case !slices.Contains(readOnlyStatements, first):
+		return fmt.Errorf("only %s statements are allowed, got %q", strings.Join(readOnlyStatements, ", "), tokens[start].value)
+	}
+
+	for i := start; i+1 < len(tokens); i++ {
+		if tokens[i].keyword() == "INTO" && tokens[i+1].keyword() == "OUTFILE" {
+			return fmt.Errorf("INTO OUTFILE is not allowed")
+		}
+	}
+	return nil
+}
+
+// statementKeywords the keywords that start a statement, a WITH clause is followed by one of them
+var statementKeywords = []string{
+	"SELECT", "INSERT", "ALTER", "CREATE", "DROP", "DELETE", "UPDATE", "TRUNCATE", "RENAME",
+	"OPTIMIZE", "SYSTEM", "GRANT", "REVOKE", "KILL", "SET", "ATTACH", "DETACH", "EXCHANGE", "USE",
+}
+
+// firstTopLevelStatement returns the first statement keyword outside of any parentheses
+func firstTopLevelStatement(tokens []sqlToken) string {
+	depth := 0
+	for _, t := range tokens {
+		switch {
+		case t.kind == sqlTokenSymbol && t.value == "(":
+			depth++
+		case t.kind == sqlTokenSymbol && t.value == ")":
+			depth--
+		case depth == 0 && slices.Contains(statementKeywords, t.keyword()):
+			return t.keyword()
+		}
+	}
+	return ""
+}
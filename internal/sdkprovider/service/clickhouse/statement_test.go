@@ -0,0 +1,64 @@
+package clickhouse
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateReadOnlyStatement(t *testing.T) {
+	valid := []string{
+		"SELECT name FROM system.databases",
+		"  select 1;",
+		"-- tenant databases\nSELECT name FROM system.databases WHERE name LIKE 'tenant_%'",
+		"/* count */ SELECT count() FROM events",
+		"(SELECT 1) UNION ALL (SELECT 2)",
+		"WITH 42 AS answer SELECT answer",
+		"WITH totals AS (SELECT tenant_id, count() AS c FROM events GROUP BY tenant_id) SELECT * FROM totals",
+		"SHOW DATABASES",
+		"DESCRIBE TABLE events",
+		"desc events",
+		"SELECT 'DROP TABLE events; INSERT' AS text",
+		"SELECT `INTO OUTFILE` FROM events",
+	}
+	for _, q := range valid {
+		assert.NoError(t, validateReadOnlyStatement(q), q)
+	}
+
+	invalid := []string{
+		"",
+		" ; ",
+		"-- SELECT 1",
+		"DROP TABLE events",
+		"INSERT INTO events SELECT * FROM events",
+		"SELECT 1; DROP TABLE events",
+		"/* SELECT */ ALTER TABLE events DELETE WHERE 1",
+		"WITH 1 AS x INSERT INTO events SELECT x",
+		"SELECT * FROM events INTO OUTFILE 'events.csv'",
+		"SELECT 'unterminated",
+		"/* unterminated SELECT 1",
+		"SELECTX 1",
+	}
+	for _, q := range invalid {
+		assert.Error(t, validateReadOnlyStatement(q), q)
+	}
+}
+
+func TestFlattenQueryRows(t *testing.T) {
+	rows, err := flattenQueryRows([]interface{}{
+		[]interface{}{"default", json.Number("42"), nil, []interface{}{"a", "b"}, true},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"default", "42", "", `["a","b"]`, "true"}}, rows)
+
+	_, err = flattenQueryRows([]interface{}{"not a row"})
+	assert.Error(t, err)
+}
+
+func TestClickhouseQueryID(t *testing.T) {
+	a := clickhouseQueryID("project", "service", "db", "SELECT 1")
+	assert.Equal(t, a, clickhouseQueryID("project", "service", "db", "SELECT 1"))
+	assert.NotEqual(t, a, clickhouseQueryID("project", "service", "db", "SELECT 2"))
+	assert.Regexp(t, `^project/service/db/[0-9a-f]{64}$`, a)
+}
@@ -0,0 +1,412 @@
+package clickhouse
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/aiven/aiven-go-client/v2"
+)
+
+type TableColumn struct {
+	Name    string
+	Type    string
+	Default string
+	Comment string
+}
+
+type Table struct {
+	Database    string
+	Name        string
+	Engine      string
+	Columns     []TableColumn
+	OrderBy     string
+	PartitionBy string
+	TTL         string
+	Settings    map[string]string
+}
+
+func CreateTable(ctx context.Context, client *aiven.Client, projectName, serviceName string, table Table) error {
+	query := createTableStatement(table)
+
+	log.Println("[DEBUG] Clickhouse: create table query: ", query)
+	_, err := client.ClickHouseQuery.Query(ctx, projectName, serviceName, defaultDatabase, query)
+	return err
+}
+
+// AlterTable runs the ALTER TABLE commands one by one,
+// ClickHouse doesn't allow some commands on the same column in a single statement
+func AlterTable(ctx context.Context, client *aiven.Client, projectName, serviceName, database, name string, commands []string) error {
+	for _, c := range commands {
+		query := alterTableStatement(database, name, c)
+
+		log.Println("[DEBUG] Clickhouse: alter table query: ", query)
+		if _, err := client.ClickHouseQuery.Query(ctx, projectName, serviceName, defaultDatabase, query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadTable returns the table from system.tables and its columns from system.columns,
+// the expressions are formatted by ClickHouse. The engine, the keys, TTL and settings are parsed from engine_full,
+// the part of create_table_query after "ENGINE = ".
+// Returns aiven.Error with 404 status if the table doesn't exist.
+func ReadTable(ctx context.Context, client *aiven.Client, projectName, serviceName, database, name string) (*Table, error) {
+	query := readTableStatement(database, name)
+
+	log.Println("[DEBUG] Clickhouse: read table query: ", query)
+	r, err := client.ClickHouseQuery.Query(ctx, projectName, serviceName, defaultDatabase, query)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := queryResultRows(r, "system.tables", "database", "name", "engine_full")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, aiven.Error{Status: http.StatusNotFound, Message: fmt.Sprintf("table %s.%s not found", database, name)}
+	}
+
+	engine, clauses := parseTableEngineFull(rows[0].string("engine_full"))
+	table := &Table{
+		Database:    rows[0].string("database"),
+		Name:        rows[0].string("name"),
+		Engine:      engine,
+		Columns:     make([]TableColumn, 0),
+		OrderBy:     clauses["ORDER BY"],
+		PartitionBy: clauses["PARTITION BY"],
+		TTL:         clauses["TTL"],
+		Settings:    parseTableSettings(clauses["SETTINGS"]),
+	}
+
+	query = readTableColumnsStatement(database, name)
+
+	log.Println("[DEBUG] Clickhouse: read table columns query: ", query)
+	r, err = client.ClickHouseQuery.Query(ctx, projectName, serviceName, defaultDatabase, query)
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := queryResultRows(r, "system.columns", "name", "type", "default_kind", "default_expression", "comment")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range columns {
+		// Only DEFAULT is managed, the expression of a MATERIALIZED or ALIAS column isn't a default
+		if kind := c.string("default_kind"); kind != "" && kind != "DEFAULT" {
+			return nil, fmt.Errorf(
+				"column %s of table %s.%s is %s, only the columns with a DEFAULT or without a default expression are supported",
+				c.string("name"), database, name, kind,
+			)
+		}
+
+		table.Columns = append(table.Columns, TableColumn{
+			Name:    c.string("name"),
+			Type:    c.string("type"),
+			Default: c.string("default_expression"),
+			Comment: c.string("comment"),
+		})
+	}
+	return table, nil
+}
+
+func DropTable(ctx context.Context, client *aiven.Client, projectName, serviceName, database, name string) error {
+	query := dropTableStatement(database, name)
+
+	log.Println("[DEBUG] Clickhouse: drop table query: ", query)
+	_, err := client.ClickHouseQuery.Query(ctx, projectName, serviceName, defaultDatabase, query)
+	return err
+}
+
+func tableNameStatement(database, name string) string {
+	return fmt.Sprintf("%s.%s", escape(database), escape(name))
+}
+
+// columnDefinitionStatement the type and the default are SQL, so they can't be escaped
+func columnDefinitionStatement(c TableColumn) string {
+	b := new(strings.Builder)
+	b.WriteString(escape(c.Name))
+	b.WriteString(" " + c.Type)
+
+	if c.Default != "" {
+		b.WriteString(" DEFAULT " + c.Default)
+	}
+	if c.Comment != "" {
+		b.WriteString(" COMMENT " + escapeString(c.Comment))
+	}
+	return b.String()
+}
+
+// tableSettingsStatement the keys are sorted, so the statement is the same for the same settings
+func tableSettingsStatement(settings map[string]string) string {
+	keys := make([]string, 0, len(settings))
+	for k := range settings {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	res := make([]string, len(keys))
+	for i, k := range keys {
+		res[i] = fmt.Sprintf("%s = %s", escape(k), escapeString(settings[k]))
+	}
+	return strings.Join(res, ", ")
+}
+
+func createTableStatement(table Table) string {
+	b := new(strings.Builder)
+	b.WriteString("CREATE TABLE ")
+	b.WriteString(tableNameStatement(table.Database, table.Name))
+
+	columns := make([]string, len(table.Columns))
+	for i, c := range table.Columns {
+		columns[i] = columnDefinitionStatement(c)
+	}
+	b.WriteString(" (" + strings.Join(columns, ", ") + ")")
+	b.WriteString(" ENGINE = " + table.Engine)
+
+	if table.PartitionBy != "" {
+		b.WriteString(" PARTITION BY " + table.PartitionBy)
+	}
+	if table.OrderBy != "" {
+		b.WriteString(" ORDER BY " + table.OrderBy)
+	}
+	if table.TTL != "" {
+		b.WriteString(" TTL " + table.TTL)
+	}
+	if len(table.Settings) > 0 {
+		b.WriteString(" SETTINGS " + tableSettingsStatement(table.Settings))
+	}
+	return b.String()
+}
+
+func alterTableStatement(database, name, command string) string {
+	return fmt.Sprintf("ALTER TABLE %s %s", tableNameStatement(database, name), command)
+}
+
+// alterTableColumnsCommands returns the commands to turn the old columns into the new ones:
+// removed columns are dropped, added columns are placed after the same column as in the config,
+// changed columns are modified. The order of the kept columns must be the same, see tableColumnsReordered.
+func alterTableColumnsCommands(oldColumns, newColumns []TableColumn) []string {
+	oldByName := make(map[string]TableColumn, len(oldColumns))
+	for _, c := range oldColumns {
+		oldByName[c.Name] = c
+	}
+	newByName := make(map[string]TableColumn, len(newColumns))
+	for _, c := range newColumns {
+		newByName[c.Name] = c
+	}
+
+	commands := make([]string, 0)
+	for _, c := range oldColumns {
+		if _, ok := newByName[c.Name]; !ok {
+			commands = append(commands, "DROP COLUMN "+escape(c.Name))
+		}
+	}
+
+	for i, c := range newColumns {
+		o, ok := oldByName[c.Name]
+		if !ok {
+			position := " FIRST"
+			if i > 0 {
+				position = " AFTER " + escape(newColumns[i-1].Name)
+			}
+			commands = append(commands, "ADD COLUMN "+columnDefinitionStatement(c)+position)
+			continue
+		}
+
+		// MODIFY COLUMN keeps the default and the comment that are not set
+		if c.Default == "" && o.Default != "" {
+			commands = append(commands, "MODIFY COLUMN "+escape(c.Name)+" REMOVE DEFAULT")
+		}
+		if o.Type != c.Type || (c.Default != "" && o.Default != c.Default) {
+			commands = append(commands, "MODIFY COLUMN "+columnDefinitionStatement(TableColumn{Name: c.Name, Type: c.Type, Default: c.Default}))
+		}
+		if o.Comment != c.Comment {
+			commands = append(commands, fmt.Sprintf("COMMENT COLUMN %s %s", escape(c.Name), escapeString(c.Comment)))
+		}
+	}
+	return commands
+}
+
+// tableColumnsReordered returns true if the columns that are kept are in a different order
+func tableColumnsReordered(oldColumns, newColumns []TableColumn) bool {
+	keep := func(columns, other []TableColumn) []string {
+		names := make(map[string]bool, len(other))
+		for _, c := range other {
+			names[c.Name] = true
+		}
+
+		res := make([]string, 0, len(columns))
+		for _, c := range columns {
+			if names[c.Name] {
+				res = append(res, c.Name)
+			}
+		}
+		return res
+	}
+
+	return strings.Join(keep(oldColumns, newColumns), "\x00") != strings.Join(keep(newColumns, oldColumns), "\x00")
+}
+
+// alterTableSettingsCommands modifies the changed settings and resets the removed ones
+func alterTableSettingsCommands(oldSettings, newSettings map[string]string) []string {
+	changed := make(map[string]string)
+	for k, v := range newSettings {
+		if o, ok := oldSettings[k]; !ok || o != v {
+			changed[k] = v
+		}
+	}
+
+	removed := make([]string, 0)
+	for k := range oldSettings {
+		if _, ok := newSettings[k]; !ok {
+			removed = append(removed, escape(k))
+		}
+	}
+	sort.Strings(removed)
+
+	commands := make([]string, 0)
+	if len(changed) > 0 {
+		commands = append(commands, "MODIFY SETTING "+tableSettingsStatement(changed))
+	}
+	if len(removed) > 0 {
+		commands = append(commands, "RESET SETTING "+strings.Join(removed, ", "))
+	}
+	return commands
+}
+
+func alterTableTTLCommands(oldTTL, newTTL string) []string {
+	switch {
+	case oldTTL == newTTL:
+		return nil
+	case newTTL == "":
+		return []string{"REMOVE TTL"}
+	}
+	return []string{"MODIFY TTL " + newTTL}
+}
+
+// tableClauses the clauses of engine_full after the engine, in the order ClickHouse writes them
+var tableClauses = []string{"PARTITION BY", "PRIMARY KEY", "ORDER BY", "SAMPLE BY", "TTL", "SETTINGS"}
+
+// parseTableEngineFull returns the engine with its parameters and the clauses of engine_full by keyword,
+// e.g. "MergeTree PARTITION BY toYYYYMM(t) ORDER BY (a, t) SETTINGS index_granularity = 8192".
+// The keywords inside the parentheses and the string literals are skipped
+func parseTableEngineFull(engineFull string) (string, map[string]string) {
+	type clause struct {
+		keyword string
+		start   int
+	}
+
+	found := make([]clause, 0)
+	for _, i := range topLevelIndexes(engineFull) {
+		if i > 0 && engineFull[i-1] != ' ' {
+			continue
+		}
+		for _, k := range tableClauses {
+			if strings.HasPrefix(engineFull[i:], k+" ") {
+				found = append(found, clause{keyword: k, start: i})
+				break
+			}
+		}
+	}
+
+	engine := engineFull
+	if len(found) > 0 {
+		engine = engineFull[:found[0].start]
+	}
+
+	clauses := make(map[string]string, len(found))
+	for j, c := range found {
+		end := len(engineFull)
+		if j+1 < len(found) {
+			end = found[j+1].start
+		}
+		clauses[c.keyword] = strings.TrimSpace(engineFull[c.start+len(c.keyword) : end])
+	}
+	return strings.TrimSpace(engine), clauses
+}
+
+// parseTableSettings parses "k1 = 1, k2 = 'v'", the string values are unquoted
+func parseTableSettings(settings string) map[string]string {
+	res := make(map[string]string)
+	if strings.TrimSpace(settings) == "" {
+		return res
+	}
+
+	start := 0
+	items := make([]string, 0)
+	for _, i := range topLevelIndexes(settings) {
+		if settings[i] == ',' {
+			items = append(items, settings[start:i])
+			start = i + 1
+		}
+	}
+	items = append(items, settings[start:])
+
+	for _, item := range items {
+		k, v, ok := strings.Cut(item, "=")
+		if !ok {
+			continue
+		}
+		res[strings.TrimSpace(k)] = unescapeString(strings.TrimSpace(v))
+	}
+	return res
+}
+
+// topLevelIndexes returns the indexes of the characters outside of the parentheses, the quotes and the backquotes
+func topLevelIndexes(s string) []int {
+	res := make([]int, 0, len(s))
+	depth := 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+			continue
+		case c == '(':
+			depth++
+			continue
+		case c == ')':
+			depth--
+			continue
+		}
+
+		if depth == 0 {
+			res = append(res, i)
+		}
+	}
+	return res
+}
+
+func readTableStatement(database, name string) string {
+	return fmt.Sprintf(
+		"SELECT * FROM system.tables WHERE database = %s AND name = %s",
+		escapeString(database), escapeString(name),
+	)
+}
+
+func readTableColumnsStatement(database, name string) string {
+	return fmt.Sprintf(
+		"SELECT * FROM system.columns WHERE database = %s AND table = %s ORDER BY position",
+		escapeString(database), escapeString(name),
+	)
+}
+
+func dropTableStatement(database, name string) string {
+	return "DROP TABLE IF EXISTS " + tableNameStatement(database, name)
+}
@@ -0,0 +1,143 @@
+package clickhouse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTableStatements(t *testing.T) {
+	table := Table{
+		Database: "analytics",
+		Name:     "events",
+		Engine:   "MergeTree",
+		Columns: []TableColumn{
+			{Name: "timestamp", Type: "DateTime", Default: "now()"},
+			{Name: "tenant_id", Type: "UInt64", Comment: "owner's id"},
+		},
+		OrderBy:     "(tenant_id, timestamp)",
+		PartitionBy: "toYYYYMM(timestamp)",
+		TTL:         "timestamp + INTERVAL 30 DAY",
+		Settings:    map[string]string{"index_granularity": "8192", "enable_mixed_granularity_parts": "1"},
+	}
+
+	assert.Equal(
+		t,
+		"CREATE TABLE `analytics`.`events` "+
+			"(`timestamp` DateTime DEFAULT now(), `tenant_id` UInt64 COMMENT 'owner\\'s id') "+
+			"ENGINE = MergeTree PARTITION BY toYYYYMM(timestamp) ORDER BY (tenant_id, timestamp) "+
+			"TTL timestamp + INTERVAL 30 DAY "+
+			"SETTINGS `enable_mixed_granularity_parts` = '1', `index_granularity` = '8192'",
+		createTableStatement(table),
+	)
+	assert.Equal(t, "ALTER TABLE `analytics`.`events` DROP COLUMN `tenant_id`", alterTableStatement("analytics", "events", "DROP COLUMN `tenant_id`"))
+	assert.Equal(t, "DROP TABLE IF EXISTS `analytics`.`events`", dropTableStatement("analytics", "events"))
+}
+
+func TestAlterTableColumnsCommands(t *testing.T) {
+	oldColumns := []TableColumn{
+		{Name: "timestamp", Type: "DateTime", Default: "now()"},
+		{Name: "tenant_id", Type: "UInt32"},
+		{Name: "payload", Type: "String"},
+	}
+	newColumns := []TableColumn{
+		{Name: "id", Type: "UUID"},
+		{Name: "timestamp", Type: "DateTime"},
+		{Name: "tenant_id", Type: "UInt64", Comment: "owner"},
+		{Name: "level", Type: "LowCardinality(String)", Default: "'info'"},
+	}
+
+	assert.Equal(t, []string{
+		"DROP COLUMN `payload`",
+		"ADD COLUMN `id` UUID FIRST",
+		"MODIFY COLUMN `timestamp` REMOVE DEFAULT",
+		"MODIFY COLUMN `tenant_id` UInt64",
+		"COMMENT COLUMN `tenant_id` 'owner'",
+		"ADD COLUMN `level` LowCardinality(String) DEFAULT 'info' AFTER `tenant_id`",
+	}, alterTableColumnsCommands(oldColumns, newColumns))
+	assert.Empty(t, alterTableColumnsCommands(oldColumns, oldColumns))
+}
+
+func TestTableColumnsReordered(t *testing.T) {
+	columns := func(names ...string) []TableColumn {
+		res := make([]TableColumn, len(names))
+		for i, n := range names {
+			res[i] = TableColumn{Name: n, Type: "String"}
+		}
+		return res
+	}
+
+	assert.False(t, tableColumnsReordered(columns("a", "b", "c"), columns("x", "a", "c", "y")))
+	assert.True(t, tableColumnsReordered(columns("a", "b", "c"), columns("b", "a", "c")))
+}
+
+func TestAlterTableSettingsAndTTLCommands(t *testing.T) {
+	assert.Equal(t, []string{
+		"MODIFY SETTING `merge_with_ttl_timeout` = '3600'",
+		"RESET SETTING `index_granularity`, `min_bytes_for_wide_part`",
+	}, alterTableSettingsCommands(
+		map[string]string{"index_granularity": "8192", "min_bytes_for_wide_part": "0", "merge_with_ttl_timeout": "60"},
+		map[string]string{"merge_with_ttl_timeout": "3600"},
+	))
+
+	assert.Empty(t, alterTableTTLCommands("timestamp + INTERVAL 1 DAY", "timestamp + INTERVAL 1 DAY"))
+	assert.Equal(t, []string{"REMOVE TTL"}, alterTableTTLCommands("timestamp + INTERVAL 1 DAY", ""))
+	assert.Equal(t, []string{"MODIFY TTL timestamp + INTERVAL 7 DAY"}, alterTableTTLCommands("", "timestamp + INTERVAL 7 DAY"))
+}
+
+func TestDiffSuppressTableEngine(t *testing.T) {
+	assert.True(t, diffSuppressTableEngine("", "ReplicatedMergeTree('/clickhouse/tables/{shard}/events', '{replica}')", "MergeTree", nil))
+	assert.True(t, diffSuppressSQLWhitespace("", "Decimal(10, 2)", "Decimal(10,2)", nil))
+	assert.False(t, diffSuppressTableEngine("", "ReplacingMergeTree", "MergeTree", nil))
+	assert.False(t, diffSuppressTableEngine("", "", "MergeTree", nil))
+}
+
+func TestParseTableEngineFull(t *testing.T) {
+	engine, clauses := parseTableEngineFull(
+		"ReplicatedMergeTree('/clickhouse/tables/{uuid}/{shard}', '{replica}') " +
+			"PARTITION BY toYYYYMM(timestamp) ORDER BY (tenant_id, timestamp) " +
+			"TTL timestamp + toIntervalDay(30) " +
+			"SETTINGS index_granularity = 8192, storage_policy = 'tiered\\'s'",
+	)
+	assert.Equal(t, "ReplicatedMergeTree('/clickhouse/tables/{uuid}/{shard}', '{replica}')", engine)
+	assert.Equal(t, map[string]string{
+		"PARTITION BY": "toYYYYMM(timestamp)",
+		"ORDER BY":     "(tenant_id, timestamp)",
+		"TTL":          "timestamp + toIntervalDay(30)",
+		"SETTINGS":     "index_granularity = 8192, storage_policy = 'tiered\\'s'",
+	}, clauses)
+	assert.Equal(t,
+		map[string]string{"index_granularity": "8192", "storage_policy": "tiered's"},
+		parseTableSettings(clauses["SETTINGS"]),
+	)
+
+	// The keywords in the engine parameters are not clauses
+	engine, clauses = parseTableEngineFull("Kafka('kafka:9092', 'ORDER BY ', 'group', 'JSONEachRow') SETTINGS kafka_num_consumers = 2")
+	assert.Equal(t, "Kafka('kafka:9092', 'ORDER BY ', 'group', 'JSONEachRow')", engine)
+	assert.Equal(t, map[string]string{"SETTINGS": "kafka_num_consumers = 2"}, clauses)
+
+	engine, clauses = parseTableEngineFull("Memory")
+	assert.Equal(t, "Memory", engine)
+	assert.Empty(t, clauses)
+	assert.Empty(t, parseTableSettings(clauses["SETTINGS"]))
+}
+
+func TestDiffSuppressTableExpressions(t *testing.T) {
+	assert.True(t, diffSuppressSQLExpression("", "timestamp + toIntervalDay(30)", "timestamp + INTERVAL 30 DAY", nil))
+	assert.True(t, diffSuppressSQLExpression("", "now() + toIntervalHour(1)", "now() + interval 1 hours", nil))
+	assert.False(t, diffSuppressSQLExpression("", "timestamp + toIntervalDay(30)", "timestamp + INTERVAL 7 DAY", nil))
+
+	assert.True(t, diffSuppressTableKey("", "(tenant_id, timestamp)", "(tenant_id,timestamp)", nil))
+	assert.True(t, diffSuppressTableKey("", "tenant_id", "(tenant_id)", nil))
+	assert.False(t, diffSuppressTableKey("", "(a) + (b)", "a) + (b", nil))
+	assert.False(t, diffSuppressTableKey("", "(timestamp, tenant_id)", "(tenant_id, timestamp)", nil))
+}
+
+func TestFlattenTableSettings(t *testing.T) {
+	remote := map[string]string{"index_granularity": "8192", "merge_with_ttl_timeout": "3600"}
+	assert.Equal(t,
+		map[string]string{"merge_with_ttl_timeout": "3600"},
+		flattenTableSettings(remote, map[string]string{"merge_with_ttl_timeout": "60"}),
+	)
+	assert.Equal(t, remote, flattenTableSettings(remote, map[string]string{"index_granularity": "8192"}))
+}
@@ -0,0 +1,142 @@
+package clickhouse
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/aiven/aiven-go-client/v2"
+)
+
+// anyHostIP the host_ip of a user that can connect from any host
+const anyHostIP = "::/0"
+
+// User the settings of a user that are managed with ALTER USER,
+// the user itself and its password are managed with the Aiven API
+type User struct {
+	Name             string
+	AllowedIPs       []string
+	AllowedHostNames []string
+	// DefaultRoles the roles enabled on login, empty means all granted roles
+	DefaultRoles    []string
+	SettingsProfile string
+}
+
+// AlterUser sets the hosts, the default roles and the settings profile of the user
+func AlterUser(ctx context.Context, client *aiven.Client, projectName, serviceName string, user User) error {
+	query := alterUserStatement(user)
+
+	log.Println("[DEBUG] Clickhouse: alter user query: ", query)
+	_, err := client.ClickHouseQuery.Query(ctx, projectName, serviceName, defaultDatabase, query)
+	return err
+}
+
+// ReadUser returns the user from system.users and its settings profile from system.settings_profile_elements.
+// Returns aiven.Error with 404 status if the user doesn't exist.
+func ReadUser(ctx context.Context, client *aiven.Client, projectName, serviceName, name string) (*User, error) {
+	query := readUserStatement(name)
+
+	log.Println("[DEBUG] Clickhouse: read user query: ", query)
+	r, err := client.ClickHouseQuery.Query(ctx, projectName, serviceName, defaultDatabase, query)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := queryResultRows(r, "system.users", "name", "host_ip", "host_names", "default_roles_all", "default_roles_list")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, aiven.Error{Status: http.StatusNotFound, Message: fmt.Sprintf("user %q not found", name)}
+	}
+
+	user := &User{
+		Name:             rows[0].string("name"),
+		AllowedIPs:       make([]string, 0),
+		AllowedHostNames: rows[0].strings("host_names"),
+		DefaultRoles:     make([]string, 0),
+	}
+
+	for _, ip := range rows[0].strings("host_ip") {
+		if ip != anyHostIP {
+			user.AllowedIPs = append(user.AllowedIPs, ip)
+		}
+	}
+
+	if !rows[0].bool("default_roles_all") {
+		user.DefaultRoles = rows[0].strings("default_roles_list")
+	}
+
+	query = readUserSettingsProfileStatement(name)
+
+	log.Println("[DEBUG] Clickhouse: read user settings profile query: ", query)
+	r, err = client.ClickHouseQuery.Query(ctx, projectName, serviceName, defaultDatabase, query)
+	if err != nil {
+		return nil, err
+	}
+
+	elements, err := queryResultRows(r, "system.settings_profile_elements", "inherit_profile")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range elements {
+		if inherit := e.string("inherit_profile"); inherit != "" {
+			user.SettingsProfile = inherit
+			break
+		}
+	}
+	return user, nil
+}
+
+// userHostStatement returns the HOST clause, a user without restrictions can connect from any host
+func userHostStatement(user User) string {
+	hosts := make([]string, 0, len(user.AllowedIPs)+len(user.AllowedHostNames))
+	for _, ip := range user.AllowedIPs {
+		hosts = append(hosts, "IP "+escapeString(ip))
+	}
+	for _, n := range user.AllowedHostNames {
+		hosts = append(hosts, "NAME "+escapeString(n))
+	}
+
+	if len(hosts) == 0 {
+		return "HOST ANY"
+	}
+	return "HOST " + strings.Join(hosts, ", ")
+}
+
+func userDefaultRoleStatement(user User) string {
+	if len(user.DefaultRoles) == 0 {
+		return "DEFAULT ROLE ALL"
+	}
+	return "DEFAULT ROLE " + rolesOrUsersStatement(user.DefaultRoles)
+}
+
+// userSettingsStatement SETTINGS NONE removes the profile that is not in the config anymore
+func userSettingsStatement(user User) string {
+	if user.SettingsProfile == "" {
+		return "SETTINGS NONE"
+	}
+	return "SETTINGS PROFILE " + escape(user.SettingsProfile)
+}
+
+func alterUserStatement(user User) string {
+	return fmt.Sprintf(
+		"ALTER USER %s %s %s %s",
+		escape(user.Name), userHostStatement(user), userDefaultRoleStatement(user), userSettingsStatement(user),
+	)
+}
+
+func readUserStatement(name string) string {
+	return fmt.Sprintf("SELECT * FROM system.users WHERE name = %s", escapeString(name))
+}
+
+func readUserSettingsProfileStatement(name string) string {
+	return fmt.Sprintf(
+		"SELECT * FROM system.settings_profile_elements WHERE user_name = %s ORDER BY index",
+		escapeString(name),
+	)
+}
@@ -0,0 +1,37 @@
+package clickhouse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlterUserStatement(t *testing.T) {
+	assert.Equal(
+		t,
+		"ALTER USER `analyst` HOST ANY DEFAULT ROLE ALL SETTINGS NONE",
+		alterUserStatement(User{Name: "analyst"}),
+	)
+	assert.Equal(
+		t,
+		"ALTER USER `analyst` HOST IP '10.0.0.0/8', IP '192.168.1.1', NAME 'bastion.example.com' "+
+			"DEFAULT ROLE `reader`, `writer` SETTINGS PROFILE `restricted`",
+		alterUserStatement(User{
+			Name:             "analyst",
+			AllowedIPs:       []string{"10.0.0.0/8", "192.168.1.1"},
+			AllowedHostNames: []string{"bastion.example.com"},
+			DefaultRoles:     []string{"reader", "writer"},
+			SettingsProfile:  "restricted",
+		}),
+	)
+}
+
+func TestGenerateUserPassword(t *testing.T) {
+	a, err := generateUserPassword()
+	assert.NoError(t, err)
+	assert.Len(t, a, 32)
+
+	b, err := generateUserPassword()
+	assert.NoError(t, err)
+	assert.NotEqual(t, a, b)
+}
@@ -0,0 +1,104 @@
+package clickhouse
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/aiven/aiven-go-client/v2"
+)
+
+type View struct {
+	Database     string
+	Name         string
+	Query        string
+	Materialized bool
+	// ToTable the table a materialized view writes to, "database.table" or a table of the same database
+	ToTable string
+}
+
+func CreateView(ctx context.Context, client *aiven.Client, projectName, serviceName string, view View) error {
+	query := createViewStatement(view)
+
+	log.Println("[DEBUG] Clickhouse: create view query: ", query)
+	_, err := client.ClickHouseQuery.Query(ctx, projectName, serviceName, defaultDatabase, query)
+	return err
+}
+
+// ReplaceView replaces the query of a view, materialized views are recreated by Terraform instead
+func ReplaceView(ctx context.Context, client *aiven.Client, projectName, serviceName string, view View) error {
+	query := replaceViewStatement(view)
+
+	log.Println("[DEBUG] Clickhouse: replace view query: ", query)
+	_, err := client.ClickHouseQuery.Query(ctx, projectName, serviceName, defaultDatabase, query)
+	return err
+}
+
+// ReadView returns the view from system.tables, the query is formatted by ClickHouse.
+// Returns aiven.Error with 404 status if the view doesn't exist.
+func ReadView(ctx context.Context, client *aiven.Client, projectName, serviceName, database, name string) (*View, error) {
+	query := readTableStatement(database, name)
+
+	log.Println("[DEBUG] Clickhouse: read view query: ", query)
+	r, err := client.ClickHouseQuery.Query(ctx, projectName, serviceName, defaultDatabase, query)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := queryResultRows(r, "system.tables", "database", "name", "engine", "as_select")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 || !isViewEngine(rows[0].string("engine")) {
+		return nil, aiven.Error{Status: http.StatusNotFound, Message: fmt.Sprintf("view %s.%s not found", database, name)}
+	}
+
+	return &View{
+		Database:     rows[0].string("database"),
+		Name:         rows[0].string("name"),
+		Query:        rows[0].string("as_select"),
+		Materialized: rows[0].string("engine") == "MaterializedView",
+	}, nil
+}
+
+func DropView(ctx context.Context, client *aiven.Client, projectName, serviceName, database, name string) error {
+	query := dropViewStatement(database, name)
+
+	log.Println("[DEBUG] Clickhouse: drop view query: ", query)
+	_, err := client.ClickHouseQuery.Query(ctx, projectName, serviceName, defaultDatabase, query)
+	return err
+}
+
+func isViewEngine(engine string) bool {
+	return engine == "View" || engine == "MaterializedView"
+}
+
+// viewTargetStatement returns the escaped "database.table", the database of the view is the default
+func viewTargetStatement(view View) string {
+	if database, table, ok := strings.Cut(view.ToTable, "."); ok {
+		return tableNameStatement(database, table)
+	}
+	return tableNameStatement(view.Database, view.ToTable)
+}
+
+// createViewStatement the query is SQL, so it can't be escaped
+func createViewStatement(view View) string {
+	if view.Materialized {
+		return fmt.Sprintf(
+			"CREATE MATERIALIZED VIEW %s TO %s AS %s",
+			tableNameStatement(view.Database, view.Name), viewTargetStatement(view), view.Query,
+		)
+	}
+	return fmt.Sprintf("CREATE VIEW %s AS %s", tableNameStatement(view.Database, view.Name), view.Query)
+}
+
+func replaceViewStatement(view View) string {
+	return fmt.Sprintf("CREATE OR REPLACE VIEW %s AS %s", tableNameStatement(view.Database, view.Name), view.Query)
+}
+
+func dropViewStatement(database, name string) string {
+	return "DROP VIEW IF EXISTS " + tableNameStatement(database, name)
+}
@@ -0,0 +1,31 @@
+package clickhouse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestViewStatements(t *testing.T) {
+	view := View{Database: "analytics", Name: "recent_events", Query: "SELECT * FROM analytics.events"}
+	assert.Equal(t, "CREATE VIEW `analytics`.`recent_events` AS SELECT * FROM analytics.events", createViewStatement(view))
+	assert.Equal(t, "CREATE OR REPLACE VIEW `analytics`.`recent_events` AS SELECT * FROM analytics.events", replaceViewStatement(view))
+	assert.Equal(t, "DROP VIEW IF EXISTS `analytics`.`recent_events`", dropViewStatement("analytics", "recent_events"))
+
+	materialized := View{
+		Database:     "analytics",
+		Name:         "events_mv",
+		Query:        "SELECT tenant_id, count() AS total FROM analytics.events GROUP BY tenant_id",
+		Materialized: true,
+		ToTable:      "events_per_tenant",
+	}
+	assert.Equal(
+		t,
+		"CREATE MATERIALIZED VIEW `analytics`.`events_mv` TO `analytics`.`events_per_tenant` "+
+			"AS SELECT tenant_id, count() AS total FROM analytics.events GROUP BY tenant_id",
+		createViewStatement(materialized),
+	)
+
+	materialized.ToTable = "reports.events_per_tenant"
+	assert.Equal(t, "`reports`.`events_per_tenant`", viewTargetStatement(materialized))
+}
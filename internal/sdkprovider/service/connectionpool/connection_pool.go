@@ -40,7 +40,7 @@ var aivenConnectionPoolSchema = map[string]*schema.Schema{
 		Type:        schema.TypeInt,
 		Optional:    true,
 		Default:     10,
-		Description: userconfig.Desc("The number of connections the pool may create towards the backend server. This does not affect the number of incoming connections, which is always a much larger number.").DefaultValue(10).Build(),
+		Description: userconfig.Desc("The number of connections the pool may create towards the backend server. This does not affect the number of incoming connections, which is always a much larger number. The sizes of all the pools of the service must not exceed `max_connections` of the service minus the reserved connections.").DefaultValue(10).Build(),
 	},
 	"username": {
 		Type:        schema.TypeString,
@@ -65,7 +65,8 @@ func ResourceConnectionPool() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Timeouts: schemautil.DefaultResourceTimeouts(),
+		Timeouts:      schemautil.DefaultResourceTimeouts(),
+		CustomizeDiff: resourceConnectionPoolCustomizeDiff,
 
 		Schema: aivenConnectionPoolSchema,
 	}
@@ -77,7 +78,12 @@ func resourceConnectionPoolCreate(ctx context.Context, d *schema.ResourceData, m
 	project := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
 	poolName := d.Get("pool_name").(string)
-	_, err := client.ConnectionPools.Create(
+	err := validatePoolReferences(ctx, client, project, serviceName, d.Get("username").(string), d.Get("database_name").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = client.ConnectionPools.Create(
 		ctx,
 		project,
 		serviceName,
@@ -127,6 +133,14 @@ func resourceConnectionPoolUpdate(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
+	// The database can't be changed
+	if d.HasChange("username") {
+		err = validatePoolReferences(ctx, client, project, serviceName, d.Get("username").(string), "")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	_, err = client.ConnectionPools.Update(
 		ctx,
 		project,
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"regexp"
 	"testing"
 
 	"github.com/aiven/aiven-go-client/v2"
@@ -211,3 +212,110 @@ func testAccCheckAivenConnectionPoolResourceDestroy(s *terraform.State) error {
 
 	return nil
 }
+
+func TestAccAivenConnectionPool_validation(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	manifest := func(pool string) string {
+		return fmt.Sprintf(`
+resource "aiven_pg" "bar" {
+  project                 = "%s"
+  cloud_name              = "google-europe-west1"
+  plan                    = "startup-4"
+  service_name            = "test-acc-sr-%s"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+}
+
+resource "aiven_pg_database" "foo" {
+  project       = aiven_pg.bar.project
+  service_name  = aiven_pg.bar.service_name
+  database_name = "test-acc-db-%s"
+}
+
+resource "aiven_connection_pool" "foo" {
+  project       = aiven_pg.bar.project
+  service_name  = aiven_pg.bar.service_name
+  database_name = aiven_pg_database.foo.database_name
+  pool_name     = "test-acc-pool-%s"
+  %s
+}`, os.Getenv("AIVEN_PROJECT_NAME"), rName, rName, rName, pool)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acc.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acc.TestProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAivenConnectionPoolResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: manifest(`pool_size = 25`),
+				Check:  resource.TestCheckResourceAttr("aiven_connection_pool.foo", "pool_size", "25"),
+			},
+			{
+				Config:      manifest(`pool_size = 10000`),
+				ExpectError: regexp.MustCompile(`more than the \d+ connections available`),
+			},
+			{
+				// The user is checked on apply, a user created in the same apply doesn't exist on plan
+				Config:      manifest(`username = "missing-user"`),
+				ExpectError: regexp.MustCompile(`has no user "missing-user"`),
+			},
+		},
+	})
+}
+
+// TestAccAivenConnectionPool_sameApply adds a user, a database and a pool to an existing service in one apply.
+// Their names are known on plan, though they don't exist yet
+func TestAccAivenConnectionPool_sameApply(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	service := fmt.Sprintf(`
+resource "aiven_pg" "bar" {
+  project                 = "%s"
+  cloud_name              = "google-europe-west1"
+  plan                    = "startup-4"
+  service_name            = "test-acc-sr-%s"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+}`, os.Getenv("AIVEN_PROJECT_NAME"), rName)
+
+	pool := fmt.Sprintf(`
+resource "aiven_pg_user" "foo" {
+  project      = aiven_pg.bar.project
+  service_name = aiven_pg.bar.service_name
+  username     = "user-%[1]s"
+}
+
+resource "aiven_pg_database" "foo" {
+  project       = aiven_pg.bar.project
+  service_name  = aiven_pg.bar.service_name
+  database_name = "test-acc-db-%[1]s"
+}
+
+resource "aiven_connection_pool" "foo" {
+  project       = aiven_pg.bar.project
+  service_name  = aiven_pg.bar.service_name
+  database_name = aiven_pg_database.foo.database_name
+  pool_name     = "test-acc-pool-%[1]s"
+  pool_size     = 25
+  username      = aiven_pg_user.foo.username
+}`, rName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acc.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acc.TestProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAivenConnectionPoolResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: service,
+			},
+			{
+				Config: service + pool,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aiven_connection_pool.foo", "database_name", "test-acc-db-"+rName),
+					resource.TestCheckResourceAttr("aiven_connection_pool.foo", "username", "user-"+rName),
+				),
+			},
+		},
+	})
+}
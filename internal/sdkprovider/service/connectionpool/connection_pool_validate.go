@@ -0,0 +1,137 @@
+package connectionpool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// reservedConnections the connections PostgreSQL keeps for the superuser (superuser_reserved_connections),
+// the pools can't use them
+const reservedConnections = 3
+
+// resourceConnectionPoolCustomizeDiff validates the pool size against the service on plan.
+// Only the new pools and the changed sizes are validated, the values that are unknown on plan are skipped.
+// The pools that are created in the same plan don't exist yet, so they are not added up.
+// The user and the database are checked on apply, see validatePoolReferences
+func resourceConnectionPoolCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("project") || !d.NewValueKnown("service_name") || !d.NewValueKnown("pool_size") {
+		return nil
+	}
+
+	if d.Id() != "" && !d.HasChange("pool_size") {
+		return nil
+	}
+
+	client := m.(*aiven.Client)
+	s, err := client.Services.Get(ctx, d.Get("project").(string), d.Get("service_name").(string))
+	if err != nil {
+		// The service is created in the same plan
+		if aiven.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	return validatePoolSize(s, d.Get("pool_name").(string), d.Get("pool_size").(int))
+}
+
+// validatePoolReferences checks that the user and the database of the pool exist, the empty values are skipped.
+// Runs on apply, not on plan: the names of a user and a database created in the same apply are known on plan,
+// though those don't exist until they are created
+func validatePoolReferences(ctx context.Context, client *aiven.Client, project, serviceName, username, database string) error {
+	if username != "" {
+		s, err := client.Services.Get(ctx, project, serviceName)
+		if err != nil {
+			return err
+		}
+		if err := validatePoolUsername(s, username); err != nil {
+			return err
+		}
+	}
+
+	if database != "" {
+		databases, err := client.Databases.List(ctx, project, serviceName)
+		if err != nil {
+			return err
+		}
+		return validatePoolDatabase(databases, serviceName, database)
+	}
+	return nil
+}
+
+// serviceMaxConnections returns max_connections of the service metadata, false if the metadata doesn't have it
+func serviceMaxConnections(s *aiven.Service) (int, bool) {
+	metadata, ok := s.Metadata.(map[string]interface{})
+	if !ok {
+		return 0, false
+	}
+
+	// aiven.Client decodes numbers as json.Number
+	switch v := metadata["max_connections"].(type) {
+	case json.Number:
+		n, err := v.Int64()
+		if err != nil {
+			return 0, false
+		}
+		return int(n), true
+	case float64:
+		return int(v), true
+	case int:
+		return v, true
+	}
+	return 0, false
+}
+
+// validatePoolSize adds up the sizes of the pools of the service, the pool itself counts with the new size
+func validatePoolSize(s *aiven.Service, poolName string, poolSize int) error {
+	maxConnections, ok := serviceMaxConnections(s)
+	if !ok {
+		log.Printf("[WARN] cannot validate the size of connection pool %s: service %s has no max_connections", poolName, s.Name)
+		return nil
+	}
+
+	total := poolSize
+	for _, p := range s.ConnectionPools {
+		if p.PoolName != poolName {
+			total += p.PoolSize
+		}
+	}
+
+	available := maxConnections - reservedConnections
+	if total > available {
+		return fmt.Errorf(
+			"the connection pools of service %s would have %d connections in total, "+
+				"more than the %d connections available: max_connections %d minus %d reserved connections",
+			s.Name, total, available, maxConnections, reservedConnections,
+		)
+	}
+	return nil
+}
+
+// validatePoolUsername a pool without a username uses the user of the incoming connection
+func validatePoolUsername(s *aiven.Service, username string) error {
+	if username == "" {
+		return nil
+	}
+
+	for _, u := range s.Users {
+		if u.Username == username {
+			return nil
+		}
+	}
+	return fmt.Errorf("service %s has no user %q", s.Name, username)
+}
+
+func validatePoolDatabase(databases []*aiven.Database, serviceName, database string) error {
+	for _, db := range databases {
+		if db.DatabaseName == database {
+			return nil
+		}
+	}
+	return fmt.Errorf("service %s has no database %q", serviceName, database)
+}
@@ -0,0 +1,60 @@
+package connectionpool
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePoolSize(t *testing.T) {
+	s := &aiven.Service{
+		Name:     "pg",
+		Metadata: map[string]interface{}{"max_connections": json.Number("100")},
+		ConnectionPools: []*aiven.ConnectionPool{
+			{PoolName: "a", PoolSize: 40},
+			{PoolName: "b", PoolSize: 30},
+		},
+	}
+
+	// A new pool is added to the existing ones
+	assert.NoError(t, validatePoolSize(s, "c", 27))
+	assert.EqualError(
+		t,
+		validatePoolSize(s, "c", 28),
+		"the connection pools of service pg would have 98 connections in total, "+
+			"more than the 97 connections available: max_connections 100 minus 3 reserved connections",
+	)
+
+	// The changed pool counts with its new size only
+	assert.NoError(t, validatePoolSize(s, "b", 57))
+	assert.Error(t, validatePoolSize(s, "b", 58))
+
+	// Can't be validated without max_connections
+	assert.NoError(t, validatePoolSize(&aiven.Service{Name: "pg"}, "c", 1000))
+}
+
+func TestServiceMaxConnections(t *testing.T) {
+	for _, v := range []interface{}{json.Number("100"), float64(100), 100} {
+		n, ok := serviceMaxConnections(&aiven.Service{Metadata: map[string]interface{}{"max_connections": v}})
+		assert.True(t, ok)
+		assert.Equal(t, 100, n)
+	}
+
+	_, ok := serviceMaxConnections(&aiven.Service{Metadata: map[string]interface{}{"max_connections": json.Number("many")}})
+	assert.False(t, ok)
+	_, ok = serviceMaxConnections(&aiven.Service{})
+	assert.False(t, ok)
+}
+
+func TestValidatePoolUsernameAndDatabase(t *testing.T) {
+	s := &aiven.Service{Name: "pg", Users: []*aiven.ServiceUser{{Username: "avnadmin"}, {Username: "app"}}}
+	assert.NoError(t, validatePoolUsername(s, "app"))
+	assert.NoError(t, validatePoolUsername(s, ""))
+	assert.EqualError(t, validatePoolUsername(s, "missing"), `service pg has no user "missing"`)
+
+	databases := []*aiven.Database{{DatabaseName: "defaultdb"}, {DatabaseName: "app"}}
+	assert.NoError(t, validatePoolDatabase(databases, "pg", "app"))
+	assert.EqualError(t, validatePoolDatabase(databases, "pg", "missing"), `service pg has no database "missing"`)
+}
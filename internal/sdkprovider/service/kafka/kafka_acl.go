@@ -2,6 +2,8 @@ package kafka
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/aiven/aiven-go-client/v2"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -11,6 +13,7 @@ import (
 	"github.com/aiven/terraform-provider-aiven/internal/common"
 	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
 	"github.com/aiven/terraform-provider-aiven/internal/schemautil/userconfig"
+	"github.com/aiven/terraform-provider-aiven/internal/sdkprovider/kafkaaclrepository"
 )
 
 var aivenKafkaACLSchema = map[string]*schema.Schema{
@@ -36,6 +39,13 @@ var aivenKafkaACLSchema = map[string]*schema.Schema{
 		ValidateFunc: schemautil.GetACLUserValidateFunc(),
 		Description:  userconfig.Desc("Username pattern for the ACL entry.").ForceNew().Build(),
 	},
+	"adopt_existing": {
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+		Description: userconfig.Desc("Take over an existing ACL with the same `permission`, `topic` and `username` " +
+			"instead of failing on create.").DefaultValue(false).Build(),
+	},
 
 	// computed
 	"acl_id": {
@@ -50,9 +60,10 @@ func ResourceKafkaACL() *schema.Resource {
 		Description:   "The Resource Kafka ACL resource allows the creation and management of ACLs for an Aiven Kafka service.",
 		CreateContext: resourceKafkaACLCreate,
 		ReadContext:   resourceKafkaACLRead,
+		UpdateContext: resourceKafkaACLUpdate,
 		DeleteContext: resourceKafkaACLDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceKafkaACLImport,
 		},
 		Timeouts: schemautil.DefaultResourceTimeouts(),
 
@@ -65,15 +76,32 @@ func resourceKafkaACLCreate(ctx context.Context, d *schema.ResourceData, m inter
 
 	project := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
+	permission := d.Get("permission").(string)
+	topic := d.Get("topic").(string)
+	username := d.Get("username").(string)
+	rep := kafkaaclrepository.New(client)
+
+	// Takes over the existing ACL, if there is one
+	if d.Get("adopt_existing").(bool) {
+		acl, err := rep.FindACL(ctx, project, serviceName, permission, topic, username)
+		if err == nil {
+			d.SetId(schemautil.BuildResourceID(project, serviceName, acl.ID))
+			return resourceKafkaACLRead(ctx, d, m)
+		}
+
+		if !aiven.IsNotFound(err) {
+			return diag.FromErr(err)
+		}
+	}
 
-	acl, err := client.KafkaACLs.Create(
+	acl, err := rep.CreateACL(
 		ctx,
 		project,
 		serviceName,
 		aiven.CreateKafkaACLRequest{
-			Permission: d.Get("permission").(string),
-			Topic:      d.Get("topic").(string),
-			Username:   d.Get("username").(string),
+			Permission: permission,
+			Topic:      topic,
+			Username:   username,
 		},
 	)
 	if err != nil {
@@ -93,12 +121,12 @@ func resourceKafkaACLRead(ctx context.Context, d *schema.ResourceData, m interfa
 		return diag.FromErr(err)
 	}
 
-	acl, err := kafkaACLCache{}.Read(ctx, project, serviceName, aclID, client)
+	acl, err := kafkaaclrepository.New(client).ReadACL(ctx, project, serviceName, aclID)
 	if err != nil {
 		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
 	}
 
-	err = copyKafkaACLPropertiesFromAPIResponseToTerraform(d, &acl, project, serviceName)
+	err = copyKafkaACLPropertiesFromAPIResponseToTerraform(d, acl, project, serviceName)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -106,6 +134,11 @@ func resourceKafkaACLRead(ctx context.Context, d *schema.ResourceData, m interfa
 	return nil
 }
 
+// resourceKafkaACLUpdate only adopt_existing can be changed, which is used on create
+func resourceKafkaACLUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourceKafkaACLRead(ctx, d, m)
+}
+
 func resourceKafkaACLDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*aiven.Client)
 
@@ -114,7 +147,7 @@ func resourceKafkaACLDelete(ctx context.Context, d *schema.ResourceData, m inter
 		return diag.FromErr(err)
 	}
 
-	err = client.KafkaACLs.Delete(ctx, projectName, serviceName, aclID)
+	err = kafkaaclrepository.New(client).DeleteACL(ctx, projectName, serviceName, aclID)
 	if common.IsCritical(err) {
 		return diag.FromErr(err)
 	}
@@ -122,6 +155,33 @@ func resourceKafkaACLDelete(ctx context.Context, d *schema.ResourceData, m inter
 	return nil
 }
 
+// resourceKafkaACLImport imports by ACL ID "project/service_name/id"
+// or by ACL fields "project/service_name/username/topic/permission"
+func resourceKafkaACLImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Import doesn't set defaults
+	if err := d.Set("adopt_existing", false); err != nil {
+		return nil, err
+	}
+
+	if strings.Count(d.Id(), "/") != 4 {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	parts, err := schemautil.SplitResourceID(d.Id(), 5)
+	if err != nil {
+		return nil, err
+	}
+
+	project, serviceName, username, topic, permission := parts[0], parts[1], parts[2], parts[3], parts[4]
+	acl, err := kafkaaclrepository.New(m.(*aiven.Client)).FindACL(ctx, project, serviceName, permission, topic, username)
+	if err != nil {
+		return nil, fmt.Errorf("cannot find Kafka ACL %s: %w", d.Id(), err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(project, serviceName, acl.ID))
+	return []*schema.ResourceData{d}, nil
+}
+
 func copyKafkaACLPropertiesFromAPIResponseToTerraform(
 	d *schema.ResourceData,
 	acl *aiven.KafkaACL,
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+	"github.com/aiven/terraform-provider-aiven/internal/sdkprovider/kafkaaclrepository"
 )
 
 func DatasourceKafkaACL() *schema.Resource {
@@ -15,11 +16,19 @@ func DatasourceKafkaACL() *schema.Resource {
 		ReadContext: datasourceKafkaACLRead,
 		Description: "The Data Source Kafka ACL data source provides information about the existing Aiven Kafka ACL for a Kafka service.",
 
-		Schema: schemautil.ResourceSchemaAsDatasourceSchema(aivenKafkaACLSchema,
-			"project", "service_name", "topic", "username", "permission"),
+		Schema: datasourceKafkaACLSchema(),
 	}
 }
 
+func datasourceKafkaACLSchema() map[string]*schema.Schema {
+	s := schemautil.ResourceSchemaAsDatasourceSchema(aivenKafkaACLSchema,
+		"project", "service_name", "topic", "username", "permission")
+
+	// Used on create only
+	delete(s, "adopt_existing")
+	return s
+}
+
 func datasourceKafkaACLRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*aiven.Client)
 
@@ -29,17 +38,14 @@ func datasourceKafkaACLRead(ctx context.Context, d *schema.ResourceData, m inter
 	userName := d.Get("username").(string)
 	permission := d.Get("permission").(string)
 
-	acls, err := client.KafkaACLs.List(ctx, projectName, serviceName)
+	acl, err := kafkaaclrepository.New(client).FindACL(ctx, projectName, serviceName, permission, topic, userName)
+	if aiven.IsNotFound(err) {
+		return diag.Errorf("KafkaACL %s/%s not found", topic, userName)
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	for _, acl := range acls {
-		if acl.Topic == topic && acl.Username == userName && acl.Permission == permission {
-			d.SetId(schemautil.BuildResourceID(projectName, serviceName, acl.ID))
-			return resourceKafkaACLRead(ctx, d, m)
-		}
-	}
-
-	return diag.Errorf("KafkaACL %s/%s not found", topic, userName)
+	d.SetId(schemautil.BuildResourceID(projectName, serviceName, acl.ID))
+	return resourceKafkaACLRead(ctx, d, m)
 }
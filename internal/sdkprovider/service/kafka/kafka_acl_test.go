@@ -74,10 +74,39 @@ func TestAccAivenKafkaACL_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet(resourceName, "acl_id"),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					a := s.RootModule().Resources[resourceName].Primary.Attributes
+					return schemautil.BuildResourceID(a["project"], a["service_name"], a["username"], a["topic"], a["permission"]), nil
+				},
+			},
+			{
+				Config: testAccKafkaACLResource(rName) + testAccKafkaACLAdoptExistingResource(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aiven_kafka_acl.adopted", "adopt_existing", "true"),
+					resource.TestCheckResourceAttrPair("aiven_kafka_acl.adopted", "acl_id", resourceName, "acl_id"),
+				),
+			},
 		},
 	})
 }
 
+func testAccKafkaACLAdoptExistingResource() string {
+	return `
+
+resource "aiven_kafka_acl" "adopted" {
+  project        = aiven_kafka_acl.foo.project
+  service_name   = aiven_kafka_acl.foo.service_name
+  topic          = aiven_kafka_acl.foo.topic
+  username       = aiven_kafka_acl.foo.username
+  permission     = aiven_kafka_acl.foo.permission
+  adopt_existing = true
+}`
+}
+
 func testAccCheckAivenKafkaACLAttributes(n string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		r := s.RootModule().Resources[n]
@@ -0,0 +1,232 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"golang.org/x/exp/slices"
+
+	"github.com/aiven/terraform-provider-aiven/internal/common"
+)
+
+var (
+	connectPluginConfigs         = make(map[string][]kafkaConnectPluginConfigKey)
+	connectPluginConfigCacheLock sync.Mutex
+
+	// kafkaConnectConfigProviderReference matches any config provider reference, e.g. ${file:/path:key}.
+	// Such values are resolved by the workers, so their types can't be validated.
+	kafkaConnectConfigProviderReference = regexp.MustCompile(`^\$\{[^}]+}$`)
+)
+
+// kafkaConnectCommonConfigKeys and kafkaConnectCommonConfigPrefixes the keys that might be not in the plugin
+// config definition, but are handled by Kafka Connect itself
+var kafkaConnectCommonConfigKeys = map[string]bool{
+	"name":                 true,
+	"connector.class":      true,
+	"tasks.max":            true,
+	"topics":               true,
+	"topics.regex":         true,
+	"key.converter":        true,
+	"value.converter":      true,
+	"header.converter":     true,
+	"config.action.reload": true,
+	"transforms":           true,
+	"predicates":           true,
+}
+
+var kafkaConnectCommonConfigPrefixes = []string{
+	"transforms.",
+	"predicates.",
+	"key.converter.",
+	"value.converter.",
+	"header.converter.",
+	"consumer.override.",
+	"producer.override.",
+	"admin.override.",
+	"errors.",
+}
+
+// kafkaConnectPlugin Kafka Connect plugin available in the service.
+// aiven.Client doesn't support these yet, see common.DoRequest
+type kafkaConnectPlugin struct {
+	Author           string `json:"author"`
+	Class            string `json:"class"`
+	DocumentationURL string `json:"docURL"`
+	Title            string `json:"title"`
+	Type             string `json:"type"`
+	Version          string `json:"version"`
+}
+
+type kafkaConnectPluginListResponse struct {
+	Plugins []*kafkaConnectPlugin `json:"plugins"`
+}
+
+// kafkaConnectPluginConfigKey a config key of a plugin, types are Kafka Connect ConfigDef types.
+// RecommendedValues are advisory, the plugins might accept other values, e.g. class names
+type kafkaConnectPluginConfigKey struct {
+	Name              string   `json:"name"`
+	Type              string   `json:"type"`
+	Required          bool     `json:"required"`
+	DefaultValue      string   `json:"default_value"`
+	RecommendedValues []string `json:"recommended_values"`
+}
+
+type kafkaConnectPluginConfigResponse struct {
+	ConfigurationSchema []kafkaConnectPluginConfigKey `json:"configuration_schema"`
+}
+
+func listKafkaConnectPlugins(ctx context.Context, client *aiven.Client, project, serviceName string) ([]*kafkaConnectPlugin, error) {
+	path := fmt.Sprintf("/project/%s/service/%s/available-connectors", url.PathEscape(project), url.PathEscape(serviceName))
+	rsp := new(kafkaConnectPluginListResponse)
+	err := common.DoRequest(ctx, client, http.MethodGet, path, nil, rsp)
+	if err != nil {
+		return nil, err
+	}
+	return rsp.Plugins, nil
+}
+
+// getKafkaConnectPluginConfig returns the config definition of the plugin, cached per service.
+// Plugins are only changed with the service version, so the cache never expires
+func getKafkaConnectPluginConfig(
+	ctx context.Context,
+	client *aiven.Client,
+	project, serviceName, class string,
+) ([]kafkaConnectPluginConfigKey, error) {
+	connectPluginConfigCacheLock.Lock()
+	defer connectPluginConfigCacheLock.Unlock()
+
+	key := strings.Join([]string{project, serviceName, class}, "/")
+	if keys, ok := connectPluginConfigs[key]; ok {
+		return keys, nil
+	}
+
+	path := fmt.Sprintf(
+		"/project/%s/service/%s/connector-plugins/%s/configuration",
+		url.PathEscape(project), url.PathEscape(serviceName), url.PathEscape(class),
+	)
+	rsp := new(kafkaConnectPluginConfigResponse)
+	err := common.DoRequest(ctx, client, http.MethodGet, path, nil, rsp)
+	if err != nil {
+		return nil, err
+	}
+
+	connectPluginConfigs[key] = rsp.ConfigurationSchema
+	return rsp.ConfigurationSchema, nil
+}
+
+// validateKafkaConnectorConfig validates the config against the plugin config definition.
+// Returns an error for missing required keys and for values of a wrong type.
+// Returns the keys that are not in the definition and the values out of the recommended values to warn about,
+// the plugins might accept those.
+func validateKafkaConnectorConfig(
+	definition []kafkaConnectPluginConfigKey,
+	config map[string]string,
+) (unknown, notRecommended []string, err error) {
+	known := make(map[string]bool, len(definition))
+	errs := make([]string, 0)
+	notRecommended = make([]string, 0)
+	for _, k := range definition {
+		known[k.Name] = true
+
+		v, ok := config[k.Name]
+		if !ok {
+			if k.Required && k.DefaultValue == "" {
+				errs = append(errs, fmt.Sprintf("%q is required", k.Name))
+			}
+			continue
+		}
+
+		if err := validateKafkaConnectConfigValue(k.Type, v); err != nil {
+			errs = append(errs, fmt.Sprintf("%q %s", k.Name, err))
+			continue
+		}
+
+		if err := checkKafkaConnectConfigRecommended(k.Type, k.RecommendedValues, v); err != nil {
+			notRecommended = append(notRecommended, fmt.Sprintf("%q %s", k.Name, err))
+		}
+	}
+
+	unknown = make([]string, 0)
+	for k := range config {
+		if !known[k] && !isKafkaConnectCommonConfigKey(k) {
+			unknown = append(unknown, k)
+		}
+	}
+
+	sort.Strings(errs)
+	sort.Strings(unknown)
+	sort.Strings(notRecommended)
+	if len(errs) > 0 {
+		return unknown, notRecommended, fmt.Errorf("invalid connector config: %s", strings.Join(errs, ", "))
+	}
+	return unknown, notRecommended, nil
+}
+
+// validateKafkaConnectConfigValue validates the value with the Kafka Connect ConfigDef type
+func validateKafkaConnectConfigValue(kind, v string) error {
+	if kafkaConnectConfigProviderReference.MatchString(v) {
+		return nil
+	}
+
+	var err error
+	switch strings.ToUpper(kind) {
+	case "BOOLEAN":
+		if s := strings.ToLower(v); s != "true" && s != "false" {
+			return fmt.Errorf("expected to be one of [true false], got %q", v)
+		}
+	case "SHORT":
+		_, err = strconv.ParseInt(v, 10, 16)
+	case "INT":
+		_, err = strconv.ParseInt(v, 10, 32)
+	case "LONG":
+		_, err = strconv.ParseInt(v, 10, 64)
+	case "DOUBLE":
+		_, err = strconv.ParseFloat(v, 64)
+	}
+
+	if err != nil {
+		return fmt.Errorf("expected to be of type %s, got %q", strings.ToUpper(kind), v)
+	}
+	return nil
+}
+
+// checkKafkaConnectConfigRecommended checks the value with the recommended values of the key, each item of a LIST.
+// Kafka Connect validators of enumerated values are mostly case-insensitive, so is the comparison
+func checkKafkaConnectConfigRecommended(kind string, recommended []string, v string) error {
+	if len(recommended) == 0 || kafkaConnectConfigProviderReference.MatchString(v) {
+		return nil
+	}
+
+	values := []string{v}
+	if strings.ToUpper(kind) == "LIST" {
+		values = strings.Split(v, ",")
+	}
+
+	for _, item := range values {
+		item = strings.TrimSpace(item)
+		if !slices.ContainsFunc(recommended, func(a string) bool { return strings.EqualFold(a, item) }) {
+			return fmt.Errorf("is not one of the recommended values [%s], got %q", strings.Join(recommended, " "), item)
+		}
+	}
+	return nil
+}
+
+func isKafkaConnectCommonConfigKey(k string) bool {
+	if kafkaConnectCommonConfigKeys[k] {
+		return true
+	}
+	for _, p := range kafkaConnectCommonConfigPrefixes {
+		if strings.HasPrefix(k, p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+)
+
+func DatasourceKafkaConnectPlugins() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceKafkaConnectPluginsRead,
+		Description: "The Kafka Connect plugins data source lists the connector plugins available in an Aiven Kafka or Kafka Connect service.",
+		Schema: map[string]*schema.Schema{
+			"project":      schemautil.CommonSchemaProjectReference,
+			"service_name": schemautil.CommonSchemaServiceNameReference,
+			"plugins": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of available plugins.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"author": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The plugin author.",
+						},
+						"class": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The plugin Java class, use it as `connector.class` in the connector config.",
+						},
+						"doc_url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The plugin documentation URL.",
+						},
+						"title": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The plugin title.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The plugin type, `sink` or `source`.",
+						},
+						"version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The plugin version.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func datasourceKafkaConnectPluginsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+
+	list, err := listKafkaConnectPlugins(ctx, m.(*aiven.Client), projectName, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	plugins := make([]map[string]interface{}, len(list))
+	for i, p := range list {
+		plugins[i] = map[string]interface{}{
+			"author":  p.Author,
+			"class":   p.Class,
+			"doc_url": p.DocumentationURL,
+			"title":   p.Title,
+			"type":    p.Type,
+			"version": p.Version,
+		}
+	}
+
+	d.SetId(schemautil.BuildResourceID(projectName, serviceName))
+	if err := d.Set("plugins", plugins); err != nil {
+		return diag.Errorf("error setting Kafka Connect `plugins` for resource %s: %s", d.Id(), err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package kafka_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	acc "github.com/aiven/terraform-provider-aiven/internal/acctest"
+)
+
+func TestAccAivenKafkaConnectPluginsDataSource_basic(t *testing.T) {
+	datasourceName := "data.aiven_kafka_connect_plugins.plugins"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acc.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acc.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKafkaConnectPluginsDataSource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceName, "project", os.Getenv("AIVEN_PROJECT_NAME")),
+					resource.TestCheckResourceAttr(datasourceName, "service_name", fmt.Sprintf("test-acc-sr-%s", rName)),
+					resource.TestCheckResourceAttrSet(datasourceName, "plugins.0.class"),
+					resource.TestCheckResourceAttrSet(datasourceName, "plugins.0.version"),
+					resource.TestCheckTypeSetElemNestedAttrs(datasourceName, "plugins.*", map[string]string{
+						"class": "io.aiven.kafka.connect.opensearch.OpensearchSinkConnector",
+						"type":  "sink",
+					}),
+				),
+			},
+		},
+	})
+}
+
+// nosemgrep: kafka connectors need kafka with business plans
+func testAccKafkaConnectPluginsDataSource(name string) string {
+	return fmt.Sprintf(`
+data "aiven_project" "foo" {
+  project = "%s"
+}
+
+resource "aiven_kafka" "bar" {
+  project                 = data.aiven_project.foo.project
+  cloud_name              = "google-europe-west1"
+  plan                    = "business-4"
+  service_name            = "test-acc-sr-%s"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+
+  kafka_user_config {
+    kafka_connect = true
+  }
+}
+
+data "aiven_kafka_connect_plugins" "plugins" {
+  project      = aiven_kafka.bar.project
+  service_name = aiven_kafka.bar.service_name
+}`, os.Getenv("AIVEN_PROJECT_NAME"), name)
+}
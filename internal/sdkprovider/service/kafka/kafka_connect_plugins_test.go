@@ -0,0 +1,111 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateKafkaConnectorConfig(t *testing.T) {
+	definition := []kafkaConnectPluginConfigKey{
+		{Name: "connection.url", Type: "STRING", Required: true},
+		{Name: "batch.size", Type: "INT", DefaultValue: "2000"},
+		{Name: "flush.timeout.ms", Type: "LONG", Required: true, DefaultValue: "10000"},
+		{Name: "key.ignore", Type: "BOOLEAN", DefaultValue: "false"},
+		{Name: "retry.backoff", Type: "DOUBLE"},
+		{Name: "behavior.on.null.values", Type: "STRING", RecommendedValues: []string{"ignore", "delete", "fail"}},
+		{Name: "data.stream.types", Type: "LIST", RecommendedValues: []string{"logs", "metrics"}},
+	}
+
+	cases := []struct {
+		name                   string
+		config                 map[string]string
+		expectedUnknown        []string
+		expectedNotRecommended []string
+		expectedErr            string
+	}{
+		{
+			name: "valid",
+			config: map[string]string{
+				"name":                       "foo",
+				"connector.class":            "io.aiven.kafka.connect.opensearch.OpensearchSinkConnector",
+				"connection.url":             "https://example.com",
+				"batch.size":                 "100",
+				"key.ignore":                 "TRUE",
+				"retry.backoff":              "1.5",
+				"transforms.route.type":      "org.apache.kafka.connect.transforms.RegexRouter",
+				"consumer.override.max.poll": "10",
+				"behavior.on.null.values":    "DELETE",
+				"data.stream.types":          "logs, metrics",
+			},
+			expectedUnknown: []string{},
+		},
+		{
+			name:            "required key is missing",
+			config:          map[string]string{"name": "foo"},
+			expectedUnknown: []string{},
+			expectedErr:     `invalid connector config: "connection.url" is required`,
+		},
+		{
+			name: "wrong types",
+			config: map[string]string{
+				"connection.url":   "https://example.com",
+				"batch.size":       "many",
+				"flush.timeout.ms": "1.5",
+				"key.ignore":       "yes",
+			},
+			expectedUnknown: []string{},
+			expectedErr: `invalid connector config: "batch.size" expected to be of type INT, got "many", ` +
+				`"flush.timeout.ms" expected to be of type LONG, got "1.5", ` +
+				`"key.ignore" expected to be one of [true false], got "yes"`,
+		},
+		{
+			name: "values are not recommended",
+			config: map[string]string{
+				"connection.url":          "https://example.com",
+				"behavior.on.null.values": "drop",
+				"data.stream.types":       "logs,traces",
+			},
+			expectedUnknown: []string{},
+			expectedNotRecommended: []string{
+				`"behavior.on.null.values" is not one of the recommended values [ignore delete fail], got "drop"`,
+				`"data.stream.types" is not one of the recommended values [logs metrics], got "traces"`,
+			},
+		},
+		{
+			name: "config provider references are not validated",
+			config: map[string]string{
+				"connection.url":          "${aiven:vault:secret/os:url}",
+				"batch.size":              "${file:/opt/connect.properties:batch}",
+				"behavior.on.null.values": "${file:/opt/connect.properties:behavior}",
+			},
+			expectedUnknown: []string{},
+		},
+		{
+			name: "unknown keys",
+			config: map[string]string{
+				"connection.url": "https://example.com",
+				"batch.sise":     "100",
+				"key.ingore":     "true",
+			},
+			expectedUnknown: []string{"batch.sise", "key.ingore"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			unknown, notRecommended, err := validateKafkaConnectorConfig(definition, c.config)
+			assert.Equal(t, c.expectedUnknown, unknown)
+			if c.expectedNotRecommended == nil {
+				assert.Empty(t, notRecommended)
+			} else {
+				assert.Equal(t, c.expectedNotRecommended, notRecommended)
+			}
+			if c.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, c.expectedErr)
+			}
+		})
+	}
+}
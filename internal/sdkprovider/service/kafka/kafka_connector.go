@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/aiven/aiven-go-client/v2"
@@ -33,7 +34,20 @@ var aivenKafkaConnectorSchema = map[string]*schema.Schema{
 		Elem: &schema.Schema{
 			Type: schema.TypeString,
 		},
-		Description: "The Kafka Connector configuration parameters.",
+		Description: "The Kafka Connector configuration parameters. " +
+			"Secret provider references, e.g. `${aiven:vault:secret/db:password}`, are not compared with the resolved values. " +
+			"Validated against the plugin config definition on plan: required keys and value types. " +
+			"The keys unknown to the plugin and the values out of the recommended values are warned about on apply.",
+	},
+	"config_sensitive": {
+		Type:      schema.TypeMap,
+		Optional:  true,
+		Sensitive: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+		Description: "The Kafka Connector configuration parameters that contain secrets, e.g. passwords. " +
+			"Merged with `config` in the request, a key can't be set in both.",
 	},
 	"plugin_author": {
 		Type:        schema.TypeString,
@@ -65,6 +79,11 @@ var aivenKafkaConnectorSchema = map[string]*schema.Schema{
 		Computed:    true,
 		Description: "The version of the kafka connector.",
 	},
+	"state": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The state of the connector, e.g. `RUNNING`, `PAUSED` or `FAILED`.",
+	},
 	"task": {
 		Type:        schema.TypeSet,
 		Description: "List of tasks of a connector.",
@@ -81,9 +100,38 @@ var aivenKafkaConnectorSchema = map[string]*schema.Schema{
 					Description: "The task id of the task.",
 					Computed:    true,
 				},
+				"state": {
+					Type:        schema.TypeString,
+					Description: "The state of the task, e.g. `RUNNING` or `FAILED`.",
+					Computed:    true,
+				},
+				"trace": {
+					Type:        schema.TypeString,
+					Description: "The stack trace of the task if it has failed.",
+					Computed:    true,
+				},
 			},
 		},
 	},
+	"wait_for_running": {
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+		Description: userconfig.Desc("Wait for the connector and all its tasks to be `RUNNING` on create and update. " +
+			"Fails with the task traces if any of them fails.").DefaultValue(false).Build(),
+	},
+	"paused": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: userconfig.Desc("Pauses the connector, or resumes it when set back to `false`.").DefaultValue(false).Build(),
+	},
+	"restart_trigger": {
+		Type:     schema.TypeString,
+		Optional: true,
+		Description: "Any value, e.g. a timestamp. " +
+			"Changing it restarts the connector and its failed tasks.",
+	},
 }
 
 func ResourceKafkaConnector() *schema.Resource {
@@ -94,18 +142,91 @@ func ResourceKafkaConnector() *schema.Resource {
 		UpdateContext: resourceKafkaTConnectorUpdate,
 		DeleteContext: resourceKafkaConnectorDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceKafkaConnectorImport,
 		},
 		Timeouts: schemautil.DefaultResourceTimeouts(),
 
 		Schema: aivenKafkaConnectorSchema,
-		CustomizeDiff: customdiff.IfValueChange("config",
-			kafkaConnectorConfigNameShouldNotBeEmpty(),
-			customizeDiffKafkaConnectorConfigName(),
+		CustomizeDiff: customdiff.All(
+			customdiff.IfValueChange("config",
+				kafkaConnectorConfigNameShouldNotBeEmpty(),
+				customizeDiffKafkaConnectorConfigName(),
+			),
+			customizeDiffKafkaConnectorConfigSensitive,
+			customdiff.If(kafkaConnectorConfigChanged, customizeDiffKafkaConnectorConfigPlugin),
 		),
 	}
 }
 
+// customizeDiffKafkaConnectorConfigSensitive `config` and `config_sensitive` must not share keys
+func customizeDiffKafkaConnectorConfigSensitive(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	return kafkaConnectorConfigOverlap(
+		diff.Get("config").(map[string]interface{}),
+		diff.Get("config_sensitive").(map[string]interface{}),
+	)
+}
+
+func kafkaConnectorConfigChanged(_ context.Context, diff *schema.ResourceDiff, _ interface{}) bool {
+	return diff.HasChanges("config", "config_sensitive")
+}
+
+// customizeDiffKafkaConnectorConfigPlugin validates `config` against the plugin config definition.
+// Skipped if the values are not known yet, or the definition can't be fetched, e.g. the service doesn't exist yet.
+func customizeDiffKafkaConnectorConfigPlugin(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	for _, k := range []string{"project", "service_name", "config", "config_sensitive"} {
+		if !diff.NewValueKnown(k) {
+			return nil
+		}
+	}
+
+	_, _, err := kafkaConnectorValidateConfig(ctx, diff, m)
+	return err
+}
+
+// kafkaConnectorValidateConfig returns the validation error, the unknown keys and the values out of the recommended values
+func kafkaConnectorValidateConfig(ctx context.Context, d schemautil.ResourceStateOrResourceDiff, m interface{}) ([]string, []string, error) {
+	config := expandKafkaConnectorConfig(
+		d.Get("config").(map[string]interface{}),
+		d.Get("config_sensitive").(map[string]interface{}),
+	)
+	class := config["connector.class"]
+	if class == "" {
+		return nil, nil, nil
+	}
+
+	project := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	definition, err := getKafkaConnectPluginConfig(ctx, m.(*aiven.Client), project, serviceName, class)
+	if err != nil {
+		// The API validates the config on apply anyway
+		log.Printf("[WARN] cannot get Kafka Connect plugin %s config definition: %s", class, err)
+		return nil, nil, nil
+	}
+	return validateKafkaConnectorConfig(definition, config)
+}
+
+// kafkaConnectorConfigWarnings warns about the keys the plugin doesn't know, those are most likely typos,
+// and about the values out of the recommended values. CustomizeDiff can't return warnings, so those are shown on apply
+func kafkaConnectorConfigWarnings(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	unknown, notRecommended, _ := kafkaConnectorValidateConfig(ctx, d, m)
+	var diags diag.Diagnostics
+	if len(unknown) > 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Kafka Connector config has keys that are unknown to the plugin",
+			Detail:   fmt.Sprintf("Check the keys for typos: %s", strings.Join(unknown, ", ")),
+		})
+	}
+	if len(notRecommended) > 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Kafka Connector config has values that are not recommended by the plugin",
+			Detail:   strings.Join(notRecommended, ", "),
+		})
+	}
+	return diags
+}
+
 // customizeDiffKafkaConnectorConfigName `config.name` should be equal to `connector_name`
 func customizeDiffKafkaConnectorConfigName() func(ctx context.Context, diff *schema.ResourceDiff, i interface{}) error {
 	return func(ctx context.Context, diff *schema.ResourceDiff, i interface{}) error {
@@ -134,13 +255,20 @@ func kafkaConnectorConfigNameShouldNotBeEmpty() func(ctx context.Context, oldVal
 	}
 }
 
-func flattenKafkaConnectorTasks(r *aiven.KafkaConnector) []map[string]interface{} {
+func flattenKafkaConnectorTasks(r *aiven.KafkaConnector, status *aiven.KafkaConnectorStatus) []map[string]interface{} {
+	statuses := make(map[int]aiven.KafkaConnectorTaskStatus, len(status.Tasks))
+	for _, t := range status.Tasks {
+		statuses[t.Id] = t
+	}
+
 	tasks := make([]map[string]interface{}, len(r.Tasks))
 
 	for i, taskS := range r.Tasks {
 		task := map[string]interface{}{
 			"connector": taskS.Connector,
 			"task":      taskS.Task,
+			"state":     statuses[taskS.Task].State,
+			"trace":     statuses[taskS.Task].Trace,
 		}
 
 		tasks[i] = task
@@ -149,8 +277,22 @@ func flattenKafkaConnectorTasks(r *aiven.KafkaConnector) []map[string]interface{
 	return tasks
 }
 
-// nolint:staticcheck // TODO: Migrate to helper/retry package to avoid deprecated resource.StateRefreshFunc.
+func resourceKafkaConnectorImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	// Import doesn't set defaults
+	if err := d.Set("wait_for_running", false); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceKafkaConnectorRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return readKafkaConnector(ctx, d, m, true)
+}
+
+// readKafkaConnector reads the connector into the resource or the data source,
+// only the resource splits the sensitive keys into `config_sensitive`
+// nolint:staticcheck // TODO: Migrate to helper/retry package to avoid deprecated resource.StateRefreshFunc.
+func readKafkaConnector(ctx context.Context, d *schema.ResourceData, m interface{}, withSensitive bool) diag.Diagnostics {
 	project, serviceName, connectorName, err := schemautil.SplitResourceID3(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
@@ -185,40 +327,13 @@ func resourceKafkaConnectorRead(ctx context.Context, d *schema.ResourceData, m i
 	for _, r := range res.(*aiven.KafkaConnectorsResponse).Connectors {
 		if r.Name == connectorName {
 			found = true
-			if err := d.Set("project", project); err != nil {
-				return diag.Errorf("error setting Kafka Connector `project` for resource %s: %s", d.Id(), err)
-			}
-			if err := d.Set("service_name", serviceName); err != nil {
-				return diag.Errorf("error setting Kafka Connector `service_name` for resource %s: %s", d.Id(), err)
-			}
-			if err := d.Set("connector_name", connectorName); err != nil {
-				return diag.Errorf("error setting Kafka Connector `connector_name` for resource %s: %s", d.Id(), err)
-			}
-			if err := d.Set("config", r.Config); err != nil {
-				return diag.Errorf("error setting Kafka Connector `config` for resource %s: %s", d.Id(), err)
-			}
-			if err := d.Set("plugin_author", r.Plugin.Author); err != nil {
-				return diag.Errorf("error setting Kafka Connector `plugin_author` for resource %s: %s", d.Id(), err)
-			}
-			if err := d.Set("plugin_class", r.Plugin.Class); err != nil {
-				return diag.Errorf("error setting Kafka Connector `plugin_class` for resource %s: %s", d.Id(), err)
-			}
-			if err := d.Set("plugin_doc_url", r.Plugin.DocumentationURL); err != nil {
-				return diag.Errorf("error setting Kafka Connector `plugin_doc_url` for resource %s: %s", d.Id(), err)
-			}
-			if err := d.Set("plugin_title", r.Plugin.Title); err != nil {
-				return diag.Errorf("error setting Kafka Connector `plugin_title` for resource %s: %s", d.Id(), err)
-			}
-			if err := d.Set("plugin_type", r.Plugin.Type); err != nil {
-				return diag.Errorf("error setting Kafka Connector `plugin_type` for resource %s: %s", d.Id(), err)
-			}
-			if err := d.Set("plugin_version", r.Plugin.Version); err != nil {
-				return diag.Errorf("error setting Kafka Connector `plugin_version` for resource %s: %s", d.Id(), err)
+			status, err := m.(*aiven.Client).KafkaConnectors.Status(ctx, project, serviceName, connectorName)
+			if err != nil {
+				return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
 			}
 
-			tasks := flattenKafkaConnectorTasks(&r)
-			if err := d.Set("task", tasks); err != nil {
-				return diag.Errorf("error setting Kafka Connector `task` array for resource %s: %s", d.Id(), err)
+			if diags := setKafkaConnector(d, project, serviceName, &r, &status.Status, withSensitive); diags.HasError() {
+				return diags
 			}
 		}
 	}
@@ -230,15 +345,82 @@ func resourceKafkaConnectorRead(ctx context.Context, d *schema.ResourceData, m i
 	return nil
 }
 
+// setKafkaConnector sets the connector and its status, withSensitive is false for the data source
+func setKafkaConnector(
+	d *schema.ResourceData,
+	project, serviceName string,
+	r *aiven.KafkaConnector,
+	status *aiven.KafkaConnectorStatus,
+	withSensitive bool,
+) diag.Diagnostics {
+	if err := d.Set("project", project); err != nil {
+		return diag.Errorf("error setting Kafka Connector `project` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.Errorf("error setting Kafka Connector `service_name` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("connector_name", r.Name); err != nil {
+		return diag.Errorf("error setting Kafka Connector `connector_name` for resource %s: %s", d.Id(), err)
+	}
+	// The data source has no `config_sensitive`, all the keys are in `config`
+	config := make(map[string]interface{})
+	sensitive := make(map[string]interface{})
+	if withSensitive {
+		config = d.Get("config").(map[string]interface{})
+		sensitive = d.Get("config_sensitive").(map[string]interface{})
+	}
+	plain, secret := flattenKafkaConnectorConfig(r.Config, config, sensitive)
+	if err := d.Set("config", plain); err != nil {
+		return diag.Errorf("error setting Kafka Connector `config` for resource %s: %s", d.Id(), err)
+	}
+	if _, ok := d.GetOk("config_sensitive"); withSensitive && ok {
+		if err := d.Set("config_sensitive", secret); err != nil {
+			return diag.Errorf("error setting Kafka Connector `config_sensitive` for resource %s: %s", d.Id(), err)
+		}
+	}
+	if err := d.Set("plugin_author", r.Plugin.Author); err != nil {
+		return diag.Errorf("error setting Kafka Connector `plugin_author` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("plugin_class", r.Plugin.Class); err != nil {
+		return diag.Errorf("error setting Kafka Connector `plugin_class` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("plugin_doc_url", r.Plugin.DocumentationURL); err != nil {
+		return diag.Errorf("error setting Kafka Connector `plugin_doc_url` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("plugin_title", r.Plugin.Title); err != nil {
+		return diag.Errorf("error setting Kafka Connector `plugin_title` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("plugin_type", r.Plugin.Type); err != nil {
+		return diag.Errorf("error setting Kafka Connector `plugin_type` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("plugin_version", r.Plugin.Version); err != nil {
+		return diag.Errorf("error setting Kafka Connector `plugin_version` for resource %s: %s", d.Id(), err)
+	}
+
+	if err := d.Set("state", status.State); err != nil {
+		return diag.Errorf("error setting Kafka Connector `state` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("paused", status.State == kafkaConnectorStatePaused); err != nil {
+		return diag.Errorf("error setting Kafka Connector `paused` for resource %s: %s", d.Id(), err)
+	}
+
+	tasks := flattenKafkaConnectorTasks(r, status)
+	if err := d.Set("task", tasks); err != nil {
+		return diag.Errorf("error setting Kafka Connector `task` array for resource %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
 func resourceKafkaConnectorCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	project := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
 	connectorName := d.Get("connector_name").(string)
 
-	config := make(aiven.KafkaConnectorConfig)
-	for k, cS := range d.Get("config").(map[string]interface{}) {
-		config[k] = cS.(string)
-	}
+	config := expandKafkaConnectorConfig(
+		d.Get("config").(map[string]interface{}),
+		d.Get("config_sensitive").(map[string]interface{}),
+	)
 
 	// Sometimes this method returns 404: Not Found
 	// Since the aiven.Client has own retries for various scenarios
@@ -261,7 +443,11 @@ func resourceKafkaConnectorCreate(ctx context.Context, d *schema.ResourceData, m
 
 	d.SetId(schemautil.BuildResourceID(project, serviceName, connectorName))
 
-	return resourceKafkaConnectorRead(ctx, d, m)
+	if err := kafkaConnectorApplyState(ctx, d, m, schema.TimeoutCreate); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return append(kafkaConnectorConfigWarnings(ctx, d, m), resourceKafkaConnectorRead(ctx, d, m)...)
 }
 
 func resourceKafkaConnectorDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -284,15 +470,73 @@ func resourceKafkaTConnectorUpdate(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	config := make(aiven.KafkaConnectorConfig)
-	for k, cS := range d.Get("config").(map[string]interface{}) {
-		config[k] = cS.(string)
+	var diags diag.Diagnostics
+	client := m.(*aiven.Client)
+	if d.HasChanges("config", "config_sensitive") {
+		diags = kafkaConnectorConfigWarnings(ctx, d, m)
+		config := expandKafkaConnectorConfig(
+			d.Get("config").(map[string]interface{}),
+			d.Get("config_sensitive").(map[string]interface{}),
+		)
+
+		_, err = client.KafkaConnectors.Update(ctx, project, serviceName, connectorName, config)
+		if err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
-	_, err = m.(*aiven.Client).KafkaConnectors.Update(ctx, project, serviceName, connectorName, config)
-	if err != nil {
+	if d.HasChange("restart_trigger") && !d.Get("paused").(bool) {
+		err = restartKafkaConnector(ctx, client, project, serviceName, connectorName)
+		if err != nil {
+			return diag.Errorf("error restarting Kafka Connector %s: %s", connectorName, err)
+		}
+	}
+
+	if err := kafkaConnectorApplyState(ctx, d, m, schema.TimeoutUpdate); err != nil {
 		return diag.FromErr(err)
 	}
 
-	return resourceKafkaConnectorRead(ctx, d, m)
+	return append(diags, resourceKafkaConnectorRead(ctx, d, m)...)
+}
+
+// kafkaConnectorApplyState pauses or resumes the connector, and waits for it to be running if requested
+func kafkaConnectorApplyState(ctx context.Context, d *schema.ResourceData, m interface{}, timeoutKey string) error {
+	project, serviceName, connectorName, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return err
+	}
+
+	client := m.(*aiven.Client)
+	timeout := d.Timeout(timeoutKey)
+	paused := d.Get("paused").(bool)
+	if paused {
+		// A new connector is created running, so it is paused on create too
+		if d.IsNewResource() || d.HasChange("paused") {
+			err = pauseKafkaConnector(ctx, client, project, serviceName, connectorName)
+			if err != nil {
+				return fmt.Errorf("error pausing Kafka Connector %s: %w", connectorName, err)
+			}
+		}
+		return waitForKafkaConnectorState(ctx, client, project, serviceName, connectorName, timeout, kafkaConnectorStatePaused)
+	}
+
+	if d.HasChange("paused") && !d.IsNewResource() {
+		err = resumeKafkaConnector(ctx, client, project, serviceName, connectorName)
+		if err != nil {
+			return fmt.Errorf("error resuming Kafka Connector %s: %w", connectorName, err)
+		}
+
+		if !d.Get("wait_for_running").(bool) {
+			// Waits for it to leave PAUSED state only, so the next plan has no diff
+			return waitForKafkaConnectorState(
+				ctx, client, project, serviceName, connectorName, timeout,
+				kafkaConnectorStateRunning, kafkaConnectorStateFailed, kafkaConnectorStateUnassigned,
+			)
+		}
+	}
+
+	if d.Get("wait_for_running").(bool) {
+		return waitForKafkaConnectorState(ctx, client, project, serviceName, connectorName, timeout, kafkaConnectorStateRunning)
+	}
+	return nil
 }
@@ -0,0 +1,68 @@
+package kafka
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aiven/aiven-go-client/v2"
+)
+
+// kafkaConnectorSecretReference matches secret provider references, e.g. ${aiven:vault:secret/db:password}.
+// Kafka Connect resolves them on the workers, so the API may return either the reference or the resolved value.
+var kafkaConnectorSecretReference = regexp.MustCompile(`^\$\{aiven:[^}]+}$`)
+
+// expandKafkaConnectorConfig merges config and config_sensitive into the API request
+func expandKafkaConnectorConfig(config, sensitive map[string]interface{}) aiven.KafkaConnectorConfig {
+	result := make(aiven.KafkaConnectorConfig, len(config)+len(sensitive))
+	for k, v := range config {
+		result[k] = v.(string)
+	}
+	for k, v := range sensitive {
+		result[k] = v.(string)
+	}
+	return result
+}
+
+// flattenKafkaConnectorConfig splits the remote config back into config and config_sensitive.
+// The keys that are already in config_sensitive stay there, so secrets never leak into config.
+// Secret provider references are kept as they are in the state, the remote value can't be compared with them.
+func flattenKafkaConnectorConfig(
+	remote aiven.KafkaConnectorConfig,
+	config, sensitive map[string]interface{},
+) (map[string]string, map[string]string) {
+	plain := make(map[string]string)
+	secret := make(map[string]string)
+	for k, v := range remote {
+		target := plain
+		prev, ok := config[k]
+		if s, isSensitive := sensitive[k]; isSensitive {
+			target = secret
+			prev, ok = s, true
+		}
+
+		if ok && kafkaConnectorSecretReference.MatchString(prev.(string)) {
+			v = prev.(string)
+		}
+		target[k] = v
+	}
+	return plain, secret
+}
+
+// kafkaConnectorConfigOverlap returns an error if a key is set both in config and config_sensitive
+func kafkaConnectorConfigOverlap(config, sensitive map[string]interface{}) error {
+	overlap := make([]string, 0)
+	for k := range sensitive {
+		if _, ok := config[k]; ok {
+			overlap = append(overlap, k)
+		}
+	}
+
+	if len(overlap) == 0 {
+		return nil
+	}
+
+	sort.Strings(overlap)
+	return fmt.Errorf("keys can't be set both in config and config_sensitive: %s", strings.Join(overlap, ", "))
+}
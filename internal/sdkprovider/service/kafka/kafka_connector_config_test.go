@@ -0,0 +1,82 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandKafkaConnectorConfig(t *testing.T) {
+	config := map[string]interface{}{"name": "pg-sink", "connection.user": "avnadmin"}
+	sensitive := map[string]interface{}{"connection.password": "secret"}
+
+	expected := aiven.KafkaConnectorConfig{
+		"name":                "pg-sink",
+		"connection.user":     "avnadmin",
+		"connection.password": "secret",
+	}
+	assert.Equal(t, expected, expandKafkaConnectorConfig(config, sensitive))
+}
+
+func TestFlattenKafkaConnectorConfig(t *testing.T) {
+	cases := []struct {
+		name              string
+		remote            aiven.KafkaConnectorConfig
+		config            map[string]interface{}
+		sensitive         map[string]interface{}
+		expectedConfig    map[string]string
+		expectedSensitive map[string]string
+	}{
+		{
+			name:              "no sensitive keys",
+			remote:            aiven.KafkaConnectorConfig{"name": "foo", "topics": "bar"},
+			config:            map[string]interface{}{"name": "foo"},
+			expectedConfig:    map[string]string{"name": "foo", "topics": "bar"},
+			expectedSensitive: map[string]string{},
+		},
+		{
+			name:              "sensitive keys don't leak into config",
+			remote:            aiven.KafkaConnectorConfig{"name": "foo", "connection.password": "changed"},
+			config:            map[string]interface{}{"name": "foo"},
+			sensitive:         map[string]interface{}{"connection.password": "secret"},
+			expectedConfig:    map[string]string{"name": "foo"},
+			expectedSensitive: map[string]string{"connection.password": "changed"},
+		},
+		{
+			name:   "secret provider references are kept",
+			remote: aiven.KafkaConnectorConfig{"name": "foo", "connection.user": "avnadmin", "connection.password": "resolved"},
+			config: map[string]interface{}{"name": "foo", "connection.user": "${aiven:vault:secret/db:user}"},
+			sensitive: map[string]interface{}{
+				"connection.password": "${aiven:vault:secret/db:password}",
+			},
+			expectedConfig:    map[string]string{"name": "foo", "connection.user": "${aiven:vault:secret/db:user}"},
+			expectedSensitive: map[string]string{"connection.password": "${aiven:vault:secret/db:password}"},
+		},
+		{
+			name:              "other references are compared",
+			remote:            aiven.KafkaConnectorConfig{"name": "foo", "connection.user": "resolved"},
+			config:            map[string]interface{}{"name": "foo", "connection.user": "${file:/tmp/db:user}"},
+			expectedConfig:    map[string]string{"name": "foo", "connection.user": "resolved"},
+			expectedSensitive: map[string]string{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			config, sensitive := flattenKafkaConnectorConfig(c.remote, c.config, c.sensitive)
+			assert.Equal(t, c.expectedConfig, config)
+			assert.Equal(t, c.expectedSensitive, sensitive)
+		})
+	}
+}
+
+func TestKafkaConnectorConfigOverlap(t *testing.T) {
+	config := map[string]interface{}{"name": "foo", "connection.password": "a", "connection.user": "b"}
+
+	assert.NoError(t, kafkaConnectorConfigOverlap(config, map[string]interface{}{"connection.url": "c"}))
+	assert.EqualError(t,
+		kafkaConnectorConfigOverlap(config, map[string]interface{}{"connection.user": "b", "connection.password": "a"}),
+		"keys can't be set both in config and config_sensitive: connection.password, connection.user",
+	)
+}
@@ -14,11 +14,22 @@ func DatasourceKafkaConnector() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: datasourceKafkaConnectorRead,
 		Description: "The Kafka connector data source provides information about the existing Aiven Kafka connector.",
-		Schema: schemautil.ResourceSchemaAsDatasourceSchema(aivenKafkaConnectorSchema,
-			"project", "service_name", "connector_name"),
+		Schema:      datasourceKafkaConnectorSchema(),
 	}
 }
 
+// datasourceKafkaConnectorSchema the data source can't tell the sensitive keys apart, so everything is in `config`.
+// Doesn't have the fields that control the connector.
+func datasourceKafkaConnectorSchema() map[string]*schema.Schema {
+	s := schemautil.ResourceSchemaAsDatasourceSchema(aivenKafkaConnectorSchema,
+		"project", "service_name", "connector_name")
+	for _, k := range []string{"config_sensitive", "wait_for_running", "restart_trigger"} {
+		delete(s, k)
+	}
+	s["config"].Description = "The Kafka Connector configuration parameters, including the ones that contain secrets."
+	return s
+}
+
 func datasourceKafkaConnectorRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	projectName := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
@@ -32,7 +43,7 @@ func datasourceKafkaConnectorRead(ctx context.Context, d *schema.ResourceData, m
 	for _, con := range cons.Connectors {
 		if con.Name == connectorName {
 			d.SetId(schemautil.BuildResourceID(projectName, serviceName, connectorName))
-			return resourceKafkaConnectorRead(ctx, d, m)
+			return readKafkaConnector(ctx, d, m, false)
 		}
 	}
 
@@ -0,0 +1,44 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetKafkaConnectorDataSource(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, datasourceKafkaConnectorSchema(), map[string]interface{}{
+		"project":        "p",
+		"service_name":   "s",
+		"connector_name": "pg-sink",
+	})
+	d.SetId("p/s/pg-sink")
+
+	r := &aiven.KafkaConnector{
+		Name: "pg-sink",
+		Config: aiven.KafkaConnectorConfig{
+			"name":                "pg-sink",
+			"connection.password": "secret",
+		},
+		Plugin: aiven.KafkaConnectorPlugin{Class: "io.aiven.connect.jdbc.JdbcSinkConnector"},
+		Tasks:  []aiven.KafkaConnectorTask{{Connector: "pg-sink", Task: 0}},
+	}
+	status := &aiven.KafkaConnectorStatus{
+		State: "RUNNING",
+		Tasks: []aiven.KafkaConnectorTaskStatus{{Id: 0, State: "RUNNING"}},
+	}
+
+	// The data source has no config_sensitive, all the keys are in config
+	diags := setKafkaConnector(d, "p", "s", r, status, false)
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, map[string]interface{}{
+		"name":                "pg-sink",
+		"connection.password": "secret",
+	}, d.Get("config"))
+	assert.Equal(t, "io.aiven.connect.jdbc.JdbcSinkConnector", d.Get("plugin_class"))
+	assert.Equal(t, "RUNNING", d.Get("state"))
+	assert.Equal(t, 1, d.Get("task").(*schema.Set).Len())
+}
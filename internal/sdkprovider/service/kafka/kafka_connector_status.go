@@ -0,0 +1,150 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"golang.org/x/exp/slices"
+
+	"github.com/aiven/terraform-provider-aiven/internal/common"
+)
+
+// Kafka Connect connector and task states
+const (
+	kafkaConnectorStateRunning    = "RUNNING"
+	kafkaConnectorStatePaused     = "PAUSED"
+	kafkaConnectorStateFailed     = "FAILED"
+	kafkaConnectorStateUnassigned = "UNASSIGNED"
+)
+
+// kafkaConnectorPath returns the connector endpoint.
+// aiven.Client doesn't support pause, resume and restart yet, see common.DoRequest
+func kafkaConnectorPath(project, serviceName, connectorName string, suffix ...string) string {
+	p := fmt.Sprintf(
+		"/project/%s/service/%s/connectors/%s",
+		url.PathEscape(project), url.PathEscape(serviceName), url.PathEscape(connectorName),
+	)
+	for _, s := range suffix {
+		p += "/" + url.PathEscape(s)
+	}
+	return p
+}
+
+func pauseKafkaConnector(ctx context.Context, client *aiven.Client, project, serviceName, connectorName string) error {
+	path := kafkaConnectorPath(project, serviceName, connectorName, "pause")
+	return common.DoRequest(ctx, client, http.MethodPost, path, nil, nil)
+}
+
+func resumeKafkaConnector(ctx context.Context, client *aiven.Client, project, serviceName, connectorName string) error {
+	path := kafkaConnectorPath(project, serviceName, connectorName, "resume")
+	return common.DoRequest(ctx, client, http.MethodPost, path, nil, nil)
+}
+
+// restartKafkaConnector restarts the connector and then its failed tasks,
+// connector restart doesn't restart the tasks
+func restartKafkaConnector(ctx context.Context, client *aiven.Client, project, serviceName, connectorName string) error {
+	path := kafkaConnectorPath(project, serviceName, connectorName, "restart")
+	err := common.DoRequest(ctx, client, http.MethodPost, path, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	rsp, err := client.KafkaConnectors.Status(ctx, project, serviceName, connectorName)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range rsp.Status.Tasks {
+		if t.State != kafkaConnectorStateFailed {
+			continue
+		}
+
+		path = kafkaConnectorPath(project, serviceName, connectorName, "tasks", strconv.Itoa(t.Id), "restart")
+		err = common.DoRequest(ctx, client, http.MethodPost, path, nil, nil)
+		if err != nil {
+			return fmt.Errorf("error restarting task %d: %w", t.Id, err)
+		}
+	}
+	return nil
+}
+
+// waitForKafkaConnectorState waits for the connector and all its tasks to get one of the target states.
+// Fails early if the connector or any task is FAILED, unless FAILED is a target.
+func waitForKafkaConnectorState(
+	ctx context.Context,
+	client *aiven.Client,
+	project, serviceName, connectorName string,
+	timeout time.Duration,
+	targets ...string,
+) error {
+	conf := &retry.StateChangeConf{
+		Pending: []string{"waiting"},
+		Target:  targets,
+		Refresh: func() (interface{}, string, error) {
+			rsp, err := client.KafkaConnectors.Status(ctx, project, serviceName, connectorName)
+			if err != nil {
+				if aiven.IsNotFound(err) {
+					return nil, "", err
+				}
+				log.Printf("[DEBUG] Kafka Connector status waiter err %s", err.Error())
+				return nil, "waiting", nil
+			}
+
+			state := kafkaConnectorAggregateState(&rsp.Status)
+			if state == kafkaConnectorStateFailed && !slices.Contains(targets, state) {
+				return nil, "", kafkaConnectorStatusError(&rsp.Status)
+			}
+			if !slices.Contains(targets, state) {
+				return rsp, "waiting", nil
+			}
+			return rsp, state, nil
+		},
+		Delay:      2 * time.Second,
+		Timeout:    timeout,
+		MinTimeout: 2 * time.Second,
+	}
+
+	_, err := conf.WaitForStateContext(ctx)
+	if err != nil {
+		return fmt.Errorf("error waiting for Kafka Connector %s to be %s: %w", connectorName, strings.Join(targets, " or "), err)
+	}
+	return nil
+}
+
+// kafkaConnectorAggregateState returns FAILED if the connector or any of its tasks failed,
+// the connector state if all tasks are in the same state, otherwise "waiting"
+func kafkaConnectorAggregateState(status *aiven.KafkaConnectorStatus) string {
+	if status.State == kafkaConnectorStateFailed {
+		return kafkaConnectorStateFailed
+	}
+
+	state := status.State
+	for _, t := range status.Tasks {
+		if t.State == kafkaConnectorStateFailed {
+			return kafkaConnectorStateFailed
+		}
+		if t.State != status.State {
+			state = "waiting"
+		}
+	}
+	return state
+}
+
+// kafkaConnectorStatusError returns an error with the traces of the failed tasks
+func kafkaConnectorStatusError(status *aiven.KafkaConnectorStatus) error {
+	failed := make([]string, 0)
+	for _, t := range status.Tasks {
+		if t.State == kafkaConnectorStateFailed {
+			failed = append(failed, fmt.Sprintf("task %d: %s", t.Id, t.Trace))
+		}
+	}
+	return fmt.Errorf("connector state is %s, failed tasks:\n%s", status.State, strings.Join(failed, "\n"))
+}
@@ -0,0 +1,69 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKafkaConnectorAggregateState(t *testing.T) {
+	cases := []struct {
+		name     string
+		status   aiven.KafkaConnectorStatus
+		expected string
+	}{
+		{
+			name:     "no tasks",
+			status:   aiven.KafkaConnectorStatus{State: "RUNNING"},
+			expected: "RUNNING",
+		},
+		{
+			name: "all running",
+			status: aiven.KafkaConnectorStatus{
+				State: "RUNNING",
+				Tasks: []aiven.KafkaConnectorTaskStatus{{Id: 0, State: "RUNNING"}, {Id: 1, State: "RUNNING"}},
+			},
+			expected: "RUNNING",
+		},
+		{
+			name: "task is not assigned yet",
+			status: aiven.KafkaConnectorStatus{
+				State: "RUNNING",
+				Tasks: []aiven.KafkaConnectorTaskStatus{{Id: 0, State: "RUNNING"}, {Id: 1, State: "UNASSIGNED"}},
+			},
+			expected: "waiting",
+		},
+		{
+			name: "task failed",
+			status: aiven.KafkaConnectorStatus{
+				State: "RUNNING",
+				Tasks: []aiven.KafkaConnectorTaskStatus{{Id: 0, State: "FAILED"}, {Id: 1, State: "UNASSIGNED"}},
+			},
+			expected: "FAILED",
+		},
+		{
+			name:     "connector failed",
+			status:   aiven.KafkaConnectorStatus{State: "FAILED"},
+			expected: "FAILED",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.expected, kafkaConnectorAggregateState(&c.status))
+		})
+	}
+}
+
+func TestKafkaConnectorStatusError(t *testing.T) {
+	status := &aiven.KafkaConnectorStatus{
+		State: "RUNNING",
+		Tasks: []aiven.KafkaConnectorTaskStatus{
+			{Id: 0, State: "RUNNING"},
+			{Id: 1, State: "FAILED", Trace: "org.apache.kafka.connect.errors.ConnectException"},
+		},
+	}
+	assert.EqualError(t, kafkaConnectorStatusError(status),
+		"connector state is RUNNING, failed tasks:\ntask 1: org.apache.kafka.connect.errors.ConnectException")
+}
@@ -44,6 +44,92 @@ func TestAccAivenKafkaConnector_basic(t *testing.T) {
 	})
 }
 
+func TestAccAivenKafkaConnector_sensitive(t *testing.T) {
+	resourceName := "aiven_kafka_connector.foo"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acc.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acc.TestProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAivenKafkaConnectorResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccKafkaConnectorSensitiveResource(rName, `"connection.password" = "foo"`, ""),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("keys can't be set both in config and config_sensitive: connection.password"),
+			},
+			{
+				Config: testAccKafkaConnectorSensitiveResource(rName, "", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "config.connection.username", "avnadmin"),
+					resource.TestCheckNoResourceAttr(resourceName, "config.connection.password"),
+					resource.TestCheckResourceAttrPair(
+						resourceName, "config_sensitive.connection.password",
+						"aiven_opensearch.dest", "service_password",
+					),
+					resource.TestCheckResourceAttrSet("data.aiven_kafka_connector.connector", "config.connection.password"),
+				),
+			},
+			{
+				// Reads the same values, so there is no drift
+				Config:   testAccKafkaConnectorSensitiveResource(rName, "", ""),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccAivenKafkaConnector_state(t *testing.T) {
+	resourceName := "aiven_kafka_connector.foo"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acc.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acc.TestProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAivenKafkaConnectorResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKafkaConnectorSensitiveResource(rName, "", "wait_for_running = true"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "state", "RUNNING"),
+					resource.TestCheckResourceAttr(resourceName, "paused", "false"),
+					resource.TestCheckResourceAttr(resourceName, "task.0.state", "RUNNING"),
+					resource.TestCheckResourceAttr("data.aiven_kafka_connector.connector", "state", "RUNNING"),
+				),
+			},
+			{
+				Config:      testAccKafkaConnectorSensitiveResource(rName, `"batch.size" = "many"`, "wait_for_running = true"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`"batch.size" expected to be of type INT, got "many"`),
+			},
+			{
+				Config: testAccKafkaConnectorSensitiveResource(rName, "", "paused = true"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "state", "PAUSED"),
+					resource.TestCheckResourceAttr(resourceName, "paused", "true"),
+				),
+			},
+			{
+				Config: testAccKafkaConnectorSensitiveResource(rName, "", `
+  wait_for_running = true
+  restart_trigger  = "1"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "state", "RUNNING"),
+					resource.TestCheckResourceAttr(resourceName, "paused", "false"),
+					resource.TestCheckResourceAttr(resourceName, "restart_trigger", "1"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"config", "config_sensitive", "restart_trigger", "wait_for_running"},
+			},
+		},
+	})
+}
+
 func TestAccAivenKafkaConnector_mogosink(t *testing.T) {
 	if os.Getenv("MONGO_URI") == "" {
 		t.Skip("MONGO_URI environment variable is required to run this test")
@@ -256,6 +342,74 @@ data "aiven_kafka_connector" "connector" {
 }`, os.Getenv("AIVEN_PROJECT_NAME"), name, name, name, name, name)
 }
 
+// nosemgrep: kafka connectors need kafka with business plans
+func testAccKafkaConnectorSensitiveResource(name, configExtra, extra string) string {
+	return fmt.Sprintf(`
+data "aiven_project" "foo" {
+  project = "%s"
+}
+
+resource "aiven_kafka" "bar" {
+  project                 = data.aiven_project.foo.project
+  cloud_name              = "google-europe-west1"
+  plan                    = "business-4"
+  service_name            = "test-acc-sr-%s"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+
+  kafka_user_config {
+    kafka_connect = true
+  }
+}
+
+resource "aiven_kafka_topic" "foo" {
+  project      = data.aiven_project.foo.project
+  service_name = aiven_kafka.bar.service_name
+  topic_name   = "test-acc-topic-%s"
+  partitions   = 3
+  replication  = 2
+}
+
+resource "aiven_opensearch" "dest" {
+  project                 = data.aiven_project.foo.project
+  cloud_name              = "google-europe-west1"
+  plan                    = "startup-4"
+  service_name            = "test-acc-sr2-%s"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+}
+
+resource "aiven_kafka_connector" "foo" {
+  project        = data.aiven_project.foo.project
+  service_name   = aiven_kafka.bar.service_name
+  connector_name = "test-acc-con-%s"
+
+  config = {
+    "topics"              = aiven_kafka_topic.foo.topic_name
+    "connector.class"     = "io.aiven.kafka.connect.opensearch.OpensearchSinkConnector"
+    "type.name"           = "es-connector"
+    "name"                = "test-acc-con-%s"
+    "connection.url"      = "https://${aiven_opensearch.dest.service_host}:${aiven_opensearch.dest.service_port}"
+    "connection.username" = aiven_opensearch.dest.service_username
+    %s
+  }
+
+  config_sensitive = {
+    "connection.password" = aiven_opensearch.dest.service_password
+  }
+
+  %s
+}
+
+data "aiven_kafka_connector" "connector" {
+  project        = aiven_kafka_connector.foo.project
+  service_name   = aiven_kafka_connector.foo.service_name
+  connector_name = aiven_kafka_connector.foo.connector_name
+
+  depends_on = [aiven_kafka_connector.foo]
+}`, os.Getenv("AIVEN_PROJECT_NAME"), name, name, name, name, name, configExtra, extra)
+}
+
 func testAccKafkaConnectorMonoSinkResource(name string) string {
 	return fmt.Sprintf(`
 data "aiven_project" "foo" {
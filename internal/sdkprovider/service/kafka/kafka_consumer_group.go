@@ -0,0 +1,161 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/aiven/aiven-go-client/v2"
+
+	"github.com/aiven/terraform-provider-aiven/internal/common"
+)
+
+// Kafka consumer group states, see org.apache.kafka.common.ConsumerGroupState
+const (
+	kafkaConsumerGroupStateEmpty = "Empty"
+	kafkaConsumerGroupStateDead  = "Dead"
+)
+
+// Offset reset modes of aiven_kafka_consumer_group_offsets
+const (
+	kafkaConsumerGroupResetEarliest  = "earliest"
+	kafkaConsumerGroupResetLatest    = "latest"
+	kafkaConsumerGroupResetTimestamp = "timestamp"
+	kafkaConsumerGroupResetOffsets   = "offsets"
+)
+
+var kafkaConsumerGroupResetModes = []string{
+	kafkaConsumerGroupResetEarliest,
+	kafkaConsumerGroupResetLatest,
+	kafkaConsumerGroupResetTimestamp,
+	kafkaConsumerGroupResetOffsets,
+}
+
+// kafkaConsumerGroup Kafka consumer group with its members and committed offsets.
+// aiven.Client doesn't support these yet, see common.DoRequest
+type kafkaConsumerGroup struct {
+	GroupID string                      `json:"group_id"`
+	State   string                      `json:"state"`
+	Members []*kafkaConsumerGroupMember `json:"members"`
+	Offsets []*kafkaConsumerGroupOffset `json:"offsets"`
+}
+
+type kafkaConsumerGroupMember struct {
+	MemberID string `json:"member_id"`
+	ClientID string `json:"client_id"`
+	Host     string `json:"host"`
+}
+
+// kafkaConsumerGroupOffset committed offset of a partition, EndOffset is the partition's latest offset
+type kafkaConsumerGroupOffset struct {
+	Topic     string `json:"topic"`
+	Partition int    `json:"partition"`
+	Offset    int64  `json:"offset"`
+	EndOffset int64  `json:"end_offset,omitempty"`
+}
+
+type kafkaConsumerGroupListResponse struct {
+	ConsumerGroups []*kafkaConsumerGroup `json:"consumer_groups"`
+}
+
+// kafkaConsumerGroupOffsetsReset resets the group offsets.
+// Timestamp is in milliseconds since epoch, Topics limits earliest, latest and timestamp resets
+type kafkaConsumerGroupOffsetsReset struct {
+	ResetTo   string                      `json:"reset_to"`
+	Timestamp *int64                      `json:"timestamp,omitempty"`
+	Topics    []string                    `json:"topics,omitempty"`
+	Offsets   []*kafkaConsumerGroupOffset `json:"offsets,omitempty"`
+}
+
+// kafkaConsumerGroupPath returns the consumer groups endpoint, or the group's one if groupID is set
+func kafkaConsumerGroupPath(project, serviceName, groupID string, suffix ...string) string {
+	p := fmt.Sprintf("/project/%s/service/%s/kafka/consumer-groups", url.PathEscape(project), url.PathEscape(serviceName))
+	if groupID != "" {
+		p += "/" + url.PathEscape(groupID)
+	}
+	for _, s := range suffix {
+		p += "/" + s
+	}
+	return p
+}
+
+func listKafkaConsumerGroups(ctx context.Context, client *aiven.Client, project, serviceName string) ([]*kafkaConsumerGroup, error) {
+	rsp := new(kafkaConsumerGroupListResponse)
+	err := common.DoRequest(ctx, client, http.MethodGet, kafkaConsumerGroupPath(project, serviceName, ""), nil, rsp)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(rsp.ConsumerGroups, func(i, j int) bool {
+		return rsp.ConsumerGroups[i].GroupID < rsp.ConsumerGroups[j].GroupID
+	})
+	for _, g := range rsp.ConsumerGroups {
+		sortKafkaConsumerGroupOffsets(g.Offsets)
+	}
+	return rsp.ConsumerGroups, nil
+}
+
+// getKafkaConsumerGroup returns the group, or aiven.Error 404 if the group doesn't exist
+func getKafkaConsumerGroup(ctx context.Context, client *aiven.Client, project, serviceName, groupID string) (*kafkaConsumerGroup, error) {
+	list, err := listKafkaConsumerGroups(ctx, client, project, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, g := range list {
+		if g.GroupID == groupID {
+			return g, nil
+		}
+	}
+	return nil, aiven.Error{Status: http.StatusNotFound, Message: fmt.Sprintf("Kafka consumer group %q not found", groupID)}
+}
+
+func resetKafkaConsumerGroupOffsets(
+	ctx context.Context,
+	client *aiven.Client,
+	project, serviceName, groupID string,
+	req *kafkaConsumerGroupOffsetsReset,
+) error {
+	path := kafkaConsumerGroupPath(project, serviceName, groupID, "offsets", "reset")
+	return common.DoRequest(ctx, client, http.MethodPost, path, req, nil)
+}
+
+// kafkaConsumerGroupActiveError returns an error if the group has members.
+// Kafka rejects offset commits from outside the group while it is active,
+// and the consumers would overwrite the offsets anyway.
+func kafkaConsumerGroupActiveError(g *kafkaConsumerGroup) error {
+	if g == nil {
+		return nil
+	}
+
+	switch g.State {
+	case kafkaConsumerGroupStateEmpty, kafkaConsumerGroupStateDead, "":
+		if len(g.Members) == 0 {
+			return nil
+		}
+	}
+
+	return fmt.Errorf(
+		"consumer group %q is active (state %s, %d members), stop its consumers before resetting the offsets",
+		g.GroupID, g.State, len(g.Members),
+	)
+}
+
+// kafkaConsumerGroupLag returns the number of messages the group is behind on the partition
+func kafkaConsumerGroupLag(o *kafkaConsumerGroupOffset) int64 {
+	if o.Offset < 0 || o.EndOffset < o.Offset {
+		return 0
+	}
+	return o.EndOffset - o.Offset
+}
+
+func sortKafkaConsumerGroupOffsets(offsets []*kafkaConsumerGroupOffset) {
+	sort.Slice(offsets, func(i, j int) bool {
+		if offsets[i].Topic != offsets[j].Topic {
+			return offsets[i].Topic < offsets[j].Topic
+		}
+		return offsets[i].Partition < offsets[j].Partition
+	})
+}
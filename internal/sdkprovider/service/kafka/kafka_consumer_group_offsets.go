@@ -0,0 +1,292 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/aiven/terraform-provider-aiven/internal/common"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil/userconfig"
+)
+
+var aivenKafkaConsumerGroupOffsetsSchema = map[string]*schema.Schema{
+	"project":      schemautil.CommonSchemaProjectReference,
+	"service_name": schemautil.CommonSchemaServiceNameReference,
+	"group_id": {
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringLenBetween(1, 249),
+		Description:  userconfig.Desc("The consumer group ID.").ForceNew().Build(),
+	},
+	"reset_to": {
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validation.StringInSlice(kafkaConsumerGroupResetModes, false),
+		Description: userconfig.Desc("Where to reset the offsets to. Changing any of the reset arguments " +
+			"resets the offsets again.").PossibleValues(schemautil.StringSliceToInterfaceSlice(kafkaConsumerGroupResetModes)...).Build(),
+	},
+	"timestamp": {
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validation.IsRFC3339Time,
+		Description: "RFC3339 time to reset the offsets to, required when `reset_to` is `timestamp`. " +
+			"Each partition is reset to the earliest offset with a greater or equal timestamp.",
+	},
+	"topics": {
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+		Description: "Topics to reset the offsets for when `reset_to` is `earliest`, `latest` or `timestamp`. " +
+			"Defaults to all topics the group has committed offsets for.",
+	},
+	"offset": {
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "Explicit offsets to reset to, required when `reset_to` is `offsets`.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"topic": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "The topic name.",
+				},
+				"partition": {
+					Type:         schema.TypeInt,
+					Required:     true,
+					ValidateFunc: validation.IntAtLeast(0),
+					Description:  "The partition number.",
+				},
+				"offset": {
+					Type:         schema.TypeInt,
+					Required:     true,
+					ValidateFunc: validation.IntAtLeast(0),
+					Description:  "The offset to reset to.",
+				},
+			},
+		},
+	},
+	"committed_offsets": {
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The group's committed offsets per partition.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"topic": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The topic name.",
+				},
+				"partition": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "The partition number.",
+				},
+				"offset": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "The committed offset.",
+				},
+			},
+		},
+	},
+}
+
+func ResourceKafkaConsumerGroupOffsets() *schema.Resource {
+	return &schema.Resource{
+		Description: "The Kafka Consumer Group Offsets resource resets the offsets of a consumer group " +
+			"in an Aiven Kafka service. The group must have no active members. " +
+			"The offsets are reset on create and whenever the reset arguments change, " +
+			"deleting the resource doesn't change the offsets.",
+		CreateContext: resourceKafkaConsumerGroupOffsetsCreate,
+		ReadContext:   resourceKafkaConsumerGroupOffsetsRead,
+		UpdateContext: resourceKafkaConsumerGroupOffsetsUpdate,
+		DeleteContext: resourceKafkaConsumerGroupOffsetsDelete,
+		CustomizeDiff: customizeDiffKafkaConsumerGroupOffsets,
+		Timeouts:      schemautil.DefaultResourceTimeouts(),
+
+		Schema: aivenKafkaConsumerGroupOffsetsSchema,
+	}
+}
+
+func resourceKafkaConsumerGroupOffsetsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	project := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	groupID := d.Get("group_id").(string)
+
+	err := resetKafkaConsumerGroupOffsetsFromSchema(ctx, d, m.(*aiven.Client), project, serviceName, groupID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(project, serviceName, groupID))
+
+	return resourceKafkaConsumerGroupOffsetsRead(ctx, d, m)
+}
+
+func resourceKafkaConsumerGroupOffsetsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	project, serviceName, groupID, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	offsets := make([]map[string]interface{}, 0)
+	group, err := getKafkaConsumerGroup(ctx, m.(*aiven.Client), project, serviceName, groupID)
+	switch {
+	case aiven.IsNotFound(err):
+		// Removing the resource from the state would reset the offsets on the next apply.
+		// Kafka drops the group once its offsets expire, that doesn't mean the reset must be repeated.
+		log.Printf("[DEBUG] Kafka consumer group %s not found, it has no committed offsets", d.Id())
+	case err != nil:
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	default:
+		for _, o := range group.Offsets {
+			offsets = append(offsets, map[string]interface{}{
+				"topic":     o.Topic,
+				"partition": o.Partition,
+				"offset":    int(o.Offset),
+			})
+		}
+	}
+
+	if err := d.Set("project", project); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("group_id", groupID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("committed_offsets", offsets); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceKafkaConsumerGroupOffsetsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	project, serviceName, groupID, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChanges("reset_to", "timestamp", "topics", "offset") {
+		err = resetKafkaConsumerGroupOffsetsFromSchema(ctx, d, m.(*aiven.Client), project, serviceName, groupID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceKafkaConsumerGroupOffsetsRead(ctx, d, m)
+}
+
+func resourceKafkaConsumerGroupOffsetsDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	// The offsets can't be "unreset", the resource is only removed from the state
+	log.Printf("[DEBUG] Kafka consumer group offsets %s removed from the state, the offsets are left as they are", d.Id())
+	return nil
+}
+
+func customizeDiffKafkaConsumerGroupOffsets(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	for _, k := range []string{"reset_to", "timestamp", "topics", "offset"} {
+		if !d.NewValueKnown(k) {
+			return nil
+		}
+	}
+
+	return validateKafkaConsumerGroupOffsetsReset(
+		d.Get("reset_to").(string),
+		d.Get("timestamp").(string),
+		d.Get("topics").(*schema.Set).Len(),
+		len(d.Get("offset").([]interface{})),
+	)
+}
+
+// validateKafkaConsumerGroupOffsetsReset checks that the arguments match the reset mode
+func validateKafkaConsumerGroupOffsetsReset(resetTo, timestamp string, topics, offsets int) error {
+	switch resetTo {
+	case kafkaConsumerGroupResetOffsets:
+		if offsets == 0 {
+			return fmt.Errorf("`offset` is required when `reset_to` is %q", resetTo)
+		}
+		if timestamp != "" || topics > 0 {
+			return fmt.Errorf("`timestamp` and `topics` can't be used when `reset_to` is %q", resetTo)
+		}
+	case kafkaConsumerGroupResetTimestamp:
+		if timestamp == "" {
+			return fmt.Errorf("`timestamp` is required when `reset_to` is %q", resetTo)
+		}
+		if offsets > 0 {
+			return fmt.Errorf("`offset` can be used only when `reset_to` is %q", kafkaConsumerGroupResetOffsets)
+		}
+	default:
+		if timestamp != "" {
+			return fmt.Errorf("`timestamp` can be used only when `reset_to` is %q", kafkaConsumerGroupResetTimestamp)
+		}
+		if offsets > 0 {
+			return fmt.Errorf("`offset` can be used only when `reset_to` is %q", kafkaConsumerGroupResetOffsets)
+		}
+	}
+	return nil
+}
+
+// resetKafkaConsumerGroupOffsetsFromSchema resets the offsets, refuses to do so while the group is active
+func resetKafkaConsumerGroupOffsetsFromSchema(
+	ctx context.Context,
+	d *schema.ResourceData,
+	client *aiven.Client,
+	project, serviceName, groupID string,
+) error {
+	req, err := kafkaConsumerGroupOffsetsResetFromSchema(d)
+	if err != nil {
+		return err
+	}
+
+	// A group that doesn't exist yet is created with the offsets
+	group, err := getKafkaConsumerGroup(ctx, client, project, serviceName, groupID)
+	if common.IsCritical(err) {
+		return err
+	}
+	if err := kafkaConsumerGroupActiveError(group); err != nil {
+		return err
+	}
+
+	err = resetKafkaConsumerGroupOffsets(ctx, client, project, serviceName, groupID, req)
+	if err != nil {
+		return fmt.Errorf("unable to reset consumer group %q offsets: %w", groupID, err)
+	}
+	return nil
+}
+
+func kafkaConsumerGroupOffsetsResetFromSchema(d *schema.ResourceData) (*kafkaConsumerGroupOffsetsReset, error) {
+	req := &kafkaConsumerGroupOffsetsReset{
+		ResetTo: d.Get("reset_to").(string),
+		Topics:  schemautil.FlattenToString(d.Get("topics").(*schema.Set).List()),
+	}
+
+	if s := d.Get("timestamp").(string); s != "" {
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timestamp %q: %w", s, err)
+		}
+		ms := t.UnixMilli()
+		req.Timestamp = &ms
+	}
+
+	for _, v := range d.Get("offset").([]interface{}) {
+		o := v.(map[string]interface{})
+		req.Offsets = append(req.Offsets, &kafkaConsumerGroupOffset{
+			Topic:     o["topic"].(string),
+			Partition: o["partition"].(int),
+			Offset:    int64(o["offset"].(int)),
+		})
+	}
+	sortKafkaConsumerGroupOffsets(req.Offsets)
+	return req, nil
+}
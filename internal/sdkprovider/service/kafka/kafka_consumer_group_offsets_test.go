@@ -0,0 +1,106 @@
+package kafka_test
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	acc "github.com/aiven/terraform-provider-aiven/internal/acctest"
+)
+
+func TestAccAivenKafkaConsumerGroupOffsets_basic(t *testing.T) {
+	resourceName := "aiven_kafka_consumer_group_offsets.foo"
+	datasourceName := "data.aiven_kafka_consumer_groups.groups"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acc.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acc.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "aiven_kafka_consumer_group_offsets" "foo" {
+  project      = "test-acc-pr-1"
+  service_name = "test-acc-sr-1"
+  group_id     = "group-1"
+  reset_to     = "timestamp"
+}`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`timestamp` is required when `reset_to` is \"timestamp\""),
+			},
+			{
+				Config: testAccKafkaConsumerGroupOffsetsResource(rName, `
+  reset_to = "earliest"
+  topics   = [aiven_kafka_topic.foo.topic_name]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "project", os.Getenv("AIVEN_PROJECT_NAME")),
+					resource.TestCheckResourceAttr(resourceName, "service_name", fmt.Sprintf("test-acc-sr-%s", rName)),
+					resource.TestCheckResourceAttr(resourceName, "group_id", fmt.Sprintf("group-%s", rName)),
+					resource.TestCheckResourceAttr(resourceName, "reset_to", "earliest"),
+				),
+			},
+			{
+				Config: testAccKafkaConsumerGroupOffsetsResource(rName, `
+  reset_to = "offsets"
+
+  offset {
+    topic     = aiven_kafka_topic.foo.topic_name
+    partition = 0
+    offset    = 0
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "reset_to", "offsets"),
+					resource.TestCheckResourceAttr(resourceName, "committed_offsets.0.partition", "0"),
+					resource.TestCheckResourceAttr(resourceName, "committed_offsets.0.offset", "0"),
+					resource.TestCheckTypeSetElemNestedAttrs(datasourceName, "consumer_groups.*", map[string]string{
+						"group_id": fmt.Sprintf("group-%s", rName),
+						"state":    "Empty",
+						"lag":      "0",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccKafkaConsumerGroupOffsetsResource(name, reset string) string {
+	return fmt.Sprintf(`
+data "aiven_project" "foo" {
+  project = "%s"
+}
+
+resource "aiven_kafka" "bar" {
+  project                 = data.aiven_project.foo.project
+  cloud_name              = "google-europe-west1"
+  plan                    = "startup-2"
+  service_name            = "test-acc-sr-%s"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+}
+
+resource "aiven_kafka_topic" "foo" {
+  project      = aiven_kafka.bar.project
+  service_name = aiven_kafka.bar.service_name
+  topic_name   = "test-acc-topic-%s"
+  partitions   = 1
+  replication  = 2
+}
+
+resource "aiven_kafka_consumer_group_offsets" "foo" {
+  project      = aiven_kafka_topic.foo.project
+  service_name = aiven_kafka_topic.foo.service_name
+  group_id     = "group-%s"
+  %s
+}
+
+data "aiven_kafka_consumer_groups" "groups" {
+  project      = aiven_kafka_consumer_group_offsets.foo.project
+  service_name = aiven_kafka_consumer_group_offsets.foo.service_name
+
+  depends_on = [aiven_kafka_consumer_group_offsets.foo]
+}`, os.Getenv("AIVEN_PROJECT_NAME"), name, name, name, reset)
+}
@@ -0,0 +1,62 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKafkaConsumerGroupActiveError(t *testing.T) {
+	assert.NoError(t, kafkaConsumerGroupActiveError(nil))
+	assert.NoError(t, kafkaConsumerGroupActiveError(&kafkaConsumerGroup{GroupID: "foo", State: "Empty"}))
+	assert.NoError(t, kafkaConsumerGroupActiveError(&kafkaConsumerGroup{GroupID: "foo", State: "Dead"}))
+
+	err := kafkaConsumerGroupActiveError(&kafkaConsumerGroup{
+		GroupID: "foo",
+		State:   "Stable",
+		Members: []*kafkaConsumerGroupMember{{MemberID: "a"}},
+	})
+	assert.ErrorContains(t, err, `consumer group "foo" is active (state Stable, 1 members)`)
+
+	// Rebalancing group has no members assigned yet
+	assert.Error(t, kafkaConsumerGroupActiveError(&kafkaConsumerGroup{GroupID: "foo", State: "PreparingRebalance"}))
+}
+
+func TestKafkaConsumerGroupLag(t *testing.T) {
+	assert.Equal(t, int64(5), kafkaConsumerGroupLag(&kafkaConsumerGroupOffset{Offset: 10, EndOffset: 15}))
+	assert.Equal(t, int64(0), kafkaConsumerGroupLag(&kafkaConsumerGroupOffset{Offset: 15, EndOffset: 15}))
+	// No committed offset
+	assert.Equal(t, int64(0), kafkaConsumerGroupLag(&kafkaConsumerGroupOffset{Offset: -1, EndOffset: 15}))
+}
+
+func TestValidateKafkaConsumerGroupOffsetsReset(t *testing.T) {
+	cases := []struct {
+		name      string
+		resetTo   string
+		timestamp string
+		topics    int
+		offsets   int
+		err       string
+	}{
+		{name: "earliest", resetTo: "earliest", topics: 2},
+		{name: "latest", resetTo: "latest"},
+		{name: "timestamp", resetTo: "timestamp", timestamp: "2024-01-01T00:00:00Z"},
+		{name: "offsets", resetTo: "offsets", offsets: 1},
+		{name: "timestamp missing", resetTo: "timestamp", err: "`timestamp` is required"},
+		{name: "offsets missing", resetTo: "offsets", err: "`offset` is required"},
+		{name: "offsets with topics", resetTo: "offsets", offsets: 1, topics: 1, err: "can't be used"},
+		{name: "latest with offsets", resetTo: "latest", offsets: 1, err: "`offset` can be used only"},
+		{name: "earliest with timestamp", resetTo: "earliest", timestamp: "2024-01-01T00:00:00Z", err: "`timestamp` can be used only"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := validateKafkaConsumerGroupOffsetsReset(c.resetTo, c.timestamp, c.topics, c.offsets)
+			if c.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, c.err)
+			}
+		})
+	}
+}
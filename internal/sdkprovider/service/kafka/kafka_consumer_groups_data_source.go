@@ -0,0 +1,159 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+)
+
+func DatasourceKafkaConsumerGroups() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceKafkaConsumerGroupsRead,
+		Description: "The Kafka Consumer Groups data source lists the consumer groups of an Aiven Kafka service " +
+			"with their state, members and lag per partition.",
+		Schema: map[string]*schema.Schema{
+			"project":      schemautil.CommonSchemaProjectReference,
+			"service_name": schemautil.CommonSchemaServiceNameReference,
+			"consumer_groups": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of consumer groups.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"group_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The consumer group ID.",
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+							Description: "The consumer group state: `Empty`, `Stable`, `PreparingRebalance`, " +
+								"`CompletingRebalance` or `Dead`.",
+						},
+						"lag": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Total lag of the group, the sum of the partition lags.",
+						},
+						"members": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The consumer group members.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"member_id": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The member ID.",
+									},
+									"client_id": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The member client ID.",
+									},
+									"host": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The member host.",
+									},
+								},
+							},
+						},
+						"partitions": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Committed offsets and lag per partition.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"topic": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The topic name.",
+									},
+									"partition": {
+										Type:        schema.TypeInt,
+										Computed:    true,
+										Description: "The partition number.",
+									},
+									"offset": {
+										Type:        schema.TypeInt,
+										Computed:    true,
+										Description: "The committed offset.",
+									},
+									"end_offset": {
+										Type:        schema.TypeInt,
+										Computed:    true,
+										Description: "The latest offset of the partition.",
+									},
+									"lag": {
+										Type:        schema.TypeInt,
+										Computed:    true,
+										Description: "The number of messages the group is behind.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func datasourceKafkaConsumerGroupsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+
+	list, err := listKafkaConsumerGroups(ctx, m.(*aiven.Client), projectName, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(projectName, serviceName))
+	if err := d.Set("consumer_groups", flattenKafkaConsumerGroups(list)); err != nil {
+		return diag.Errorf("error setting Kafka `consumer_groups` for resource %s: %s", d.Id(), err)
+	}
+	return nil
+}
+
+func flattenKafkaConsumerGroups(list []*kafkaConsumerGroup) []map[string]interface{} {
+	groups := make([]map[string]interface{}, len(list))
+	for i, g := range list {
+		members := make([]map[string]interface{}, len(g.Members))
+		for j, v := range g.Members {
+			members[j] = map[string]interface{}{
+				"member_id": v.MemberID,
+				"client_id": v.ClientID,
+				"host":      v.Host,
+			}
+		}
+
+		var total int64
+		partitions := make([]map[string]interface{}, len(g.Offsets))
+		for j, o := range g.Offsets {
+			lag := kafkaConsumerGroupLag(o)
+			total += lag
+			partitions[j] = map[string]interface{}{
+				"topic":      o.Topic,
+				"partition":  o.Partition,
+				"offset":     int(o.Offset),
+				"end_offset": int(o.EndOffset),
+				"lag":        int(lag),
+			}
+		}
+
+		groups[i] = map[string]interface{}{
+			"group_id":   g.GroupID,
+			"state":      g.State,
+			"lag":        int(total),
+			"members":    members,
+			"partitions": partitions,
+		}
+	}
+	return groups
+}
@@ -0,0 +1,279 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/aiven/terraform-provider-aiven/internal/common"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil/userconfig"
+)
+
+var (
+	kafkaNativeACLResourceTypes = []string{"Topic", "Group", "Cluster", "TransactionalId"}
+	kafkaNativeACLPatternTypes  = []string{"LITERAL", "PREFIXED"}
+	kafkaNativeACLOperations    = []string{
+		"All", "Alter", "AlterConfigs", "ClusterAction", "Create", "Delete", "Describe", "DescribeConfigs",
+		"IdempotentWrite", "Read", "Write",
+	}
+	kafkaNativeACLPermissionTypes = []string{"ALLOW", "DENY"}
+	kafkaNativeACLPrincipalRe     = regexp.MustCompile(`^User:.+$`)
+)
+
+var aivenKafkaNativeACLSchema = map[string]*schema.Schema{
+	"project":      schemautil.CommonSchemaProjectReference,
+	"service_name": schemautil.CommonSchemaServiceNameReference,
+	"resource_type": {
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringInSlice(kafkaNativeACLResourceTypes, false),
+		Description:  userconfig.Desc("Kafka resource type.").ForceNew().PossibleValues(schemautil.StringSliceToInterfaceSlice(kafkaNativeACLResourceTypes)...).Build(),
+	},
+	"resource_name": {
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringLenBetween(1, 256),
+		Description:  userconfig.Desc("Kafka resource name. Use `kafka-cluster` for the `Cluster` resource type.").ForceNew().MaxLen(256).Build(),
+	},
+	"pattern_type": {
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringInSlice(kafkaNativeACLPatternTypes, false),
+		Description:  userconfig.Desc("Resource pattern type.").ForceNew().PossibleValues(schemautil.StringSliceToInterfaceSlice(kafkaNativeACLPatternTypes)...).Build(),
+	},
+	"principal": {
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringMatch(kafkaNativeACLPrincipalRe, "must be in the format User:<name>"),
+		Description:  userconfig.Desc("Principal in the format `User:<name>`. Use `User:*` to match any user.").ForceNew().Build(),
+	},
+	"host": {
+		Type:         schema.TypeString,
+		Optional:     true,
+		ForceNew:     true,
+		Default:      "*",
+		ValidateFunc: validation.StringLenBetween(1, 256),
+		Description:  userconfig.Desc("Host the principal connects from.").ForceNew().DefaultValue("*").Build(),
+	},
+	"operation": {
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringInSlice(kafkaNativeACLOperations, false),
+		Description:  userconfig.Desc("Kafka operation.").ForceNew().PossibleValues(schemautil.StringSliceToInterfaceSlice(kafkaNativeACLOperations)...).Build(),
+	},
+	"permission_type": {
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringInSlice(kafkaNativeACLPermissionTypes, false),
+		Description:  userconfig.Desc("Whether the operation is allowed or denied.").ForceNew().PossibleValues(schemautil.StringSliceToInterfaceSlice(kafkaNativeACLPermissionTypes)...).Build(),
+	},
+
+	// computed
+	"acl_id": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Kafka-native ACL ID",
+	},
+}
+
+func ResourceKafkaNativeACL() *schema.Resource {
+	return &schema.Resource{
+		Description:   "The Kafka Native ACL resource allows the creation and management of Kafka-native ACLs (resource type, pattern type, principal, host, operation and permission type) for an Aiven Kafka service.",
+		CreateContext: resourceKafkaNativeACLCreate,
+		ReadContext:   resourceKafkaNativeACLRead,
+		DeleteContext: resourceKafkaNativeACLDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: schemautil.DefaultResourceTimeouts(),
+
+		Schema: aivenKafkaNativeACLSchema,
+	}
+}
+
+func resourceKafkaNativeACLCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+
+	acl, err := createKafkaNativeACL(
+		ctx,
+		client,
+		project,
+		serviceName,
+		kafkaNativeACL{
+			ResourceType:   d.Get("resource_type").(string),
+			ResourceName:   d.Get("resource_name").(string),
+			PatternType:    d.Get("pattern_type").(string),
+			Principal:      d.Get("principal").(string),
+			Host:           d.Get("host").(string),
+			Operation:      d.Get("operation").(string),
+			PermissionType: d.Get("permission_type").(string),
+		},
+	)
+
+	// Invalidates even on error: the ACL might have been created anyway
+	singleNativeACLCache.invalidate(project, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(project, serviceName, acl.ID))
+
+	return resourceKafkaNativeACLRead(ctx, d, m)
+}
+
+func resourceKafkaNativeACLRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, aclID, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	acl, err := singleNativeACLCache.Read(ctx, project, serviceName, aclID, client)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	err = copyKafkaNativeACLPropertiesFromAPIResponseToTerraform(d, &acl, project, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceKafkaNativeACLDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, aclID, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Invalidates even on error: the ACL might have been deleted anyway
+	defer singleNativeACLCache.invalidate(project, serviceName)
+
+	err = deleteKafkaNativeACL(ctx, client, project, serviceName, aclID)
+	if common.IsCritical(err) {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func copyKafkaNativeACLPropertiesFromAPIResponseToTerraform(
+	d *schema.ResourceData,
+	acl *kafkaNativeACL,
+	project string,
+	serviceName string,
+) error {
+	if err := d.Set("project", project); err != nil {
+		return err
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return err
+	}
+	if err := d.Set("resource_type", acl.ResourceType); err != nil {
+		return err
+	}
+	if err := d.Set("resource_name", acl.ResourceName); err != nil {
+		return err
+	}
+	if err := d.Set("pattern_type", acl.PatternType); err != nil {
+		return err
+	}
+	if err := d.Set("principal", acl.Principal); err != nil {
+		return err
+	}
+	if err := d.Set("host", acl.Host); err != nil {
+		return err
+	}
+	if err := d.Set("operation", acl.Operation); err != nil {
+		return err
+	}
+	if err := d.Set("permission_type", acl.PermissionType); err != nil {
+		return err
+	}
+
+	return d.Set("acl_id", acl.ID)
+}
+
+// kafkaNativeACL Kafka-native ACL entry.
+// aiven.Client doesn't support these yet, see common.DoRequest
+type kafkaNativeACL struct {
+	ID             string `json:"id,omitempty"`
+	ResourceType   string `json:"resource_type"`
+	ResourceName   string `json:"resource_name"`
+	PatternType    string `json:"pattern_type"`
+	Principal      string `json:"principal"`
+	Host           string `json:"host"`
+	Operation      string `json:"operation"`
+	PermissionType string `json:"permission_type"`
+}
+
+// equals compares entries ignoring the ID
+func (a *kafkaNativeACL) equals(b *kafkaNativeACL) bool {
+	x, y := *a, *b
+	x.ID, y.ID = "", ""
+	return x == y
+}
+
+// kafkaNativeACLsResponse is returned by the list endpoint. "acl" holds Aiven ACLs, which are ignored here
+type kafkaNativeACLsResponse struct {
+	KafkaACL []*kafkaNativeACL `json:"kafka_acl"`
+}
+
+func kafkaNativeACLPath(project, serviceName string, parts ...string) string {
+	p := fmt.Sprintf("/project/%s/service/%s/kafka/acls", url.PathEscape(project), url.PathEscape(serviceName))
+	for _, v := range parts {
+		p += "/" + url.PathEscape(v)
+	}
+	return p
+}
+
+func createKafkaNativeACL(ctx context.Context, client *aiven.Client, project, serviceName string, req kafkaNativeACL) (*kafkaNativeACL, error) {
+	rsp := new(kafkaNativeACL)
+	err := common.DoRequest(ctx, client, http.MethodPost, kafkaNativeACLPath(project, serviceName), req, rsp)
+	if err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+func getKafkaNativeACL(ctx context.Context, client *aiven.Client, project, serviceName, aclID string) (*kafkaNativeACL, error) {
+	rsp := new(kafkaNativeACL)
+	err := common.DoRequest(ctx, client, http.MethodGet, kafkaNativeACLPath(project, serviceName, aclID), nil, rsp)
+	if err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+func listKafkaNativeACLs(ctx context.Context, client *aiven.Client, project, serviceName string) ([]*kafkaNativeACL, error) {
+	rsp := new(kafkaNativeACLsResponse)
+	err := common.DoRequest(ctx, client, http.MethodGet, kafkaNativeACLPath(project, serviceName), nil, rsp)
+	if err != nil {
+		return nil, err
+	}
+	return rsp.KafkaACL, nil
+}
+
+func deleteKafkaNativeACL(ctx context.Context, client *aiven.Client, project, serviceName, aclID string) error {
+	return common.DoRequest(ctx, client, http.MethodDelete, kafkaNativeACLPath(project, serviceName, aclID), nil, nil)
+}
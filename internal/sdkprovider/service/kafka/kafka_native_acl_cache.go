@@ -0,0 +1,88 @@
+package kafka
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/aiven/aiven-go-client/v2"
+
+	"github.com/aiven/terraform-provider-aiven/internal/sdkprovider/ttlcache"
+)
+
+// defaultNativeACLCacheTTL how long the listed Kafka-native ACLs of a service are considered fresh,
+// the same as for the ACLs of kafkaaclrepository
+const defaultNativeACLCacheTTL = 30 * time.Second
+
+// singleNativeACLCache the cache is shared by the resources of the provider process
+var singleNativeACLCache = newKafkaNativeACLCache(defaultNativeACLCacheTTL, time.Now)
+
+// kafkaNativeACLCache bulk-reads Kafka-native ACLs per service, so reading many ACLs takes a single list call.
+// The ACLs are cached for the ttl, and invalidated on create and delete.
+type kafkaNativeACLCache struct {
+	// services stores the ACLs by ID per service key
+	services *ttlcache.Cache[map[string]kafkaNativeACL]
+
+	// list and get call the API, replaced in unit tests
+	list func(ctx context.Context, client *aiven.Client, project, serviceName string) ([]*kafkaNativeACL, error)
+	get  func(ctx context.Context, client *aiven.Client, project, serviceName, aclID string) (*kafkaNativeACL, error)
+}
+
+func newKafkaNativeACLCache(ttl time.Duration, now func() time.Time) *kafkaNativeACLCache {
+	return &kafkaNativeACLCache{
+		services: ttlcache.New[map[string]kafkaNativeACL](ttl, now),
+		list:     listKafkaNativeACLs,
+		get:      getKafkaNativeACL,
+	}
+}
+
+// Read populates the cache if it is missing or expired, and reads the required acl.
+// Goes to the API on cache miss, so the result (or its aiven.Error) is always the remote state
+func (c *kafkaNativeACLCache) Read(
+	ctx context.Context,
+	project string,
+	service string,
+	aclID string,
+	client *aiven.Client,
+) (acl kafkaNativeACL, err error) {
+	acls, err := c.services.Get(ctx, ttlcache.Key(project, service), func(ctx context.Context) (map[string]kafkaNativeACL, error) {
+		return c.populate(ctx, project, service, client)
+	})
+	if err != nil {
+		return acl, err
+	}
+
+	acl, ok := acls[aclID]
+	if ok {
+		return acl, nil
+	}
+
+	// cache miss, the ACL might have been created after the list call
+	log.Printf("[DEBUG] cache miss on Kafka-native ACL %s, going live to Aiven API", aclID)
+	liveACL, err := c.get(ctx, client, project, service, aclID)
+	if err != nil {
+		return acl, err
+	}
+
+	return *liveACL, nil
+}
+
+// invalidate removes the service ACLs from the cache, so the next read gets the fresh state.
+// Must be called after an ACL of the service is created or deleted
+func (c *kafkaNativeACLCache) invalidate(project, service string) {
+	c.services.Invalidate(ttlcache.Key(project, service))
+}
+
+// populate makes a call to Aiven to list Kafka-native ACLs of the service
+func (c *kafkaNativeACLCache) populate(ctx context.Context, project, service string, client *aiven.Client) (map[string]kafkaNativeACL, error) {
+	list, err := c.list(ctx, client, project, service)
+	if err != nil {
+		return nil, err
+	}
+
+	acls := make(map[string]kafkaNativeACL, len(list))
+	for _, acl := range list {
+		acls[acl.ID] = *acl
+	}
+	return acls, nil
+}
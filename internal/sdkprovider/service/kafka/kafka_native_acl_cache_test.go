@@ -0,0 +1,68 @@
+package kafka
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKafkaNativeACLCache(t *testing.T) {
+	remote := map[string]*kafkaNativeACL{"a": {ID: "a", Principal: "User:a"}}
+	listCalled, getCalled := 0, 0
+
+	now := time.Now()
+	c := newKafkaNativeACLCache(time.Minute, func() time.Time { return now })
+	c.list = func(_ context.Context, _ *aiven.Client, _, _ string) ([]*kafkaNativeACL, error) {
+		listCalled++
+		list := make([]*kafkaNativeACL, 0, len(remote))
+		for _, acl := range remote {
+			list = append(list, acl)
+		}
+		return list, nil
+	}
+	c.get = func(_ context.Context, _ *aiven.Client, _, _, aclID string) (*kafkaNativeACL, error) {
+		getCalled++
+		if acl, ok := remote[aclID]; ok {
+			return acl, nil
+		}
+		return nil, aiven.Error{Status: http.StatusNotFound}
+	}
+
+	ctx := context.Background()
+	acl, err := c.Read(ctx, "p", "s", "a", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "User:a", acl.Principal)
+
+	// Served from the cache
+	_, err = c.Read(ctx, "p", "s", "a", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, listCalled)
+
+	// An ACL created by another process is read live
+	remote["b"] = &kafkaNativeACL{ID: "b", Principal: "User:b"}
+	acl, err = c.Read(ctx, "p", "s", "b", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "User:b", acl.Principal)
+	assert.Equal(t, 1, getCalled)
+
+	// A missing ACL is the API 404
+	_, err = c.Read(ctx, "p", "s", "c", nil)
+	assert.True(t, aiven.IsNotFound(err))
+
+	// Listed again after the invalidation and after the TTL
+	c.invalidate("p", "s")
+	_, err = c.Read(ctx, "p", "s", "b", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, listCalled)
+
+	now = now.Add(time.Minute)
+	_, err = c.Read(ctx, "p", "s", "b", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, listCalled)
+	assert.Equal(t, 2, getCalled)
+}
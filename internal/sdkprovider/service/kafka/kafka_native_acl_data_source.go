@@ -0,0 +1,62 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+)
+
+func DatasourceKafkaNativeACL() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceKafkaNativeACLRead,
+		Description: "The Kafka Native ACL data source provides information about the existing Kafka-native ACL for a Kafka service.",
+
+		Schema: datasourceKafkaNativeACLSchema(),
+	}
+}
+
+func datasourceKafkaNativeACLSchema() map[string]*schema.Schema {
+	s := schemautil.ResourceSchemaAsDatasourceSchema(aivenKafkaNativeACLSchema,
+		"project", "service_name", "resource_type", "resource_name", "pattern_type", "principal",
+		"operation", "permission_type")
+
+	// host is optional, the same as in the resource
+	s["host"].Computed = false
+	s["host"].Optional = true
+	s["host"].Default = aivenKafkaNativeACLSchema["host"].Default
+	return s
+}
+
+func datasourceKafkaNativeACLRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	expected := &kafkaNativeACL{
+		ResourceType:   d.Get("resource_type").(string),
+		ResourceName:   d.Get("resource_name").(string),
+		PatternType:    d.Get("pattern_type").(string),
+		Principal:      d.Get("principal").(string),
+		Host:           d.Get("host").(string),
+		Operation:      d.Get("operation").(string),
+		PermissionType: d.Get("permission_type").(string),
+	}
+
+	acls, err := listKafkaNativeACLs(ctx, client, projectName, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, acl := range acls {
+		if acl.equals(expected) {
+			d.SetId(schemautil.BuildResourceID(projectName, serviceName, acl.ID))
+			return resourceKafkaNativeACLRead(ctx, d, m)
+		}
+	}
+
+	return diag.Errorf("Kafka-native ACL %s %s %s/%s not found", expected.Principal, expected.Operation, expected.ResourceType, expected.ResourceName)
+}
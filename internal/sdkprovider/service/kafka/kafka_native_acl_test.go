@@ -0,0 +1,186 @@
+package kafka_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	acc "github.com/aiven/terraform-provider-aiven/internal/acctest"
+	"github.com/aiven/terraform-provider-aiven/internal/common"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+)
+
+func TestAccAivenKafkaNativeACL_basic(t *testing.T) {
+	resourceName := "aiven_kafka_native_acl.foo"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acc.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acc.TestProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAivenKafkaNativeACLResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccKafkaNativeACLInvalidResource("resource_type", "Broker"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("expected resource_type to be one of"),
+			},
+			{
+				Config:      testAccKafkaNativeACLInvalidResource("pattern_type", "MATCH"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("expected pattern_type to be one of"),
+			},
+			{
+				Config:      testAccKafkaNativeACLInvalidResource("principal", "alice"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("must be in the format User:<name>"),
+			},
+			{
+				Config:      testAccKafkaNativeACLInvalidResource("operation", "Produce"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("expected operation to be one of"),
+			},
+			{
+				Config:      testAccKafkaNativeACLInvalidResource("permission_type", "allow"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("expected permission_type to be one of"),
+			},
+			{
+				Config: testAccKafkaNativeACLResource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "project", os.Getenv("AIVEN_PROJECT_NAME")),
+					resource.TestCheckResourceAttr(resourceName, "service_name", fmt.Sprintf("test-acc-sr-%s", rName)),
+					resource.TestCheckResourceAttr(resourceName, "resource_type", "Topic"),
+					resource.TestCheckResourceAttr(resourceName, "resource_name", fmt.Sprintf("test-acc-topic-%s-", rName)),
+					resource.TestCheckResourceAttr(resourceName, "pattern_type", "PREFIXED"),
+					resource.TestCheckResourceAttr(resourceName, "principal", fmt.Sprintf("User:user-%s", rName)),
+					resource.TestCheckResourceAttr(resourceName, "host", "*"),
+					resource.TestCheckResourceAttr(resourceName, "operation", "Read"),
+					resource.TestCheckResourceAttr(resourceName, "permission_type", "ALLOW"),
+					resource.TestCheckResourceAttrSet(resourceName, "acl_id"),
+					resource.TestCheckResourceAttrPair(resourceName, "acl_id", "data.aiven_kafka_native_acl.acl", "acl_id"),
+					resource.TestCheckResourceAttr("aiven_kafka_native_acl.deny", "host", "10.0.0.1"),
+					resource.TestCheckResourceAttr("aiven_kafka_native_acl.deny", "permission_type", "DENY"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccKafkaNativeACLInvalidResource(key, value string) string {
+	values := map[string]string{
+		"resource_type":   "Topic",
+		"resource_name":   "test-acc-topic-1",
+		"pattern_type":    "LITERAL",
+		"principal":       "User:user-1",
+		"operation":       "Read",
+		"permission_type": "ALLOW",
+	}
+	values[key] = value
+	return fmt.Sprintf(`
+resource "aiven_kafka_native_acl" "foo" {
+  project         = "test-acc-pr-1"
+  service_name    = "test-acc-sr-1"
+  resource_type   = "%s"
+  resource_name   = "%s"
+  pattern_type    = "%s"
+  principal       = "%s"
+  operation       = "%s"
+  permission_type = "%s"
+}`, values["resource_type"], values["resource_name"], values["pattern_type"], values["principal"],
+		values["operation"], values["permission_type"])
+}
+
+func testAccKafkaNativeACLResource(name string) string {
+	return fmt.Sprintf(`
+data "aiven_project" "foo" {
+  project = "%s"
+}
+
+resource "aiven_kafka" "bar" {
+  project                 = data.aiven_project.foo.project
+  cloud_name              = "google-europe-west1"
+  plan                    = "startup-2"
+  service_name            = "test-acc-sr-%s"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+}
+
+resource "aiven_kafka_native_acl" "foo" {
+  project         = data.aiven_project.foo.project
+  service_name    = aiven_kafka.bar.service_name
+  resource_type   = "Topic"
+  resource_name   = "test-acc-topic-%s-"
+  pattern_type    = "PREFIXED"
+  principal       = "User:user-%s"
+  operation       = "Read"
+  permission_type = "ALLOW"
+}
+
+resource "aiven_kafka_native_acl" "deny" {
+  project         = data.aiven_project.foo.project
+  service_name    = aiven_kafka.bar.service_name
+  resource_type   = "Group"
+  resource_name   = "test-acc-group-%s"
+  pattern_type    = "LITERAL"
+  principal       = "User:user-%s"
+  host            = "10.0.0.1"
+  operation       = "Describe"
+  permission_type = "DENY"
+}
+
+data "aiven_kafka_native_acl" "acl" {
+  project         = aiven_kafka_native_acl.foo.project
+  service_name    = aiven_kafka_native_acl.foo.service_name
+  resource_type   = aiven_kafka_native_acl.foo.resource_type
+  resource_name   = aiven_kafka_native_acl.foo.resource_name
+  pattern_type    = aiven_kafka_native_acl.foo.pattern_type
+  principal       = aiven_kafka_native_acl.foo.principal
+  operation       = aiven_kafka_native_acl.foo.operation
+  permission_type = aiven_kafka_native_acl.foo.permission_type
+
+  depends_on = [aiven_kafka_native_acl.foo]
+}`, os.Getenv("AIVEN_PROJECT_NAME"), name, name, name, name, name)
+}
+
+func testAccCheckAivenKafkaNativeACLResourceDestroy(s *terraform.State) error {
+	c := acc.GetTestAivenClient()
+
+	ctx := context.Background()
+
+	// loop through the resources in state, verifying each kafka-native ACL is destroyed
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aiven_kafka_native_acl" {
+			continue
+		}
+
+		project, serviceName, aclID, err := schemautil.SplitResourceID3(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		path := fmt.Sprintf("/project/%s/service/%s/kafka/acls/%s", project, serviceName, aclID)
+		err = common.DoRequest(ctx, c, http.MethodGet, path, nil, nil)
+		if err == nil {
+			return fmt.Errorf("kafka-native ACL (%s) still exists", rs.Primary.ID)
+		}
+
+		if !aiven.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
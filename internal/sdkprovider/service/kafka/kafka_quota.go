@@ -0,0 +1,288 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/aiven/terraform-provider-aiven/internal/common"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil/userconfig"
+)
+
+// kafkaQuotaMaxByteRate the max value for consumer_byte_rate and producer_byte_rate, 1 GiB/s
+const kafkaQuotaMaxByteRate = 1073741824
+
+var aivenKafkaQuotaSchema = map[string]*schema.Schema{
+	"project":      schemautil.CommonSchemaProjectReference,
+	"service_name": schemautil.CommonSchemaServiceNameReference,
+	"user": {
+		Type:         schema.TypeString,
+		Optional:     true,
+		ForceNew:     true,
+		AtLeastOneOf: []string{"user", "client_id"},
+		ValidateFunc: validation.StringLenBetween(1, 64),
+		Description: userconfig.Desc("Kafka user the quota applies to. " +
+			"Use `<default>` to set the default quota for all users.").ForceNew().MaxLen(64).Build(),
+	},
+	"client_id": {
+		Type:         schema.TypeString,
+		Optional:     true,
+		ForceNew:     true,
+		AtLeastOneOf: []string{"user", "client_id"},
+		ValidateFunc: validation.StringLenBetween(1, 255),
+		Description: userconfig.Desc("Kafka client ID the quota applies to. " +
+			"Use `<default>` to set the default quota for all client IDs.").ForceNew().MaxLen(255).Build(),
+	},
+	"consumer_byte_rate": {
+		Type:         schema.TypeInt,
+		Optional:     true,
+		AtLeastOneOf: []string{"consumer_byte_rate", "producer_byte_rate", "request_percentage"},
+		ValidateFunc: validation.IntBetween(1, kafkaQuotaMaxByteRate),
+		Description:  "Consumer byte rate limit in bytes per second per broker. Removing the limit recreates the quota.",
+	},
+	"producer_byte_rate": {
+		Type:         schema.TypeInt,
+		Optional:     true,
+		AtLeastOneOf: []string{"consumer_byte_rate", "producer_byte_rate", "request_percentage"},
+		ValidateFunc: validation.IntBetween(1, kafkaQuotaMaxByteRate),
+		Description:  "Producer byte rate limit in bytes per second per broker. Removing the limit recreates the quota.",
+	},
+	"request_percentage": {
+		Type:         schema.TypeFloat,
+		Optional:     true,
+		AtLeastOneOf: []string{"consumer_byte_rate", "producer_byte_rate", "request_percentage"},
+		ValidateFunc: validation.FloatBetween(0, 100),
+		Description: "Percentage of the request handler and network threads time a client group can use " +
+			"in each quota window per broker. Removing the limit recreates the quota.",
+	},
+}
+
+func ResourceKafkaQuota() *schema.Resource {
+	return &schema.Resource{
+		Description:   "The Kafka Quota resource allows the creation and management of Kafka client quotas for an Aiven Kafka service.",
+		CreateContext: resourceKafkaQuotaCreate,
+		ReadContext:   resourceKafkaQuotaRead,
+		UpdateContext: resourceKafkaQuotaUpdate,
+		DeleteContext: resourceKafkaQuotaDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: schemautil.DefaultResourceTimeouts(),
+		// The upsert keeps the limits that are not sent, so a removed limit recreates the quota
+		CustomizeDiff: customdiff.All(
+			customdiff.ForceNewIf("consumer_byte_rate", kafkaQuotaLimitRemoved("consumer_byte_rate")),
+			customdiff.ForceNewIf("producer_byte_rate", kafkaQuotaLimitRemoved("producer_byte_rate")),
+			customdiff.ForceNewIf("request_percentage", kafkaQuotaLimitRemoved("request_percentage")),
+		),
+
+		Schema: aivenKafkaQuotaSchema,
+	}
+}
+
+func resourceKafkaQuotaCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	user := d.Get("user").(string)
+	clientID := d.Get("client_id").(string)
+
+	err := upsertKafkaQuota(ctx, client, project, serviceName, kafkaQuotaFromSchema(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(project, serviceName, user, clientID))
+
+	return resourceKafkaQuotaRead(ctx, d, m)
+}
+
+func resourceKafkaQuotaRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, user, clientID, err := schemautil.SplitResourceID4(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	quota, err := getKafkaQuota(ctx, client, project, serviceName, user, clientID)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	err = copyKafkaQuotaPropertiesFromAPIResponseToTerraform(d, quota, project, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceKafkaQuotaUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, _, _, err := schemautil.SplitResourceID4(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Quotas are upserted, so this is the same call as for create.
+	// The removed limits are not here, see kafkaQuotaLimitRemoved
+	err = upsertKafkaQuota(ctx, client, project, serviceName, kafkaQuotaFromSchema(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceKafkaQuotaRead(ctx, d, m)
+}
+
+func resourceKafkaQuotaDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project, serviceName, user, clientID, err := schemautil.SplitResourceID4(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = deleteKafkaQuota(ctx, client, project, serviceName, user, clientID)
+	if common.IsCritical(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// kafkaQuotaLimitRemoved returns true if the limit is removed from the config.
+// The API has no call to remove a single limit, so the quota is deleted and created again without it.
+func kafkaQuotaLimitRemoved(key string) customdiff.ResourceConditionFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, _ interface{}) bool {
+		if d.Id() == "" || !d.NewValueKnown(key) {
+			return false
+		}
+
+		o, n := d.GetChange(key)
+		return !isZeroLimit(o) && isZeroLimit(n)
+	}
+}
+
+// isZeroLimit an unset limit is read as zero, same as schemautil.OptionalIntPointer
+func isZeroLimit(v interface{}) bool {
+	switch v := v.(type) {
+	case int:
+		return v == 0
+	case float64:
+		return v == 0
+	}
+	return v == nil
+}
+
+func kafkaQuotaFromSchema(d *schema.ResourceData) kafkaQuota {
+	return kafkaQuota{
+		User:              d.Get("user").(string),
+		ClientID:          d.Get("client_id").(string),
+		ConsumerByteRate:  schemautil.OptionalIntPointer(d, "consumer_byte_rate"),
+		ProducerByteRate:  schemautil.OptionalIntPointer(d, "producer_byte_rate"),
+		RequestPercentage: schemautil.OptionalFloatPointer(d, "request_percentage"),
+	}
+}
+
+func copyKafkaQuotaPropertiesFromAPIResponseToTerraform(
+	d *schema.ResourceData,
+	quota *kafkaQuota,
+	project string,
+	serviceName string,
+) error {
+	if err := d.Set("project", project); err != nil {
+		return err
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return err
+	}
+	if err := d.Set("user", quota.User); err != nil {
+		return err
+	}
+	if err := d.Set("client_id", quota.ClientID); err != nil {
+		return err
+	}
+	if err := d.Set("consumer_byte_rate", quota.ConsumerByteRate); err != nil {
+		return err
+	}
+	if err := d.Set("producer_byte_rate", quota.ProducerByteRate); err != nil {
+		return err
+	}
+
+	return d.Set("request_percentage", quota.RequestPercentage)
+}
+
+// kafkaQuota Kafka client quota.
+// aiven.Client doesn't support these yet, see common.DoRequest
+type kafkaQuota struct {
+	User              string   `json:"user,omitempty"`
+	ClientID          string   `json:"client-id,omitempty"`
+	ConsumerByteRate  *int     `json:"consumer_byte_rate,omitempty"`
+	ProducerByteRate  *int     `json:"producer_byte_rate,omitempty"`
+	RequestPercentage *float64 `json:"request_percentage,omitempty"`
+}
+
+type kafkaQuotaResponse struct {
+	Quota *kafkaQuota `json:"quota"`
+}
+
+type kafkaQuotaListResponse struct {
+	Quotas []*kafkaQuota `json:"quotas"`
+}
+
+// kafkaQuotaPath returns the quota endpoint, user and client-id are query parameters
+func kafkaQuotaPath(project, serviceName, suffix, user, clientID string) string {
+	p := fmt.Sprintf("/project/%s/service/%s/quota%s", url.PathEscape(project), url.PathEscape(serviceName), suffix)
+	q := url.Values{}
+	if user != "" {
+		q.Set("user", user)
+	}
+	if clientID != "" {
+		q.Set("client-id", clientID)
+	}
+	if len(q) > 0 {
+		p += "?" + q.Encode()
+	}
+	return p
+}
+
+func upsertKafkaQuota(ctx context.Context, client *aiven.Client, project, serviceName string, req kafkaQuota) error {
+	return common.DoRequest(ctx, client, http.MethodPost, kafkaQuotaPath(project, serviceName, "", "", ""), req, nil)
+}
+
+func getKafkaQuota(ctx context.Context, client *aiven.Client, project, serviceName, user, clientID string) (*kafkaQuota, error) {
+	rsp := new(kafkaQuotaResponse)
+	err := common.DoRequest(ctx, client, http.MethodGet, kafkaQuotaPath(project, serviceName, "/describe", user, clientID), nil, rsp)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.Quota == nil {
+		return nil, aiven.Error{Status: http.StatusNotFound, Message: "Kafka quota not found"}
+	}
+
+	return rsp.Quota, nil
+}
+
+func listKafkaQuotas(ctx context.Context, client *aiven.Client, project, serviceName string) ([]*kafkaQuota, error) {
+	rsp := new(kafkaQuotaListResponse)
+	err := common.DoRequest(ctx, client, http.MethodGet, kafkaQuotaPath(project, serviceName, "", "", ""), nil, rsp)
+	if err != nil {
+		return nil, err
+	}
+	return rsp.Quotas, nil
+}
+
+func deleteKafkaQuota(ctx context.Context, client *aiven.Client, project, serviceName, user, clientID string) error {
+	return common.DoRequest(ctx, client, http.MethodDelete, kafkaQuotaPath(project, serviceName, "", user, clientID), nil, nil)
+}
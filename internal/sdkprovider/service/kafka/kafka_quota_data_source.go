@@ -0,0 +1,55 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+)
+
+func DatasourceKafkaQuota() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceKafkaQuotaRead,
+		Description: "The Kafka Quota data source provides information about the existing Kafka client quota for a Kafka service.",
+
+		Schema: datasourceKafkaQuotaSchema(),
+	}
+}
+
+func datasourceKafkaQuotaSchema() map[string]*schema.Schema {
+	s := schemautil.ResourceSchemaAsDatasourceSchema(aivenKafkaQuotaSchema, "project", "service_name")
+
+	// The quota is selected by user and/or client_id, the same as in the resource
+	for _, k := range []string{"user", "client_id"} {
+		s[k].Computed = false
+		s[k].Optional = true
+		s[k].AtLeastOneOf = []string{"user", "client_id"}
+	}
+	return s
+}
+
+func datasourceKafkaQuotaRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	user := d.Get("user").(string)
+	clientID := d.Get("client_id").(string)
+
+	quotas, err := listKafkaQuotas(ctx, client, projectName, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, q := range quotas {
+		if q.User == user && q.ClientID == clientID {
+			d.SetId(schemautil.BuildResourceID(projectName, serviceName, user, clientID))
+			return resourceKafkaQuotaRead(ctx, d, m)
+		}
+	}
+
+	return diag.Errorf("Kafka quota for user %q and client_id %q not found", user, clientID)
+}
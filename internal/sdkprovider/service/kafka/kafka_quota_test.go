@@ -0,0 +1,162 @@
+package kafka_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	acc "github.com/aiven/terraform-provider-aiven/internal/acctest"
+	"github.com/aiven/terraform-provider-aiven/internal/common"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+)
+
+func TestAccAivenKafkaQuota_basic(t *testing.T) {
+	resourceName := "aiven_kafka_quota.foo"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acc.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acc.TestProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAivenKafkaQuotaResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "aiven_kafka_quota" "foo" {
+  project            = "test-acc-pr-1"
+  service_name       = "test-acc-sr-1"
+  consumer_byte_rate = 1024
+}`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`one of\s+.client_id,user. must be specified`),
+			},
+			{
+				Config: `
+resource "aiven_kafka_quota" "foo" {
+  project      = "test-acc-pr-1"
+  service_name = "test-acc-sr-1"
+  user         = "user-1"
+}`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`one of\s+.consumer_byte_rate,producer_byte_rate,request_percentage. must be`),
+			},
+			{
+				Config: testAccKafkaQuotaResource(rName, 1048576, "request_percentage = 25"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "project", os.Getenv("AIVEN_PROJECT_NAME")),
+					resource.TestCheckResourceAttr(resourceName, "service_name", fmt.Sprintf("test-acc-sr-%s", rName)),
+					resource.TestCheckResourceAttr(resourceName, "user", fmt.Sprintf("user-%s", rName)),
+					resource.TestCheckResourceAttr(resourceName, "client_id", fmt.Sprintf("client-%s", rName)),
+					resource.TestCheckResourceAttr(resourceName, "consumer_byte_rate", "1048576"),
+					resource.TestCheckResourceAttr(resourceName, "request_percentage", "25"),
+					resource.TestCheckResourceAttr("data.aiven_kafka_quota.quota", "consumer_byte_rate", "1048576"),
+				),
+			},
+			{
+				// Updates the limit in place
+				Config: testAccKafkaQuotaResource(rName, 2097152, "request_percentage = 25"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "consumer_byte_rate", "2097152"),
+					resource.TestCheckResourceAttr(resourceName, "request_percentage", "25"),
+				),
+			},
+			{
+				// A removed limit recreates the quota
+				Config: testAccKafkaQuotaResource(rName, 2097152, ""),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "consumer_byte_rate", "2097152"),
+					resource.TestCheckNoResourceAttr(resourceName, "request_percentage"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccKafkaQuotaResource(name string, consumerByteRate int, extra string) string {
+	return fmt.Sprintf(`
+data "aiven_project" "foo" {
+  project = "%s"
+}
+
+resource "aiven_kafka" "bar" {
+  project                 = data.aiven_project.foo.project
+  cloud_name              = "google-europe-west1"
+  plan                    = "startup-2"
+  service_name            = "test-acc-sr-%s"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+}
+
+resource "aiven_kafka_quota" "foo" {
+  project            = data.aiven_project.foo.project
+  service_name       = aiven_kafka.bar.service_name
+  user               = "user-%s"
+  client_id          = "client-%s"
+  consumer_byte_rate = %d
+  %s
+}
+
+data "aiven_kafka_quota" "quota" {
+  project      = aiven_kafka_quota.foo.project
+  service_name = aiven_kafka_quota.foo.service_name
+  user         = aiven_kafka_quota.foo.user
+  client_id    = aiven_kafka_quota.foo.client_id
+
+  depends_on = [aiven_kafka_quota.foo]
+}`, os.Getenv("AIVEN_PROJECT_NAME"), name, name, name, consumerByteRate, extra)
+}
+
+func testAccCheckAivenKafkaQuotaResourceDestroy(s *terraform.State) error {
+	c := acc.GetTestAivenClient()
+
+	ctx := context.Background()
+
+	// loop through the resources in state, verifying each kafka quota is destroyed
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aiven_kafka_quota" {
+			continue
+		}
+
+		project, serviceName, user, clientID, err := schemautil.SplitResourceID4(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		q := url.Values{"user": {user}, "client-id": {clientID}}
+		path := fmt.Sprintf("/project/%s/service/%s/quota/describe?%s", project, serviceName, q.Encode())
+		err = common.DoRequest(ctx, c, http.MethodGet, path, nil, nil)
+		if err == nil {
+			return fmt.Errorf("kafka quota (%s) still exists", rs.Primary.ID)
+		}
+
+		if !aiven.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
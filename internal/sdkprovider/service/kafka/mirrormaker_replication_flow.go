@@ -2,6 +2,7 @@ package kafka
 
 import (
 	"context"
+	"log"
 	"sync"
 
 	"github.com/aiven/aiven-go-client/v2"
@@ -51,8 +52,9 @@ var aivenMirrorMakerReplicationFlowSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Description: "List of topics and/or regular expressions to replicate",
 		Elem: &schema.Schema{
-			Type:     schema.TypeString,
-			MaxItems: 256,
+			Type:         schema.TypeString,
+			MaxItems:     256,
+			ValidateFunc: validateReplicationFlowTopicPattern,
 		},
 	},
 	"topics_blacklist": {
@@ -60,8 +62,9 @@ var aivenMirrorMakerReplicationFlowSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Description: "List of topics and/or regular expressions to not replicate.",
 		Elem: &schema.Schema{
-			Type:     schema.TypeString,
-			MaxItems: 256,
+			Type:         schema.TypeString,
+			MaxItems:     256,
+			ValidateFunc: validateReplicationFlowTopicPattern,
 		},
 	},
 	"replication_policy_class": {
@@ -106,6 +109,14 @@ var aivenMirrorMakerReplicationFlowSchema = map[string]*schema.Schema{
 		Description:  "Offset syncs topic location.",
 		ValidateFunc: validation.StringInSlice([]string{"source", "target"}, false),
 	},
+	"matched_topics": {
+		Type:     schema.TypeList,
+		Computed: true,
+		Description: "Source cluster topics that currently match `topics` and not `topics_blacklist`. " +
+			"Patterns with Java-only regular expression syntax, like lookarounds, are not evaluated. " +
+			"The source cluster topics are cached for 30 seconds, so a topic created in the same apply might be missing until the next refresh.",
+		Elem: &schema.Schema{Type: schema.TypeString},
+	},
 }
 
 func ResourceMirrorMakerReplicationFlow() *schema.Resource {
@@ -120,14 +131,41 @@ func ResourceMirrorMakerReplicationFlow() *schema.Resource {
 		},
 		Timeouts: schemautil.DefaultResourceTimeouts(),
 
-		Schema: aivenMirrorMakerReplicationFlowSchema,
+		Schema:        aivenMirrorMakerReplicationFlowSchema,
+		CustomizeDiff: resourceMirrorMakerReplicationFlowCustomizeDiff,
 	}
 }
 
-func resourceMirrorMakerReplicationFlowCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	lockReplicationFlow.Lock()
-	defer lockReplicationFlow.Unlock()
+// resourceMirrorMakerReplicationFlowCustomizeDiff a flow with an unknown alias is created, but doesn't replicate anything.
+// Skipped if the values are not known yet, or the service has no integrations yet, e.g. those are created in the same apply
+func resourceMirrorMakerReplicationFlowCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" {
+		return nil
+	}
+
+	for _, k := range []string{"project", "service_name", "source_cluster", "target_cluster"} {
+		if !d.NewValueKnown(k) {
+			return nil
+		}
+	}
+
+	serviceName := d.Get("service_name").(string)
+	found, err := mirrorMakerClusterAliases(ctx, m.(*aiven.Client), d.Get("project").(string), serviceName)
+	if err != nil {
+		if aiven.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	if len(found) == 0 {
+		return nil
+	}
 
+	return checkMirrorMakerClusterAliases(found, serviceName, d.Get("source_cluster").(string), d.Get("target_cluster").(string))
+}
+
+func resourceMirrorMakerReplicationFlowCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*aiven.Client)
 
 	project := d.Get("project").(string)
@@ -136,6 +174,9 @@ func resourceMirrorMakerReplicationFlowCreate(ctx context.Context, d *schema.Res
 	sourceCluster := d.Get("source_cluster").(string)
 	targetCluster := d.Get("target_cluster").(string)
 
+	lockReplicationFlow.Lock()
+	defer lockReplicationFlow.Unlock()
+
 	err := client.KafkaMirrorMakerReplicationFlow.Create(ctx, project, serviceName, aiven.MirrorMakerReplicationFlowRequest{
 		ReplicationFlow: aiven.ReplicationFlow{
 			Enabled:                         enable,
@@ -213,6 +254,25 @@ func resourceMirrorMakerReplicationFlowRead(ctx context.Context, d *schema.Resou
 		return diag.FromErr(err)
 	}
 
+	// The preview is informational, it shouldn't break the read.
+	// Every flow of the service is refreshed, so the integrations and the topics are cached
+	listTopics := func(ctx context.Context, project, serviceName string) ([]string, error) {
+		return singleMirrorMakerPreviewCache.Topics(ctx, client, project, serviceName)
+	}
+	matched := make([]string, 0)
+	aliases, err := singleMirrorMakerPreviewCache.Aliases(ctx, client, project, serviceName)
+	if err == nil {
+		flow := replicationFlow.ReplicationFlow
+		flow.SourceCluster = sourceCluster
+		matched, err = mirrorMakerFlowMatchedTopics(ctx, listTopics, project, aliases, make(map[string][]string), &flow)
+	}
+	if err != nil {
+		log.Printf("[WARN] unable to list MirrorMaker replication flow %s matched topics: %s", d.Id(), err)
+	}
+	if err := d.Set("matched_topics", matched); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return nil
 }
 
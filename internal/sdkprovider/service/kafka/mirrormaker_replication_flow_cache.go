@@ -0,0 +1,58 @@
+package kafka
+
+import (
+	"context"
+	"time"
+
+	"github.com/aiven/aiven-go-client/v2"
+
+	"github.com/aiven/terraform-provider-aiven/internal/sdkprovider/ttlcache"
+)
+
+// defaultMirrorMakerPreviewCacheTTL how long the integrations and the topics listed for the matched_topics preview
+// are reused, the same as for the ACLs of kafkaaclrepository
+const defaultMirrorMakerPreviewCacheTTL = 30 * time.Second
+
+// singleMirrorMakerPreviewCache the cache is shared by the replication flows of the provider process
+var singleMirrorMakerPreviewCache = newMirrorMakerPreviewCache(defaultMirrorMakerPreviewCacheTTL, time.Now)
+
+// mirrorMakerPreviewCache caches the cluster aliases of MirrorMaker services and the topics of the source services,
+// so refreshing many replication flows lists those once per service instead of once per flow.
+// The preview is informational, so the values are not invalidated, only expired after the ttl
+type mirrorMakerPreviewCache struct {
+	aliases *ttlcache.Cache[map[string]*aiven.ServiceIntegration]
+	topics  *ttlcache.Cache[[]string]
+
+	// listAliases and listTopics call the API, replaced in unit tests
+	listAliases func(ctx context.Context, client *aiven.Client, project, serviceName string) (map[string]*aiven.ServiceIntegration, error)
+	listTopics  func(ctx context.Context, client *aiven.Client, project, serviceName string) ([]string, error)
+}
+
+func newMirrorMakerPreviewCache(ttl time.Duration, now func() time.Time) *mirrorMakerPreviewCache {
+	return &mirrorMakerPreviewCache{
+		aliases:     ttlcache.New[map[string]*aiven.ServiceIntegration](ttl, now),
+		topics:      ttlcache.New[[]string](ttl, now),
+		listAliases: mirrorMakerClusterAliases,
+		listTopics:  listKafkaTopicNames,
+	}
+}
+
+// Aliases returns the kafka_mirrormaker integrations of the MirrorMaker service by cluster alias
+func (c *mirrorMakerPreviewCache) Aliases(
+	ctx context.Context,
+	client *aiven.Client,
+	project, serviceName string,
+) (map[string]*aiven.ServiceIntegration, error) {
+	key := ttlcache.Key(project, serviceName)
+	return c.aliases.Get(ctx, key, func(ctx context.Context) (map[string]*aiven.ServiceIntegration, error) {
+		return c.listAliases(ctx, client, project, serviceName)
+	})
+}
+
+// Topics returns the topic names of the Kafka service
+func (c *mirrorMakerPreviewCache) Topics(ctx context.Context, client *aiven.Client, project, serviceName string) ([]string, error) {
+	key := ttlcache.Key(project, serviceName)
+	return c.topics.Get(ctx, key, func(ctx context.Context) ([]string, error) {
+		return c.listTopics(ctx, client, project, serviceName)
+	})
+}
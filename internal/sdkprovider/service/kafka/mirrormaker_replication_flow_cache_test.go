@@ -0,0 +1,53 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMirrorMakerPreviewCache(t *testing.T) {
+	aliasesCalled, topicsCalled := 0, 0
+
+	now := time.Now()
+	c := newMirrorMakerPreviewCache(time.Minute, func() time.Time { return now })
+	c.listAliases = func(_ context.Context, _ *aiven.Client, _, _ string) (map[string]*aiven.ServiceIntegration, error) {
+		aliasesCalled++
+		return map[string]*aiven.ServiceIntegration{"source": {}}, nil
+	}
+	c.listTopics = func(_ context.Context, _ *aiven.Client, _, serviceName string) ([]string, error) {
+		topicsCalled++
+		return []string{serviceName + "-orders"}, nil
+	}
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		aliases, err := c.Aliases(ctx, nil, "p", "mm")
+		require.NoError(t, err)
+		assert.Contains(t, aliases, "source")
+
+		topics, err := c.Topics(ctx, nil, "p", "kafka")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"kafka-orders"}, topics)
+	}
+	assert.Equal(t, 1, aliasesCalled)
+	assert.Equal(t, 1, topicsCalled)
+
+	// Another service is listed separately
+	_, err := c.Topics(ctx, nil, "p", "kafka2")
+	require.NoError(t, err)
+	assert.Equal(t, 2, topicsCalled)
+
+	// Listed again after the TTL
+	now = now.Add(time.Minute)
+	_, err = c.Aliases(ctx, nil, "p", "mm")
+	require.NoError(t, err)
+	_, err = c.Topics(ctx, nil, "p", "kafka")
+	require.NoError(t, err)
+	assert.Equal(t, 2, aliasesCalled)
+	assert.Equal(t, 3, topicsCalled)
+}
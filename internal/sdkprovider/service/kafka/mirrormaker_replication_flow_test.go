@@ -36,6 +36,14 @@ func TestAccAivenMirrorMakerReplicationFlow_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "target_cluster", "target"),
 					resource.TestCheckResourceAttr(resourceName, "enable", "true"),
 					resource.TestCheckResourceAttr(resourceName, "offset_syncs_topic_location", "source"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "matched_topics.*", fmt.Sprintf("test-acc-topic-a-%s", rName)),
+					resource.TestCheckResourceAttr("data.aiven_mirrormaker_replication_flows.flows", "replication_flows.#", "1"),
+					resource.TestCheckResourceAttr("data.aiven_mirrormaker_replication_flows.flows", "replication_flows.0.source_cluster", "source"),
+					resource.TestCheckTypeSetElemAttr(
+						"data.aiven_mirrormaker_replication_flows.flows",
+						"replication_flows.0.matched_topics.*",
+						fmt.Sprintf("test-acc-topic-a-%s", rName),
+					),
 				),
 			},
 		},
@@ -201,6 +209,8 @@ resource "aiven_mirrormaker_replication_flow" "foo" {
     ".*\\.replica",
     "__.*"
   ]
+
+  depends_on = [aiven_kafka_topic.source]
 }
 
 data "aiven_mirrormaker_replication_flow" "flow" {
@@ -210,6 +220,13 @@ data "aiven_mirrormaker_replication_flow" "flow" {
   target_cluster = aiven_mirrormaker_replication_flow.foo.target_cluster
 
   depends_on = [aiven_mirrormaker_replication_flow.foo]
+}
+
+data "aiven_mirrormaker_replication_flows" "flows" {
+  project      = data.aiven_project.foo.project
+  service_name = aiven_kafka_mirrormaker.mm.service_name
+
+  depends_on = [aiven_mirrormaker_replication_flow.foo, aiven_kafka_topic.source]
 }`, os.Getenv("AIVEN_PROJECT_NAME"), name, name, name, name, name)
 }
 
@@ -269,3 +286,27 @@ resource "aiven_mirrormaker_replication_flow" "foo" {
 		},
 	})
 }
+
+func TestAccAivenMirrorMakerReplicationFlow_invalid_topics(t *testing.T) {
+	config := `
+resource "aiven_mirrormaker_replication_flow" "foo" {
+  project        = "foo"
+  service_name   = "foo"
+  source_cluster = "source"
+  target_cluster = "target"
+  enable         = true
+  topics         = ["orders-["]
+}
+`
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acc.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acc.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`invalid regular expression "orders-\[": missing closing ]`),
+			},
+		},
+	})
+}
@@ -0,0 +1,189 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"regexp/syntax"
+	"sort"
+	"strings"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"golang.org/x/exp/maps"
+)
+
+// replicationFlowDefaultTopicsBlacklist MirrorMaker 2 default topics.exclude, used when topics_blacklist is empty
+var replicationFlowDefaultTopicsBlacklist = []string{`.*[\-\.]internal`, `.*\.replica`, `__.*`}
+
+// compileReplicationFlowTopicPattern compiles the pattern as MirrorMaker does, it must match the whole topic name.
+// MirrorMaker uses Java regular expressions, returns nil pattern and no error
+// for the Java syntax Go doesn't support, like lookarounds, possessive quantifiers and backreferences.
+func compileReplicationFlowTopicPattern(p string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile("^(?:" + p + ")$")
+	if err == nil {
+		return re, nil
+	}
+
+	var e *syntax.Error
+	if errors.As(err, &e) {
+		switch e.Code {
+		case syntax.ErrInvalidPerlOp, syntax.ErrInvalidRepeatOp, syntax.ErrInvalidEscape:
+			return nil, nil
+		}
+		return nil, fmt.Errorf("invalid regular expression %q: %s", p, e.Code)
+	}
+	return nil, fmt.Errorf("invalid regular expression %q: %w", p, err)
+}
+
+// validateReplicationFlowTopicPattern a schema.SchemaValidateFunc for topics and topics_blacklist
+func validateReplicationFlowTopicPattern(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	if strings.TrimSpace(v) == "" {
+		return nil, []error{fmt.Errorf("%s: topic pattern can't be empty", k)}
+	}
+
+	if _, err := compileReplicationFlowTopicPattern(v); err != nil {
+		return nil, []error{fmt.Errorf("%s: %w", k, err)}
+	}
+	return nil, nil
+}
+
+// replicationFlowMatchedTopics returns the topics that match any of the topics patterns and none of the blacklist.
+// Patterns that can't be evaluated in Go are skipped.
+func replicationFlowMatchedTopics(topics, include, exclude []string) []string {
+	if len(exclude) == 0 {
+		exclude = replicationFlowDefaultTopicsBlacklist
+	}
+
+	includeRe := compileReplicationFlowTopicPatterns(include)
+	excludeRe := compileReplicationFlowTopicPatterns(exclude)
+
+	matched := make([]string, 0)
+	for _, t := range topics {
+		if matchAnyPattern(includeRe, t) && !matchAnyPattern(excludeRe, t) {
+			matched = append(matched, t)
+		}
+	}
+	sort.Strings(matched)
+	return matched
+}
+
+func compileReplicationFlowTopicPatterns(patterns []string) []*regexp.Regexp {
+	result := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		re, err := compileReplicationFlowTopicPattern(p)
+		if err != nil || re == nil {
+			log.Printf("[DEBUG] skipping MirrorMaker topic pattern %q in the preview: %v", p, err)
+			continue
+		}
+		result = append(result, re)
+	}
+	return result
+}
+
+func matchAnyPattern(patterns []*regexp.Regexp, s string) bool {
+	for _, re := range patterns {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// mirrorMakerClusterAliases returns kafka_mirrormaker integrations of the MirrorMaker service by cluster alias
+func mirrorMakerClusterAliases(
+	ctx context.Context,
+	client *aiven.Client,
+	project, serviceName string,
+) (map[string]*aiven.ServiceIntegration, error) {
+	list, err := client.ServiceIntegrations.List(ctx, project, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	aliases := make(map[string]*aiven.ServiceIntegration)
+	for _, i := range list {
+		if i.IntegrationType != "kafka_mirrormaker" || i.DestinationService == nil || *i.DestinationService != serviceName {
+			continue
+		}
+		if alias, ok := i.UserConfig["cluster_alias"].(string); ok && alias != "" {
+			aliases[alias] = i
+		}
+	}
+	return aliases, nil
+}
+
+// checkMirrorMakerClusterAliases checks that every alias has a kafka_mirrormaker integration on the service
+func checkMirrorMakerClusterAliases(found map[string]*aiven.ServiceIntegration, serviceName string, aliases ...string) error {
+	missing := make([]string, 0)
+	for _, a := range aliases {
+		if _, ok := found[a]; !ok {
+			missing = append(missing, a)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	available := maps.Keys(found)
+	sort.Strings(available)
+	return fmt.Errorf(
+		"cluster alias %s doesn't match any kafka_mirrormaker integration of service %s, available aliases: [%s]",
+		strings.Join(missing, ", "), serviceName, strings.Join(available, ", "),
+	)
+}
+
+// listKafkaTopicNames returns the topic names of the Kafka service
+func listKafkaTopicNames(ctx context.Context, client *aiven.Client, project, serviceName string) ([]string, error) {
+	list, err := client.KafkaTopics.List(ctx, project, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	topics := make([]string, len(list))
+	for i, t := range list {
+		topics[i] = t.TopicName
+	}
+	return topics, nil
+}
+
+// mirrorMakerFlowMatchedTopics returns the topics of the source cluster the flow currently replicates.
+// topicsByAlias caches the source cluster topics, so flows with the same source list topics once.
+// listTopics is listKafkaTopicNames or the cached one
+func mirrorMakerFlowMatchedTopics(
+	ctx context.Context,
+	listTopics func(ctx context.Context, project, serviceName string) ([]string, error),
+	project string,
+	aliases map[string]*aiven.ServiceIntegration,
+	topicsByAlias map[string][]string,
+	flow *aiven.ReplicationFlow,
+) ([]string, error) {
+	topics, ok := topicsByAlias[flow.SourceCluster]
+	if !ok {
+		integration, ok := aliases[flow.SourceCluster]
+		if !ok || integration.SourceService == nil {
+			return nil, fmt.Errorf("cluster alias %q not found", flow.SourceCluster)
+		}
+
+		sourceProject := project
+		if integration.SourceProject != nil && *integration.SourceProject != "" {
+			sourceProject = *integration.SourceProject
+		}
+
+		list, err := listTopics(ctx, sourceProject, *integration.SourceService)
+		if err != nil {
+			return nil, err
+		}
+
+		topics = list
+		topicsByAlias[flow.SourceCluster] = topics
+	}
+
+	return replicationFlowMatchedTopics(topics, flow.Topics, flow.TopicsBlacklist), nil
+}
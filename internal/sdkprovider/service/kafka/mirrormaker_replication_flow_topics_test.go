@@ -0,0 +1,72 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateReplicationFlowTopicPattern(t *testing.T) {
+	cases := []struct {
+		pattern string
+		err     string
+	}{
+		{pattern: "orders"},
+		{pattern: `.*[\-\.]internal`},
+		{pattern: "orders-(eu|us)\\..*"},
+		// Java-only syntax is not validated
+		{pattern: "(?!internal).*"},
+		{pattern: "a*+"},
+		{pattern: "", err: "topic pattern can't be empty"},
+		{pattern: "orders-[", err: `invalid regular expression "orders-[": missing closing ]`},
+		{pattern: "(orders", err: "missing closing )"},
+		{pattern: "*orders", err: "missing argument to repetition operator"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.pattern, func(t *testing.T) {
+			_, errs := validateReplicationFlowTopicPattern(c.pattern, "topics.0")
+			if c.err == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			require.Len(t, errs, 1)
+			assert.ErrorContains(t, errs[0], c.err)
+		})
+	}
+}
+
+func TestReplicationFlowMatchedTopics(t *testing.T) {
+	topics := []string{"orders", "orders-eu", "payments", "__consumer_offsets", "connect-status.internal", "orders.replica"}
+
+	assert.Equal(t,
+		[]string{"orders", "orders-eu", "payments"},
+		replicationFlowMatchedTopics(topics, []string{".*"}, nil),
+	)
+
+	// The pattern must match the whole name
+	assert.Equal(t,
+		[]string{"orders"},
+		replicationFlowMatchedTopics(topics, []string{"orders"}, nil),
+	)
+
+	// Blacklist replaces the defaults
+	assert.Equal(t,
+		[]string{"__consumer_offsets", "orders", "orders-eu", "orders.replica"},
+		replicationFlowMatchedTopics(topics, []string{"orders.*", "__.*"}, []string{"payments"}),
+	)
+
+	// Java-only patterns are skipped
+	assert.Empty(t, replicationFlowMatchedTopics(topics, []string{"(?!orders).*"}, nil))
+}
+
+func TestCheckMirrorMakerClusterAliases(t *testing.T) {
+	found := map[string]*aiven.ServiceIntegration{"source": {}, "target": {}}
+	assert.NoError(t, checkMirrorMakerClusterAliases(found, "mm", "source", "target"))
+	assert.EqualError(t,
+		checkMirrorMakerClusterAliases(found, "mm", "sorce", "target"),
+		"cluster alias sorce doesn't match any kafka_mirrormaker integration of service mm, available aliases: [source, target]",
+	)
+}
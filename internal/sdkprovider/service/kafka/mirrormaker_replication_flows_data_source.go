@@ -0,0 +1,84 @@
+package kafka
+
+import (
+	"context"
+	"log"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+)
+
+func DatasourceMirrorMakerReplicationFlows() *schema.Resource {
+	flow := schemautil.ResourceSchemaAsDatasourceSchema(aivenMirrorMakerReplicationFlowSchema)
+	delete(flow, "project")
+	delete(flow, "service_name")
+
+	return &schema.Resource{
+		ReadContext: datasourceMirrorMakerReplicationFlowsRead,
+		Description: "The MirrorMaker 2 Replication Flows data source lists all replication flows of a MirrorMaker 2 service on Aiven Cloud.",
+		Schema: map[string]*schema.Schema{
+			"project":      schemautil.CommonSchemaProjectReference,
+			"service_name": schemautil.CommonSchemaServiceNameReference,
+			"replication_flows": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of replication flows.",
+				Elem:        &schema.Resource{Schema: flow},
+			},
+		},
+	}
+}
+
+func datasourceMirrorMakerReplicationFlowsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+
+	rsp, err := client.KafkaMirrorMakerReplicationFlow.List(ctx, projectName, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	aliases, err := mirrorMakerClusterAliases(ctx, client, projectName, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	listTopics := func(ctx context.Context, project, serviceName string) ([]string, error) {
+		return listKafkaTopicNames(ctx, client, project, serviceName)
+	}
+	topicsByAlias := make(map[string][]string)
+	flows := make([]map[string]interface{}, len(rsp.ReplicationFlows))
+	for i := range rsp.ReplicationFlows {
+		f := &rsp.ReplicationFlows[i]
+		matched, err := mirrorMakerFlowMatchedTopics(ctx, listTopics, projectName, aliases, topicsByAlias, f)
+		if err != nil {
+			log.Printf("[WARN] unable to list MirrorMaker replication flow %s->%s matched topics: %s", f.SourceCluster, f.TargetCluster, err)
+		}
+
+		flows[i] = map[string]interface{}{
+			"enable":                              f.Enabled,
+			"source_cluster":                      f.SourceCluster,
+			"target_cluster":                      f.TargetCluster,
+			"topics":                              f.Topics,
+			"topics_blacklist":                    f.TopicsBlacklist,
+			"replication_policy_class":            f.ReplicationPolicyClass,
+			"sync_group_offsets_enabled":          f.SyncGroupOffsetsEnabled,
+			"sync_group_offsets_interval_seconds": f.SyncGroupOffsetsIntervalSeconds,
+			"emit_heartbeats_enabled":             f.EmitHeartbeatsEnabled,
+			"emit_backward_heartbeats_enabled":    f.EmitBackwardHeartbeatsEnabled,
+			"offset_syncs_topic_location":         f.OffsetSyncsTopicLocation,
+			"matched_topics":                      matched,
+		}
+	}
+
+	d.SetId(schemautil.BuildResourceID(projectName, serviceName))
+	if err := d.Set("replication_flows", flows); err != nil {
+		return diag.Errorf("error setting MirrorMaker `replication_flows` for resource %s: %s", d.Id(), err)
+	}
+	return nil
+}
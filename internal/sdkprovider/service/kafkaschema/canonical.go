@@ -0,0 +1,45 @@
+package kafkaschema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// canonicalSchema returns the canonical form of the schema, so equivalent schemas are equal.
+// If schema type is unknown, e.g. on import, only JSON formatting is normalized:
+// Avro Parsing Canonical Form reduces JSON Schemas like {"type": "object", ...} to bare names,
+// so unrelated schemas would be equal
+func canonicalSchema(schemaType, s string) (string, error) {
+	switch strings.ToUpper(schemaType) {
+	case "AVRO":
+		return canonicalAvro(s)
+	case "JSON":
+		return canonicalJSONSchema(s)
+	case "PROTOBUF":
+		return canonicalProtobuf(s)
+	case "":
+		v, err := decodeJSON(s)
+		if err != nil {
+			return "", err
+		}
+		return encodeJSON(v)
+	}
+	return "", fmt.Errorf("unknown schema type %q", schemaType)
+}
+
+// schemasEqual compares canonical forms, falls back to plain comparison if a schema can't be parsed
+func schemasEqual(schemaType, a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	ca, err := canonicalSchema(schemaType, a)
+	if err != nil {
+		return false
+	}
+	cb, err := canonicalSchema(schemaType, b)
+	if err != nil {
+		return false
+	}
+	return ca == cb
+}
@@ -0,0 +1,181 @@
+package kafkaschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// avroPrimitiveTypes Avro primitive type names
+var avroPrimitiveTypes = map[string]bool{
+	"null":    true,
+	"boolean": true,
+	"int":     true,
+	"long":    true,
+	"float":   true,
+	"double":  true,
+	"bytes":   true,
+	"string":  true,
+}
+
+// canonicalAvro returns Avro Parsing Canonical Form of the schema,
+// see https://avro.apache.org/docs/1.11.1/specification/#parsing-canonical-form-for-schemas
+// Attributes that don't affect parsing, like doc, aliases and default, are dropped,
+// the rest are ordered and names are replaced with full names.
+func canonicalAvro(s string) (string, error) {
+	v, err := decodeJSON(s)
+	if err != nil {
+		return "", err
+	}
+
+	b := new(strings.Builder)
+	err = writeAvroCanonical(b, v, "", make(map[string]bool))
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// writeAvroCanonical writes the canonical form of the schema v, which is defined in the namespace.
+// Named types are written in full once, and referenced by full name after that.
+func writeAvroCanonical(b *strings.Builder, v any, namespace string, seen map[string]bool) error {
+	switch t := v.(type) {
+	case string:
+		if avroPrimitiveTypes[t] {
+			b.WriteString(jsonString(t))
+		} else {
+			b.WriteString(jsonString(avroFullName(t, namespace)))
+		}
+		return nil
+	case []any:
+		// union
+		b.WriteString("[")
+		for i, s := range t {
+			if i > 0 {
+				b.WriteString(",")
+			}
+			if err := writeAvroCanonical(b, s, namespace, seen); err != nil {
+				return err
+			}
+		}
+		b.WriteString("]")
+		return nil
+	case map[string]any:
+		return writeAvroCanonicalObject(b, t, namespace, seen)
+	}
+	return fmt.Errorf("invalid Avro schema: unexpected %v", v)
+}
+
+func writeAvroCanonicalObject(b *strings.Builder, v map[string]any, namespace string, seen map[string]bool) error {
+	kind, ok := v["type"].(string)
+	if !ok {
+		// {"type": {"type": "int"}} or {"type": ["null", "int"]}
+		if t, ok := v["type"]; ok {
+			return writeAvroCanonical(b, t, namespace, seen)
+		}
+		return fmt.Errorf("invalid Avro schema: type is missing")
+	}
+
+	switch kind {
+	case "record", "error", "enum", "fixed":
+	case "array":
+		b.WriteString(`{"type":"array","items":`)
+		if err := writeAvroCanonical(b, v["items"], namespace, seen); err != nil {
+			return err
+		}
+		b.WriteString("}")
+		return nil
+	case "map":
+		b.WriteString(`{"type":"map","values":`)
+		if err := writeAvroCanonical(b, v["values"], namespace, seen); err != nil {
+			return err
+		}
+		b.WriteString("}")
+		return nil
+	default:
+		// Primitive types and references to named types, logicalType and the rest are dropped
+		return writeAvroCanonical(b, kind, namespace, seen)
+	}
+
+	name, _ := v["name"].(string)
+	if name == "" {
+		return fmt.Errorf("invalid Avro schema: %s name is missing", kind)
+	}
+
+	if ns, ok := v["namespace"].(string); ok && !strings.Contains(name, ".") {
+		namespace = ns
+	}
+	fullName := avroFullName(name, namespace)
+	if seen[fullName] {
+		b.WriteString(jsonString(fullName))
+		return nil
+	}
+	seen[fullName] = true
+
+	// Nested types are in the namespace of the enclosing type
+	if i := strings.LastIndex(fullName, "."); i >= 0 {
+		namespace = fullName[:i]
+	} else {
+		namespace = ""
+	}
+
+	b.WriteString(`{"name":`)
+	b.WriteString(jsonString(fullName))
+	b.WriteString(`,"type":`)
+	b.WriteString(jsonString(kind))
+
+	switch kind {
+	case "record", "error":
+		fields, _ := v["fields"].([]any)
+		b.WriteString(`,"fields":[`)
+		for i, f := range fields {
+			field, ok := f.(map[string]any)
+			if !ok {
+				return fmt.Errorf("invalid Avro schema: unexpected field %v", f)
+			}
+			if i > 0 {
+				b.WriteString(",")
+			}
+			fieldName, _ := field["name"].(string)
+			b.WriteString(`{"name":`)
+			b.WriteString(jsonString(fieldName))
+			b.WriteString(`,"type":`)
+			if err := writeAvroCanonical(b, field["type"], namespace, seen); err != nil {
+				return err
+			}
+			b.WriteString("}")
+		}
+		b.WriteString("]")
+	case "enum":
+		symbols, _ := v["symbols"].([]any)
+		b.WriteString(`,"symbols":[`)
+		for i, s := range symbols {
+			if i > 0 {
+				b.WriteString(",")
+			}
+			b.WriteString(jsonString(fmt.Sprint(s)))
+		}
+		b.WriteString("]")
+	case "fixed":
+		size, ok := v["size"].(json.Number)
+		if !ok {
+			return fmt.Errorf("invalid Avro schema: fixed size is missing")
+		}
+		n, err := size.Int64()
+		if err != nil {
+			return fmt.Errorf("invalid Avro schema: fixed size %s: %w", size, err)
+		}
+		b.WriteString(fmt.Sprintf(`,"size":%d`, n))
+	}
+
+	b.WriteString("}")
+	return nil
+}
+
+// avroFullName returns the full name, a name with dots is a full name already
+func avroFullName(name, namespace string) string {
+	if namespace == "" || strings.Contains(name, ".") {
+		return name
+	}
+	return namespace + "." + name
+}
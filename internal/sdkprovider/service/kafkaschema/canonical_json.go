@@ -0,0 +1,163 @@
+package kafkaschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+// canonicalJSONSchema returns JSON Schema with local $ref resolved and keys ordered.
+// Definitions are dropped once all refs are resolved, so moving a subschema to $defs isn't a change.
+func canonicalJSONSchema(s string) (string, error) {
+	v, err := decodeJSON(s)
+	if err != nil {
+		return "", err
+	}
+
+	r := &jsonSchemaResolver{root: v}
+	resolved, err := r.resolve(v, nil)
+	if err != nil {
+		return "", err
+	}
+
+	if m, ok := resolved.(map[string]any); ok && !r.recursive {
+		delete(m, "$defs")
+		delete(m, "definitions")
+	}
+
+	return encodeJSON(resolved)
+}
+
+// jsonSchemaResolver inlines local $ref, e.g. "#/$defs/foo"
+type jsonSchemaResolver struct {
+	root any
+	// recursive is true if a $ref is kept, because it points to its own parent
+	recursive bool
+}
+
+// resolve returns a copy of v with $ref replaced by the referenced subschemas.
+// stack holds the refs being resolved, to stop on recursive schemas
+func (r *jsonSchemaResolver) resolve(v any, stack []string) (any, error) {
+	switch t := v.(type) {
+	case []any:
+		result := make([]any, len(t))
+		for i, s := range t {
+			item, err := r.resolve(s, stack)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = item
+		}
+		return result, nil
+	case map[string]any:
+		result := make(map[string]any, len(t))
+		ref, ok := t["$ref"].(string)
+		if ok && strings.HasPrefix(ref, "#") {
+			if slices.Contains(stack, ref) {
+				r.recursive = true
+				result["$ref"] = ref
+			} else {
+				target, err := r.pointer(ref)
+				if err != nil {
+					return nil, err
+				}
+				resolved, err := r.resolve(target, append(stack, ref))
+				if err != nil {
+					return nil, err
+				}
+				if m, ok := resolved.(map[string]any); ok {
+					for k, v := range m {
+						result[k] = v
+					}
+				} else if len(t) == 1 {
+					// A boolean schema
+					return resolved, nil
+				}
+			}
+		}
+
+		// Keywords next to $ref override the referenced ones
+		for k, s := range t {
+			if k == "$ref" && ok && strings.HasPrefix(ref, "#") {
+				continue
+			}
+			item, err := r.resolve(s, stack)
+			if err != nil {
+				return nil, err
+			}
+			result[k] = item
+		}
+		return result, nil
+	}
+	return v, nil
+}
+
+// pointer returns the value of the root by JSON pointer, e.g. "#/$defs/foo"
+func (r *jsonSchemaResolver) pointer(ref string) (any, error) {
+	p, err := url.PathUnescape(strings.TrimPrefix(ref, "#"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid $ref %q: %w", ref, err)
+	}
+
+	v := r.root
+	if p == "" {
+		return v, nil
+	}
+
+	for _, part := range strings.Split(strings.TrimPrefix(p, "/"), "/") {
+		part = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+		switch t := v.(type) {
+		case map[string]any:
+			next, ok := t[part]
+			if !ok {
+				return nil, fmt.Errorf("invalid $ref %q: %q not found", ref, part)
+			}
+			v = next
+		case []any:
+			i, err := strconv.Atoi(part)
+			if err != nil || i < 0 || i >= len(t) {
+				return nil, fmt.Errorf("invalid $ref %q: index %q out of range", ref, part)
+			}
+			v = t[i]
+		default:
+			return nil, fmt.Errorf("invalid $ref %q", ref)
+		}
+	}
+	return v, nil
+}
+
+// decodeJSON decodes JSON keeping numbers as they are, so large integers are not rounded
+func decodeJSON(s string) (any, error) {
+	var v any
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("invalid JSON: unexpected data after the value")
+	}
+	return v, nil
+}
+
+// encodeJSON encodes JSON with sorted keys and no HTML escaping
+func encodeJSON(v any) (string, error) {
+	b := new(bytes.Buffer)
+	enc := json.NewEncoder(b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(b.String(), "\n"), nil
+}
+
+// jsonString returns s as a JSON string
+func jsonString(s string) string {
+	v, _ := encodeJSON(s)
+	return v
+}
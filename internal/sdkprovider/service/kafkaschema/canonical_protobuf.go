@@ -0,0 +1,236 @@
+package kafkaschema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+// protoStatement a statement ending with ";", or a block like "message Foo { ... }"
+type protoStatement struct {
+	tokens []string
+	body   []*protoStatement
+	block  bool
+}
+
+// canonicalProtobuf returns the Protobuf file with comments and formatting dropped.
+// Imports, options and field options are sorted, because their order doesn't matter.
+func canonicalProtobuf(s string) (string, error) {
+	tokens, err := tokenizeProtobuf(s)
+	if err != nil {
+		return "", err
+	}
+
+	p := &protoParser{tokens: tokens}
+	body, err := p.parseBody()
+	if err != nil {
+		return "", err
+	}
+	if p.pos < len(p.tokens) {
+		return "", fmt.Errorf("invalid Protobuf schema: unexpected %q", p.tokens[p.pos])
+	}
+
+	return writeProtobufBody(body), nil
+}
+
+// tokenizeProtobuf splits the file into identifiers, numbers, strings and symbols, dropping comments.
+// Strings are always double-quoted.
+func tokenizeProtobuf(s string) ([]string, error) {
+	tokens := make([]string, 0)
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == '\f' || c == '\v':
+			i++
+		case strings.HasPrefix(s[i:], "//"):
+			end := strings.IndexByte(s[i:], '\n')
+			if end < 0 {
+				return tokens, nil
+			}
+			i += end + 1
+		case strings.HasPrefix(s[i:], "/*"):
+			end := strings.Index(s[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("invalid Protobuf schema: unterminated comment")
+			}
+			i += end + 4
+		case c == '"' || c == '\'':
+			j := i + 1
+			for ; j < len(s) && s[j] != c; j++ {
+				if s[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(s) {
+				return nil, fmt.Errorf("invalid Protobuf schema: unterminated string")
+			}
+			tokens = append(tokens, protoDoubleQuoted(s[i+1:j], c))
+			i = j + 1
+		case isProtoWordChar(c):
+			j := i
+			for j < len(s) && isProtoWordChar(s[j]) {
+				j++
+			}
+			tokens = append(tokens, s[i:j])
+			i = j
+		default:
+			tokens = append(tokens, string(c))
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+func isProtoWordChar(c byte) bool {
+	return c == '_' || c == '.' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// protoDoubleQuoted returns the string content in double quotes
+func protoDoubleQuoted(content string, quote byte) string {
+	if quote == '"' {
+		return `"` + content + `"`
+	}
+
+	b := new(strings.Builder)
+	b.WriteByte('"')
+	for i := 0; i < len(content); i++ {
+		switch {
+		case content[i] == '\\' && i+1 < len(content) && content[i+1] == '\'':
+			b.WriteByte('\'')
+			i++
+		case content[i] == '\\' && i+1 < len(content):
+			b.WriteString(content[i : i+2])
+			i++
+		case content[i] == '"':
+			b.WriteString(`\"`)
+		default:
+			b.WriteByte(content[i])
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+type protoParser struct {
+	tokens []string
+	pos    int
+}
+
+// parseBody parses statements until "}" or the end of the file
+func (p *protoParser) parseBody() ([]*protoStatement, error) {
+	body := make([]*protoStatement, 0)
+	for p.pos < len(p.tokens) && p.tokens[p.pos] != "}" {
+		st, err := p.parseStatement()
+		if err != nil {
+			return nil, err
+		}
+		if st != nil {
+			body = append(body, st)
+		}
+	}
+	return body, nil
+}
+
+// parseStatement parses a statement, returns nil for an empty one
+func (p *protoParser) parseStatement() (*protoStatement, error) {
+	st := new(protoStatement)
+	// brackets of field options, which may have message literals with braces
+	brackets := 0
+	for p.pos < len(p.tokens) {
+		t := p.tokens[p.pos]
+		p.pos++
+		switch {
+		case t == "[":
+			brackets++
+		case t == "]":
+			brackets--
+		case brackets > 0:
+		case t == ";":
+			if len(st.tokens) == 0 {
+				return nil, nil
+			}
+			return st, nil
+		case t == "{":
+			body, err := p.parseBody()
+			if err != nil {
+				return nil, err
+			}
+			if p.pos >= len(p.tokens) {
+				return nil, fmt.Errorf("invalid Protobuf schema: missing }")
+			}
+			p.pos++
+			st.body = body
+			st.block = true
+			return st, nil
+		}
+		st.tokens = append(st.tokens, t)
+
+		// Message literals in options don't have ";" between the fields
+		if brackets == 0 && p.pos < len(p.tokens) && p.tokens[p.pos] == "}" {
+			return st, nil
+		}
+	}
+	return st, nil
+}
+
+func writeProtobufBody(body []*protoStatement) string {
+	sorted := make([]string, 0)
+	rest := make([]string, 0, len(body))
+	for _, st := range body {
+		s := writeProtobufStatement(st)
+		if !st.block && len(st.tokens) > 0 && (st.tokens[0] == "import" || st.tokens[0] == "option") {
+			sorted = append(sorted, s)
+		} else {
+			rest = append(rest, s)
+		}
+	}
+	sort.Strings(sorted)
+	return strings.Join(append(sorted, rest...), " ")
+}
+
+func writeProtobufStatement(st *protoStatement) string {
+	head := strings.Join(sortProtobufFieldOptions(st.tokens), " ")
+	if st.block {
+		return strings.TrimSpace(head + " { " + writeProtobufBody(st.body) + " }")
+	}
+	return head + " ;"
+}
+
+// sortProtobufFieldOptions sorts options in "[deprecated = true, json_name = "foo"]"
+func sortProtobufFieldOptions(tokens []string) []string {
+	start := slices.Index(tokens, "[")
+	if start < 0 {
+		return tokens
+	}
+
+	options := make([]string, 0)
+	option := make([]string, 0)
+	depth := 0
+	for i := start + 1; i < len(tokens); i++ {
+		t := tokens[i]
+		switch t {
+		case "[", "{", "(":
+			depth++
+		case "}", ")":
+			depth--
+		case "]":
+			if depth == 0 {
+				options = append(options, strings.Join(option, " "))
+				sort.Strings(options)
+				result := append(slices.Clone(tokens[:start+1]), strings.Join(options, " , "))
+				return append(result, tokens[i:]...)
+			}
+			depth--
+		case ",":
+			if depth == 0 {
+				options = append(options, strings.Join(option, " "))
+				option = option[:0]
+				continue
+			}
+		}
+		option = append(option, t)
+	}
+	return tokens
+}
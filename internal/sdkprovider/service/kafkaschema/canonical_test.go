@@ -0,0 +1,189 @@
+package kafkaschema
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalAvro(t *testing.T) {
+	cases := []struct {
+		name     string
+		schema   string
+		expected string
+	}{
+		{
+			name:     "primitive",
+			schema:   `{"type": "int", "logicalType": "date"}`,
+			expected: `"int"`,
+		},
+		{
+			name: "record",
+			schema: `{
+				"doc": "A user",
+				"fields": [
+					{"type": "string", "name": "name", "default": "foo", "doc": "User name"},
+					{"name": "tags", "type": {"items": "string", "type": "array"}},
+					{"name": "next", "type": ["null", "User"]},
+					{"name": "kind", "type": {"type": "enum", "name": "Kind", "symbols": ["A", "B"]}},
+					{"name": "hash", "type": {"type": "fixed", "name": "other.Hash", "size": 16}}
+				],
+				"type": "record",
+				"namespace": "example",
+				"name": "User",
+				"aliases": ["Person"]
+			}`,
+			expected: `{"name":"example.User","type":"record","fields":[` +
+				`{"name":"name","type":"string"},` +
+				`{"name":"tags","type":{"type":"array","items":"string"}},` +
+				`{"name":"next","type":["null","example.User"]},` +
+				`{"name":"kind","type":{"name":"example.Kind","type":"enum","symbols":["A","B"]}},` +
+				`{"name":"hash","type":{"name":"other.Hash","type":"fixed","size":16}}]}`,
+		},
+		{
+			name:     "map",
+			schema:   `{"type": "map", "values": {"type": "long"}}`,
+			expected: `{"type":"map","values":"long"}`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := canonicalAvro(c.schema)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, actual)
+		})
+	}
+}
+
+func TestCanonicalJSONSchema(t *testing.T) {
+	a := `{
+		"type": "object",
+		"properties": {
+			"name": {"$ref": "#/$defs/name"},
+			"tags": {"type": "array", "items": {"$ref": "#/$defs/name"}}
+		},
+		"$defs": {"name": {"type": "string", "maxLength": 10}}
+	}`
+	b := `{
+		"properties": {
+			"tags": {"items": {"maxLength": 10, "type": "string"}, "type": "array"},
+			"name": {"maxLength": 10, "type": "string"}
+		},
+		"type": "object"
+	}`
+
+	ca, err := canonicalJSONSchema(a)
+	require.NoError(t, err)
+	cb, err := canonicalJSONSchema(b)
+	require.NoError(t, err)
+	assert.Equal(t, cb, ca)
+	assert.Equal(t,
+		`{"properties":{"name":{"maxLength":10,"type":"string"},"tags":{"items":{"maxLength":10,"type":"string"},"type":"array"}},"type":"object"}`,
+		ca,
+	)
+
+	// Recursive refs are kept with their definitions
+	recursive := `{"$ref": "#/definitions/node", "definitions": {"node": {"type": "object", "properties": {"next": {"$ref": "#/definitions/node"}}}}}`
+	actual, err := canonicalJSONSchema(recursive)
+	require.NoError(t, err)
+	assert.Contains(t, actual, `"definitions"`)
+	assert.Contains(t, actual, `"next":{"$ref":"#/definitions/node"}`)
+
+	_, err = canonicalJSONSchema(`{"$ref": "#/$defs/missing"}`)
+	assert.ErrorContains(t, err, `invalid $ref "#/$defs/missing"`)
+}
+
+func TestCanonicalProtobuf(t *testing.T) {
+	a := `
+// User schema
+syntax = "proto3";
+package example;
+
+option java_package = "com.example";
+option go_package = "example";
+import "google/protobuf/timestamp.proto";
+
+/* A user
+   with a name */
+message User {
+  string name = 1 [json_name = "userName", deprecated = true]; // trailing comment
+  google.protobuf.Timestamp created = 2;
+  map<string, int32> scores = 3;
+}
+`
+	b := `syntax='proto3';package example;
+import 'google/protobuf/timestamp.proto';
+option go_package="example";
+option java_package="com.example";
+message User{string name=1[deprecated=true,json_name='userName'];google.protobuf.Timestamp created=2;map<string,int32> scores=3;};
+`
+	ca, err := canonicalProtobuf(a)
+	require.NoError(t, err)
+	cb, err := canonicalProtobuf(b)
+	require.NoError(t, err)
+	assert.Equal(t, ca, cb)
+
+	c := `syntax = "proto3"; message User { string name = 1; int32 age = 2; }`
+	cc, err := canonicalProtobuf(c)
+	require.NoError(t, err)
+	assert.NotEqual(t, ca, cc)
+
+	_, err = canonicalProtobuf(`message User { string name = 1;`)
+	assert.ErrorContains(t, err, "missing }")
+}
+
+func TestSchemasEqual(t *testing.T) {
+	assert.True(t, schemasEqual("AVRO",
+		`{"type": "record", "name": "A", "fields": [{"name": "a", "type": "int", "default": 1}]}`,
+		`{"fields": [{"type": "int", "name": "a", "default": 2}], "name": "A", "type": "record"}`,
+	))
+	assert.False(t, schemasEqual("AVRO",
+		`{"type": "record", "name": "A", "fields": [{"name": "a", "type": "int"}]}`,
+		`{"type": "record", "name": "A", "fields": [{"name": "a", "type": "long"}]}`,
+	))
+	assert.True(t, schemasEqual("AVRO", `{"type": "string"}`, `"string"`))
+	assert.False(t, schemasEqual("JSON", `{"type": "string"}`, `not a schema`))
+
+	// Unknown type, only JSON formatting is normalized
+	assert.True(t, schemasEqual("", `{"type": "string", "maxLength": 1}`, `{"maxLength":1,"type":"string"}`))
+	assert.False(t, schemasEqual("", `{"type": "string"}`, `"string"`))
+	assert.True(t, schemasEqual("", `message A {}`, `message A {}`))
+	assert.False(t, schemasEqual("", `message A {}`, `message B {}`))
+}
+
+// TestDiffSuppressKafkaSchemaImported schema_type is empty in the imported state,
+// so JSON Schemas must not be compared as Avro, which reduces them to their type names
+func TestDiffSuppressKafkaSchemaImported(t *testing.T) {
+	d := ResourceKafkaSchema().Data(&terraform.InstanceState{
+		ID:         "project/service/subject",
+		Attributes: map[string]string{"schema_type": ""},
+	})
+	require.Equal(t, "", d.Get("schema_type"))
+
+	a := `{"type": "object", "properties": {"a": {"type": "string"}}}`
+	b := `{"type": "object", "properties": {"b": {"type": "string"}}}`
+	assert.False(t, diffSuppressKafkaSchema("schema", a, b, d))
+	assert.True(t, diffSuppressKafkaSchema("schema", a, `{"properties":{"a":{"type":"string"}},"type":"object"}`, d))
+}
+
+func TestCanonicalSchemaReferences(t *testing.T) {
+	// Avro named types from the referenced subjects are compared by full name
+	assert.True(t, schemasEqual("AVRO",
+		`{"type": "record", "name": "User", "namespace": "com.example", "fields": [{"name": "address", "type": "Address"}]}`,
+		`{"type": "record", "name": "com.example.User", "fields": [{"name": "address", "type": "com.example.Address", "doc": "Home"}]}`,
+	))
+
+	// External $ref is kept as is
+	actual, err := canonicalJSONSchema(`{"properties": {"address": {"$ref": "address.json"}}, "type": "object"}`)
+	require.NoError(t, err)
+	assert.Equal(t, `{"properties":{"address":{"$ref":"address.json"}},"type":"object"}`, actual)
+
+	// Imports are sorted
+	assert.True(t, schemasEqual("PROTOBUF",
+		`syntax = "proto3"; import "b.proto"; import "a.proto"; message User { a.Address address = 1; }`,
+		`syntax = "proto3"; import "a.proto"; import "b.proto"; message User { a.Address address = 1; }`,
+	))
+}
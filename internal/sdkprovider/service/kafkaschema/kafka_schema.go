@@ -2,10 +2,10 @@ package kafkaschema
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"reflect"
+	"log"
 	"regexp"
+	"strings"
 
 	"github.com/aiven/aiven-go-client/v2"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -33,8 +33,8 @@ var aivenKafkaSchemaSchema = map[string]*schema.Schema{
 	"schema": {
 		Type:             schema.TypeString,
 		Required:         true,
-		StateFunc:        normalizeJSONOrProtobufString,
-		DiffSuppressFunc: diffSuppressJSONObjectOrProtobufString,
+		StateFunc:        normalizeJSONString,
+		DiffSuppressFunc: diffSuppressKafkaSchema,
 		Description: "Kafka Schema configuration. Should be a valid Avro, JSON, or Protobuf schema," +
 			" depending on the schema type.",
 	},
@@ -53,11 +53,45 @@ var aivenKafkaSchemaSchema = map[string]*schema.Schema{
 			return oldValue == "" && d.Id() != ""
 		},
 	},
+	"references": {
+		Type:     schema.TypeList,
+		Optional: true,
+		Description: "Schemas of other subjects this schema refers to, " +
+			"e.g. Protobuf imports, Avro named types or JSON Schema `$ref` URLs.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Required: true,
+					Description: "The name the schema refers to the subject by: " +
+						"Protobuf import path, Avro full name or JSON Schema `$ref` URL.",
+				},
+				"subject": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "The referenced subject name.",
+				},
+				"version": {
+					Type:         schema.TypeInt,
+					Required:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description:  "The referenced subject version.",
+				},
+			},
+		},
+	},
 	"version": {
 		Type:        schema.TypeInt,
 		Computed:    true,
 		Description: "Kafka Schema configuration version.",
 	},
+	"skip_compatibility_check": {
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+		Description: userconfig.Desc("Don't check the schema compatibility with the registry on plan. " +
+			"The registry still rejects incompatible schemas on apply.").DefaultValue(false).Build(),
+	},
 	"compatibility_level": {
 		Type:         schema.TypeString,
 		Optional:     true,
@@ -71,27 +105,14 @@ var aivenKafkaSchemaSchema = map[string]*schema.Schema{
 	},
 }
 
-// diffSuppressJSONObject checks logical equivalences in JSON Kafka Schema values
-func diffSuppressJSONObject(_, old, new string, _ *schema.ResourceData) bool {
-	var objOld, objNew interface{}
-
-	if err := json.Unmarshal([]byte(old), &objOld); err != nil {
-		return false
-	}
-	if err := json.Unmarshal([]byte(new), &objNew); err != nil {
-		return false
+// diffSuppressKafkaSchema checks logical equivalences of Kafka Schema values using their canonical forms
+func diffSuppressKafkaSchema(_, old, new string, d *schema.ResourceData) bool {
+	if schemasEqual(d.Get("schema_type").(string), old, new) {
+		return true
 	}
 
-	return reflect.DeepEqual(objNew, objOld)
-}
-
-// diffSuppressJSONObjectOrProtobufString checks logical equivalences in JSON or Protobuf Kafka Schema values.
-func diffSuppressJSONObjectOrProtobufString(k, old, new string, d *schema.ResourceData) bool {
-	if !diffSuppressJSONObject(k, old, new, d) {
-		return normalizeProtobufString(old) == normalizeProtobufString(new)
-	}
-
-	return true
+	// Protobuf schemas used to be stored without newlines
+	return normalizeProtobufString(old) == normalizeProtobufString(new)
 }
 
 // normalizeProtobufString returns normalized Protobuf string.
@@ -101,15 +122,16 @@ func normalizeProtobufString(i any) string {
 	return newlineRegExp.ReplaceAllString(v, "")
 }
 
-// normalizeJSONOrProtobufString returns normalized JSON or Protobuf string.
-func normalizeJSONOrProtobufString(i any) string {
+// normalizeJSONString returns normalized JSON string, other schemas are stored as they are,
+// because Protobuf line comments can't be joined into a single line.
+func normalizeJSONString(i any) string {
 	v := i.(string)
 
 	if n, err := structure.NormalizeJsonString(v); err == nil {
 		return n
 	}
 
-	return normalizeProtobufString(v)
+	return v
 }
 
 func ResourceKafkaSchema() *schema.Resource {
@@ -120,7 +142,7 @@ func ResourceKafkaSchema() *schema.Resource {
 		ReadContext:   resourceKafkaSchemaRead,
 		DeleteContext: resourceKafkaSchemaDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceKafkaSchemaImport,
 		},
 		CustomizeDiff: resourceKafkaSchemaCustomizeDiff,
 		Timeouts:      schemautil.DefaultResourceTimeouts(),
@@ -129,6 +151,14 @@ func ResourceKafkaSchema() *schema.Resource {
 	}
 }
 
+func resourceKafkaSchemaImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	// Import doesn't set defaults
+	if err := d.Set("skip_compatibility_check", false); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
 func kafkaSchemaSubjectGetLastVersion(
 	ctx context.Context,
 	m interface{},
@@ -165,16 +195,7 @@ func resourceKafkaSchemaCreate(ctx context.Context, d *schema.ResourceData, m in
 	client := m.(*aiven.Client)
 
 	// create Kafka Schema Subject
-	_, err := client.KafkaSubjectSchemas.Add(
-		ctx,
-		project,
-		serviceName,
-		subjectName,
-		aiven.KafkaSchemaSubject{
-			Schema:     d.Get("schema").(string),
-			SchemaType: d.Get("schema_type").(string),
-		},
-	)
+	err := addKafkaSchemaSubject(ctx, client, project, serviceName, subjectName, kafkaSchemaSubjectFromSchema(d))
 	if err != nil {
 		return diag.Errorf("unable to create schema: %s", err)
 	}
@@ -216,23 +237,8 @@ func resourceKafkaSchemaUpdate(ctx context.Context, d *schema.ResourceData, m in
 
 	client := m.(*aiven.Client)
 
-	if d.HasChange("schema") {
-		_, err := client.KafkaSubjectSchemas.Add(
-			ctx,
-			project,
-			serviceName,
-			subjectName,
-			aiven.KafkaSchemaSubject{
-				Schema:     d.Get("schema").(string),
-				SchemaType: d.Get("schema_type").(string),
-			},
-		)
-		if err != nil {
-			return diag.Errorf("unable to update schema: %s", err)
-		}
-	}
-
-	// if compatibility_level has changed and the new value is not empty
+	// if compatibility_level has changed and the new value is not empty.
+	// Goes first, so the new schema version is checked with the new level
 	if compatibility, ok := d.GetOk("compatibility_level"); ok {
 		_, err = client.KafkaSubjectSchemas.UpdateConfiguration(
 			ctx,
@@ -245,6 +251,14 @@ func resourceKafkaSchemaUpdate(ctx context.Context, d *schema.ResourceData, m in
 		}
 	}
 
+	// A new version is added only if the schema has changed, see kafkaSchemaChanged
+	if kafkaSchemaChanged(d) {
+		err := addKafkaSchemaSubject(ctx, client, project, serviceName, subjectName, kafkaSchemaSubjectFromSchema(d))
+		if err != nil {
+			return diag.Errorf("unable to update schema: %s", err)
+		}
+	}
+
 	return resourceKafkaSchemaRead(ctx, d, m)
 }
 
@@ -261,7 +275,7 @@ func resourceKafkaSchemaRead(ctx context.Context, d *schema.ResourceData, m inte
 		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
 	}
 
-	r, err := client.KafkaSubjectSchemas.Get(ctx, project, serviceName, subjectName, version)
+	r, err := getKafkaSchemaSubjectVersion(ctx, client, project, serviceName, subjectName, version)
 	if err != nil {
 		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
 	}
@@ -278,7 +292,10 @@ func resourceKafkaSchemaRead(ctx context.Context, d *schema.ResourceData, m inte
 	if err := d.Set("version", version); err != nil {
 		return diag.FromErr(err)
 	}
-	if err := d.Set("schema", r.Version.Schema); err != nil {
+	if err := d.Set("schema", r.Schema); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("references", flattenKafkaSchemaReferences(r.References)); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -310,29 +327,136 @@ func resourceKafkaSchemaDelete(ctx context.Context, d *schema.ResourceData, m in
 	return nil
 }
 
-func resourceKafkaSchemaCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
-	client := m.(*aiven.Client)
+// kafkaSchemaChanged returns true if the references or the canonical forms of the old and new schemas are different
+func kafkaSchemaChanged(d interface {
+	HasChange(string) bool
+	GetChange(string) (interface{}, interface{})
+	Get(string) interface{}
+}) bool {
+	if d.HasChange("references") {
+		return true
+	}
+
+	if !d.HasChange("schema") {
+		return false
+	}
+
+	o, n := d.GetChange("schema")
+	return !schemasEqual(d.Get("schema_type").(string), o.(string), n.(string))
+}
+
+func kafkaSchemaSubjectFromSchema(d schemautil.ResourceStateOrResourceDiff) kafkaSchemaSubject {
+	subject := kafkaSchemaSubject{
+		Schema:     d.Get("schema").(string),
+		SchemaType: d.Get("schema_type").(string),
+	}
+	for _, v := range d.Get("references").([]interface{}) {
+		r := v.(map[string]interface{})
+		subject.References = append(subject.References, kafkaSchemaReference{
+			Name:    r["name"].(string),
+			Subject: r["subject"].(string),
+			Version: r["version"].(int),
+		})
+	}
+	return subject
+}
+
+func flattenKafkaSchemaReferences(references []kafkaSchemaReference) []map[string]interface{} {
+	result := make([]map[string]interface{}, len(references))
+	for i, r := range references {
+		result[i] = map[string]interface{}{
+			"name":    r.Name,
+			"subject": r.Subject,
+			"version": r.Version,
+		}
+	}
+	return result
+}
 
+func resourceKafkaSchemaCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
 	// no previous version: allow the diff, nothing to check compatibility against
 	if _, ok := d.GetOk("version"); !ok {
 		return nil
 	}
 
-	if compatible, err := client.KafkaSubjectSchemas.Validate(
+	// the same schema doesn't get a new version
+	if !kafkaSchemaChanged(d) {
+		return nil
+	}
+
+	if err := kafkaSchemaCheckCompatibility(ctx, d, m.(*aiven.Client)); err != nil {
+		return err
+	}
+
+	return d.SetNewComputed("version")
+}
+
+// kafkaSchemaCheckCompatibility checks the new schema with the registry using the subject's effective compatibility level
+func kafkaSchemaCheckCompatibility(ctx context.Context, d *schema.ResourceDiff, client *aiven.Client) error {
+	if d.Get("skip_compatibility_check").(bool) {
+		return nil
+	}
+
+	// referenced versions are not known yet, e.g. the referenced schema gets a new version too
+	if !d.NewValueKnown("schema") || !d.NewValueKnown("references") {
+		return nil
+	}
+
+	project := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	subjectName := d.Get("subject_name").(string)
+	level, err := kafkaSchemaEffectiveCompatibility(ctx, client, project, serviceName, subjectName)
+	if err != nil {
+		return fmt.Errorf("unable to get compatibility level: %w", err)
+	}
+
+	if planned := d.Get("compatibility_level").(string); planned != "" && planned != level {
+		// The level is updated before the new version is added, so the registry checks the schema on apply
+		log.Printf("[DEBUG] Kafka Schema %s compatibility level changes from %s to %s, skipping the check", subjectName, level, planned)
+		return nil
+	}
+
+	if level == "NONE" {
+		return nil
+	}
+
+	rsp, err := validateKafkaSchemaSubject(
 		ctx,
-		d.Get("project").(string),
-		d.Get("service_name").(string),
-		d.Get("subject_name").(string),
+		client,
+		project,
+		serviceName,
+		subjectName,
 		d.Get("version").(int),
-		aiven.KafkaSchemaSubject{
-			Schema:     d.Get("schema").(string),
-			SchemaType: d.Get("schema_type").(string),
-		},
-	); err != nil {
+		kafkaSchemaSubjectFromSchema(d),
+	)
+	if err != nil {
 		return fmt.Errorf("unable to check schema validity: %w", err)
-	} else if !compatible {
-		return fmt.Errorf("schema is not compatible with previous version")
+	}
+
+	if !rsp.IsCompatible {
+		msg := fmt.Sprintf("schema is not compatible with previous version, compatibility level is %s", level)
+		if len(rsp.Messages) > 0 {
+			msg += ":\n" + strings.Join(rsp.Messages, "\n")
+		}
+		return fmt.Errorf("%s", msg)
 	}
 
 	return nil
 }
+
+// kafkaSchemaEffectiveCompatibility returns the subject compatibility level, or the global one if the subject has none
+func kafkaSchemaEffectiveCompatibility(ctx context.Context, client *aiven.Client, project, serviceName, subjectName string) (string, error) {
+	c, err := client.KafkaSubjectSchemas.GetConfiguration(ctx, project, serviceName, subjectName)
+	if err == nil {
+		return c.CompatibilityLevel, nil
+	}
+	if !aiven.IsNotFound(err) {
+		return "", err
+	}
+
+	g, err := client.KafkaGlobalSchemaConfig.Get(ctx, project, serviceName)
+	if err != nil {
+		return "", err
+	}
+	return g.CompatibilityLevel, nil
+}
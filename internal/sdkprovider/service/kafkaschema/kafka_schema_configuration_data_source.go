@@ -14,8 +14,7 @@ func DatasourceKafkaSchemaConfiguration() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: datasourceKafkaSchemasConfigurationRead,
 		Description: "The Kafka Schema Configuration data source provides information about the existing Aiven Kafka Schema Configuration.",
-		Schema: schemautil.ResourceSchemaAsDatasourceSchema(aivenKafkaSchemaSchema,
-			"project", "service_name"),
+		Schema:      datasourceKafkaSchemaSchema("project", "service_name"),
 	}
 }
 
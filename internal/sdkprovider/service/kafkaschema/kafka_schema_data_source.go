@@ -14,11 +14,17 @@ func DatasourceKafkaSchema() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: datasourceKafkaSchemaRead,
 		Description: "The Kafka Schema data source provides information about the existing Aiven Kafka Schema.",
-		Schema: schemautil.ResourceSchemaAsDatasourceSchema(aivenKafkaSchemaSchema,
-			"project", "service_name", "subject_name"),
+		Schema:      datasourceKafkaSchemaSchema("project", "service_name", "subject_name"),
 	}
 }
 
+// datasourceKafkaSchemaSchema doesn't have the fields that only control the resource
+func datasourceKafkaSchemaSchema(required ...string) map[string]*schema.Schema {
+	s := schemautil.ResourceSchemaAsDatasourceSchema(aivenKafkaSchemaSchema, required...)
+	delete(s, "skip_compatibility_check")
+	return s
+}
+
 func datasourceKafkaSchemaRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	projectName := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
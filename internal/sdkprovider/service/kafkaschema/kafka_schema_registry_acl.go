@@ -2,6 +2,8 @@ package kafkaschema
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/aiven/aiven-go-client/v2"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -11,6 +13,7 @@ import (
 	"github.com/aiven/terraform-provider-aiven/internal/common"
 	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
 	"github.com/aiven/terraform-provider-aiven/internal/schemautil/userconfig"
+	"github.com/aiven/terraform-provider-aiven/internal/sdkprovider/kafkaaclrepository"
 )
 
 var aivenKafkaSchemaRegistryACLSchema = map[string]*schema.Schema{
@@ -36,6 +39,13 @@ var aivenKafkaSchemaRegistryACLSchema = map[string]*schema.Schema{
 		ValidateFunc: schemautil.GetACLUserValidateFunc(),
 		Description:  userconfig.Desc("Username pattern for the ACL entry.").ForceNew().Build(),
 	},
+	"adopt_existing": {
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+		Description: userconfig.Desc("Take over an existing ACL with the same `permission`, `resource` and `username` " +
+			"instead of failing on create.").DefaultValue(false).Build(),
+	},
 
 	// computed
 	"acl_id": {
@@ -50,9 +60,10 @@ func ResourceKafkaSchemaRegistryACL() *schema.Resource {
 		Description:   "The Resource Kafka Schema Registry ACL resource allows the creation and management of Schema Registry ACLs for an Aiven Kafka service.",
 		CreateContext: resourceKafkaSchemaRegistryACLCreate,
 		ReadContext:   resourceKafkaSchemaRegistryACLRead,
+		UpdateContext: resourceKafkaSchemaRegistryACLUpdate,
 		DeleteContext: resourceKafkaSchemaRegistryACLDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceKafkaSchemaRegistryACLImport,
 		},
 		Timeouts: schemautil.DefaultResourceTimeouts(),
 
@@ -65,15 +76,32 @@ func resourceKafkaSchemaRegistryACLCreate(ctx context.Context, d *schema.Resourc
 
 	project := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
+	permission := d.Get("permission").(string)
+	resource := d.Get("resource").(string)
+	username := d.Get("username").(string)
+	rep := kafkaaclrepository.New(client)
+
+	// Takes over the existing ACL, if there is one
+	if d.Get("adopt_existing").(bool) {
+		acl, err := rep.FindSchemaRegistryACL(ctx, project, serviceName, permission, resource, username)
+		if err == nil {
+			d.SetId(schemautil.BuildResourceID(project, serviceName, acl.ID))
+			return resourceKafkaSchemaRegistryACLRead(ctx, d, m)
+		}
+
+		if !aiven.IsNotFound(err) {
+			return diag.FromErr(err)
+		}
+	}
 
-	acl, err := client.KafkaSchemaRegistryACLs.Create(
+	acl, err := rep.CreateSchemaRegistryACL(
 		ctx,
 		project,
 		serviceName,
 		aiven.CreateKafkaSchemaRegistryACLRequest{
-			Permission: d.Get("permission").(string),
-			Resource:   d.Get("resource").(string),
-			Username:   d.Get("username").(string),
+			Permission: permission,
+			Resource:   resource,
+			Username:   username,
 		},
 	)
 	if err != nil {
@@ -93,7 +121,7 @@ func resourceKafkaSchemaRegistryACLRead(ctx context.Context, d *schema.ResourceD
 		return diag.FromErr(err)
 	}
 
-	acl, err := client.KafkaSchemaRegistryACLs.Get(ctx, project, serviceName, aclID)
+	acl, err := kafkaaclrepository.New(client).ReadSchemaRegistryACL(ctx, project, serviceName, aclID)
 	if err != nil {
 		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
 	}
@@ -106,6 +134,11 @@ func resourceKafkaSchemaRegistryACLRead(ctx context.Context, d *schema.ResourceD
 	return nil
 }
 
+// resourceKafkaSchemaRegistryACLUpdate only adopt_existing can be changed, which is used on create
+func resourceKafkaSchemaRegistryACLUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourceKafkaSchemaRegistryACLRead(ctx, d, m)
+}
+
 func resourceKafkaSchemaRegistryACLDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*aiven.Client)
 
@@ -114,7 +147,7 @@ func resourceKafkaSchemaRegistryACLDelete(ctx context.Context, d *schema.Resourc
 		return diag.FromErr(err)
 	}
 
-	err = client.KafkaSchemaRegistryACLs.Delete(ctx, projectName, serviceName, aclID)
+	err = kafkaaclrepository.New(client).DeleteSchemaRegistryACL(ctx, projectName, serviceName, aclID)
 	if common.IsCritical(err) {
 		return diag.FromErr(err)
 	}
@@ -122,6 +155,33 @@ func resourceKafkaSchemaRegistryACLDelete(ctx context.Context, d *schema.Resourc
 	return nil
 }
 
+// resourceKafkaSchemaRegistryACLImport imports by ACL ID "project/service_name/id"
+// or by ACL fields "project/service_name/username/resource/permission"
+func resourceKafkaSchemaRegistryACLImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Import doesn't set defaults
+	if err := d.Set("adopt_existing", false); err != nil {
+		return nil, err
+	}
+
+	if strings.Count(d.Id(), "/") != 4 {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	parts, err := schemautil.SplitResourceID(d.Id(), 5)
+	if err != nil {
+		return nil, err
+	}
+
+	project, serviceName, username, resource, permission := parts[0], parts[1], parts[2], parts[3], parts[4]
+	acl, err := kafkaaclrepository.New(m.(*aiven.Client)).FindSchemaRegistryACL(ctx, project, serviceName, permission, resource, username)
+	if err != nil {
+		return nil, fmt.Errorf("cannot find Kafka Schema Registry ACL %s: %w", d.Id(), err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(project, serviceName, acl.ID))
+	return []*schema.ResourceData{d}, nil
+}
+
 func copyKafkaSchemaRegistryACLPropertiesFromAPIResponseToTerraform(
 	d *schema.ResourceData,
 	acl *aiven.KafkaSchemaRegistryACL,
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+	"github.com/aiven/terraform-provider-aiven/internal/sdkprovider/kafkaaclrepository"
 )
 
 func DatasourceKafkaSchemaRegistryACL() *schema.Resource {
@@ -15,11 +16,19 @@ func DatasourceKafkaSchemaRegistryACL() *schema.Resource {
 		ReadContext: datasourceKafkaSchemaRegistryACLRead,
 		Description: "The Data Source Kafka Schema Registry ACL data source provides information about the existing Aiven Kafka Schema Registry ACL for a Kafka service.",
 
-		Schema: schemautil.ResourceSchemaAsDatasourceSchema(aivenKafkaSchemaRegistryACLSchema,
-			"project", "service_name", "resource", "username", "permission"),
+		Schema: datasourceKafkaSchemaRegistryACLSchema(),
 	}
 }
 
+func datasourceKafkaSchemaRegistryACLSchema() map[string]*schema.Schema {
+	s := schemautil.ResourceSchemaAsDatasourceSchema(aivenKafkaSchemaRegistryACLSchema,
+		"project", "service_name", "resource", "username", "permission")
+
+	// Used on create only
+	delete(s, "adopt_existing")
+	return s
+}
+
 func datasourceKafkaSchemaRegistryACLRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*aiven.Client)
 
@@ -29,17 +38,14 @@ func datasourceKafkaSchemaRegistryACLRead(ctx context.Context, d *schema.Resourc
 	userName := d.Get("username").(string)
 	permission := d.Get("permission").(string)
 
-	acls, err := client.KafkaSchemaRegistryACLs.List(ctx, projectName, serviceName)
+	acl, err := kafkaaclrepository.New(client).FindSchemaRegistryACL(ctx, projectName, serviceName, permission, resource, userName)
+	if aiven.IsNotFound(err) {
+		return diag.Errorf("KafkaSchemaRegistryACL %s/%s not found", resource, userName)
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	for _, acl := range acls {
-		if acl.Resource == resource && acl.Username == userName && acl.Permission == permission {
-			d.SetId(schemautil.BuildResourceID(projectName, serviceName, acl.ID))
-			return resourceKafkaSchemaRegistryACLRead(ctx, d, m)
-		}
-	}
-
-	return diag.Errorf("KafkaSchemaRegistryACL %s/%s not found", resource, userName)
+	d.SetId(schemautil.BuildResourceID(projectName, serviceName, acl.ID))
+	return resourceKafkaSchemaRegistryACLRead(ctx, d, m)
 }
@@ -74,10 +74,39 @@ func TestAccAivenKafkaSchemaRegistryACL_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet(resourceName, "acl_id"),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					a := s.RootModule().Resources[resourceName].Primary.Attributes
+					return schemautil.BuildResourceID(a["project"], a["service_name"], a["username"], a["resource"], a["permission"]), nil
+				},
+			},
+			{
+				Config: testAccKafkaSchemaRegistryACLResource(rName) + testAccKafkaSchemaRegistryACLAdoptExistingResource(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("aiven_kafka_schema_registry_acl.adopted", "adopt_existing", "true"),
+					resource.TestCheckResourceAttrPair("aiven_kafka_schema_registry_acl.adopted", "acl_id", resourceName, "acl_id"),
+				),
+			},
 		},
 	})
 }
 
+func testAccKafkaSchemaRegistryACLAdoptExistingResource() string {
+	return `
+
+resource "aiven_kafka_schema_registry_acl" "adopted" {
+  project        = aiven_kafka_schema_registry_acl.foo.project
+  service_name   = aiven_kafka_schema_registry_acl.foo.service_name
+  resource       = aiven_kafka_schema_registry_acl.foo.resource
+  username       = aiven_kafka_schema_registry_acl.foo.username
+  permission     = aiven_kafka_schema_registry_acl.foo.permission
+  adopt_existing = true
+}`
+}
+
 func testAccCheckAivenKafkaSchemaRegistryACLAttributes(n string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		r := s.RootModule().Resources[n]
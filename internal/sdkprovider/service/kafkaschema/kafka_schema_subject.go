@@ -0,0 +1,98 @@
+package kafkaschema
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/aiven/aiven-go-client/v2"
+
+	"github.com/aiven/terraform-provider-aiven/internal/common"
+)
+
+// kafkaSchemaSubject Kafka Schema with references to other subjects.
+// aiven.Client doesn't support references yet, see common.DoRequest
+type kafkaSchemaSubject struct {
+	Schema     string                 `json:"schema"`
+	SchemaType string                 `json:"schemaType,omitempty"`
+	References []kafkaSchemaReference `json:"references,omitempty"`
+}
+
+// kafkaSchemaReference a reference to a version of another subject,
+// name is the Protobuf import path, Avro full name or JSON Schema $ref URL
+type kafkaSchemaReference struct {
+	Name    string `json:"name"`
+	Subject string `json:"subject"`
+	Version int    `json:"version"`
+}
+
+type kafkaSchemaSubjectVersion struct {
+	ID         int                    `json:"id"`
+	Schema     string                 `json:"schema"`
+	Subject    string                 `json:"subject"`
+	Version    int                    `json:"version"`
+	SchemaType string                 `json:"schemaType"`
+	References []kafkaSchemaReference `json:"references"`
+}
+
+type kafkaSchemaSubjectVersionResponse struct {
+	Version kafkaSchemaSubjectVersion `json:"version"`
+}
+
+type kafkaSchemaValidateResponse struct {
+	IsCompatible bool     `json:"is_compatible"`
+	Messages     []string `json:"messages"`
+}
+
+// kafkaSchemaSubjectPath returns the subject endpoint, e.g. "/compatibility" + "/subjects/foo" + "/versions/1"
+func kafkaSchemaSubjectPath(project, serviceName, prefix, subjectName, suffix string) string {
+	return fmt.Sprintf(
+		"/project/%s/service/%s/kafka/schema%s/subjects/%s%s",
+		url.PathEscape(project), url.PathEscape(serviceName), prefix, url.PathEscape(subjectName), suffix,
+	)
+}
+
+// addKafkaSchemaSubject registers a new version of the subject, the same schema gets the same version
+func addKafkaSchemaSubject(
+	ctx context.Context,
+	client *aiven.Client,
+	project, serviceName, subjectName string,
+	subject kafkaSchemaSubject,
+) error {
+	path := kafkaSchemaSubjectPath(project, serviceName, "", subjectName, "/versions")
+	return common.DoRequest(ctx, client, http.MethodPost, path, subject, nil)
+}
+
+func getKafkaSchemaSubjectVersion(
+	ctx context.Context,
+	client *aiven.Client,
+	project, serviceName, subjectName string,
+	version int,
+) (*kafkaSchemaSubjectVersion, error) {
+	path := kafkaSchemaSubjectPath(project, serviceName, "", subjectName, fmt.Sprintf("/versions/%d", version))
+	rsp := new(kafkaSchemaSubjectVersionResponse)
+	err := common.DoRequest(ctx, client, http.MethodGet, path, nil, rsp)
+	if err != nil {
+		return nil, err
+	}
+	return &rsp.Version, nil
+}
+
+// validateKafkaSchemaSubject checks the schema is compatible with the version of the subject.
+// Verbose mode returns the reasons why it is not
+func validateKafkaSchemaSubject(
+	ctx context.Context,
+	client *aiven.Client,
+	project, serviceName, subjectName string,
+	version int,
+	subject kafkaSchemaSubject,
+) (*kafkaSchemaValidateResponse, error) {
+	path := kafkaSchemaSubjectPath(project, serviceName, "/compatibility", subjectName, fmt.Sprintf("/versions/%d?verbose=true", version))
+	rsp := new(kafkaSchemaValidateResponse)
+	err := common.DoRequest(ctx, client, http.MethodPost, path, subject, rsp)
+	if err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
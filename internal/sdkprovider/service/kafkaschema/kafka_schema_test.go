@@ -106,7 +106,7 @@ func TestAccAivenKafkaSchema_json_protobuf_basic(t *testing.T) {
 		CheckDestroy:             testAccCheckAivenKafkaSchemaResourceDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccKafkaSchemaJSONProtobufResource(rName),
+				Config: testAccKafkaSchemaJSONProtobufResource(rName, testAccKafkaSchemaProtobuf),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckAivenKafkaSchemaAttributes("data.aiven_kafka_schema.schema"),
 					testAccCheckAivenKafkaSchemaAttributes("data.aiven_kafka_schema.schema2"),
@@ -130,6 +130,55 @@ func TestAccAivenKafkaSchema_json_protobuf_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName2, "schema_type", "PROTOBUF"),
 				),
 			},
+			{
+				// Comments and formatting are not a change
+				Config:   testAccKafkaSchemaJSONProtobufResource(rName, testAccKafkaSchemaProtobufReformatted),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+const testAccKafkaSchemaProtobuf = `syntax = "proto3";
+
+message Example {
+  int32 test = 5;
+}
+`
+
+const testAccKafkaSchemaProtobufReformatted = `// Example schema
+syntax = 'proto3';
+message Example { int32 test = 5; /* the only field */ }
+`
+
+func TestAccAivenKafkaSchema_references(t *testing.T) {
+	resourceName := "aiven_kafka_schema.user"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acc.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acc.TestProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAivenKafkaSchemaResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKafkaSchemaReferencesResource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "version", "1"),
+					resource.TestCheckResourceAttr(resourceName, "references.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "references.0.name", "common.proto"),
+					resource.TestCheckResourceAttr(
+						resourceName, "references.0.subject", fmt.Sprintf("kafka-schema-%s-common", rName),
+					),
+					resource.TestCheckResourceAttr(resourceName, "references.0.version", "1"),
+					resource.TestCheckResourceAttr("data.aiven_kafka_schema.user", "references.0.name", "common.proto"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"schema_type"},
+			},
 		},
 	})
 }
@@ -166,8 +215,14 @@ func TestAccAivenKafkaSchema_basic(t *testing.T) {
 				),
 			},
 			{
-				Config:      testAccKafkaSchemaResourceInvalidUpdate(rName),
-				ExpectError: regexp.MustCompile("schema is not compatible with previous version"),
+				Config:      testAccKafkaSchemaResourceInvalidUpdate(rName, false),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("schema is not compatible with previous version, compatibility level is BACKWARD"),
+			},
+			{
+				// Karapace still rejects the schema on apply
+				Config:      testAccKafkaSchemaResourceInvalidUpdate(rName, true),
+				ExpectError: regexp.MustCompile("unable to update schema"),
 			},
 		},
 	})
@@ -231,7 +286,7 @@ func testAccCheckAivenKafkaSchemaResourceDestroy(s *terraform.State) error {
 }
 
 // testAccKafkaSchemaJSONProtobufResource is a test resource for JSON and Protobuf schema Kafka Schema resource.
-func testAccKafkaSchemaJSONProtobufResource(name string) string {
+func testAccKafkaSchemaJSONProtobufResource(name, protobuf string) string {
 	return fmt.Sprintf(`
 data "aiven_project" "foo" {
   project = "%[1]s"
@@ -298,12 +353,7 @@ resource "aiven_kafka_schema" "bar" {
   schema_type  = "PROTOBUF"
 
   schema = <<EOT
-syntax = "proto3";
-
-message Example {
-  int32 test = 5;
-}
-
+%[3]s
 EOT
 }
 
@@ -313,6 +363,73 @@ data "aiven_kafka_schema" "schema2" {
   subject_name = aiven_kafka_schema.bar.subject_name
 
   depends_on = [aiven_kafka_schema.bar]
+}`, os.Getenv("AIVEN_PROJECT_NAME"), name, protobuf)
+}
+
+func testAccKafkaSchemaReferencesResource(name string) string {
+	return fmt.Sprintf(`
+data "aiven_project" "foo" {
+  project = "%[1]s"
+}
+
+resource "aiven_kafka" "bar" {
+  project                 = data.aiven_project.foo.project
+  cloud_name              = "google-europe-west1"
+  plan                    = "startup-2"
+  service_name            = "test-acc-sr-%[2]s"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+
+  kafka_user_config {
+    schema_registry = true
+  }
+}
+
+resource "aiven_kafka_schema" "common" {
+  project      = aiven_kafka.bar.project
+  service_name = aiven_kafka.bar.service_name
+  subject_name = "kafka-schema-%[2]s-common"
+  schema_type  = "PROTOBUF"
+
+  schema = <<EOT
+syntax = "proto3";
+package common;
+
+message Address {
+  string city = 1;
+}
+EOT
+}
+
+resource "aiven_kafka_schema" "user" {
+  project      = aiven_kafka.bar.project
+  service_name = aiven_kafka.bar.service_name
+  subject_name = "kafka-schema-%[2]s-user"
+  schema_type  = "PROTOBUF"
+
+  references {
+    name    = "common.proto"
+    subject = aiven_kafka_schema.common.subject_name
+    version = aiven_kafka_schema.common.version
+  }
+
+  schema = <<EOT
+syntax = "proto3";
+import "common.proto";
+
+message User {
+  string name = 1;
+  common.Address address = 2;
+}
+EOT
+}
+
+data "aiven_kafka_schema" "user" {
+  project      = aiven_kafka_schema.user.project
+  service_name = aiven_kafka_schema.user.service_name
+  subject_name = aiven_kafka_schema.user.subject_name
+
+  depends_on = [aiven_kafka_schema.user]
 }`, os.Getenv("AIVEN_PROJECT_NAME"), name)
 }
 
@@ -377,7 +494,7 @@ data "aiven_kafka_schema" "schema" {
 }`, os.Getenv("AIVEN_PROJECT_NAME"), name, name)
 }
 
-func testAccKafkaSchemaResourceInvalidUpdate(name string) string {
+func testAccKafkaSchemaResourceInvalidUpdate(name string, skipCheck bool) string {
 	return fmt.Sprintf(`
 data "aiven_project" "foo" {
   project = "%s"
@@ -411,6 +528,8 @@ resource "aiven_kafka_schema" "foo" {
   service_name = aiven_kafka_schema_configuration.foo.service_name
   subject_name = "kafka-schema-%s"
 
+  skip_compatibility_check = %t
+
   schema = <<EOT
 		    {
 		      "doc": "example",
@@ -434,7 +553,7 @@ data "aiven_kafka_schema" "schema" {
   subject_name = aiven_kafka_schema.foo.subject_name
 
   depends_on = [aiven_kafka_schema.foo]
-}`, os.Getenv("AIVEN_PROJECT_NAME"), name, name)
+}`, os.Getenv("AIVEN_PROJECT_NAME"), name, name, skipCheck)
 }
 
 func testAccKafkaSchemaResourceGoodUpdate(name string) string {
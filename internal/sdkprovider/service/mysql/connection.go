@@ -0,0 +1,57 @@
+package mysql
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql"
+	"fmt"
+	"net"
+
+	"github.com/aiven/aiven-go-client/v2"
+	mysqldriver "github.com/go-sql-driver/mysql"
+)
+
+// connectService opens a connection to the service with the admin credentials of the service.
+// The server certificate is verified with the CA of the project. The caller must close it.
+func connectService(ctx context.Context, client *aiven.Client, projectName, serviceName string) (*sql.DB, error) {
+	s, err := client.Services.Get(ctx, projectName, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(s.ConnectionInfo.MySQLParams) == 0 {
+		return nil, fmt.Errorf("service %s/%s has no MySQL connection info", projectName, serviceName)
+	}
+
+	params := s.ConnectionInfo.MySQLParams[0]
+	config := mysqldriver.NewConfig()
+	config.User = params.User
+	config.Passwd = params.Password
+	config.Net = "tcp"
+	config.Addr = net.JoinHostPort(params.Host, params.Port)
+	config.DBName = params.DatabaseName
+
+	ca, err := client.CA.Get(ctx, projectName)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get the CA certificate of project %s: %w", projectName, err)
+	}
+
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM([]byte(ca)) {
+		return nil, fmt.Errorf("cannot parse the CA certificate of project %s", projectName)
+	}
+	config.TLS = &tls.Config{RootCAs: roots, ServerName: params.Host, MinVersion: tls.VersionTLS12}
+
+	connector, err := mysqldriver.NewConnector(config)
+	if err != nil {
+		return nil, err
+	}
+
+	db := sql.OpenDB(connector)
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("cannot connect to %s/%s: %w", projectName, serviceName, err)
+	}
+	return db, nil
+}
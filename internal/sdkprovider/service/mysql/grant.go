@@ -0,0 +1,158 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+// allTables the table of a grant on all the tables of the database, GRANT ... ON db.*
+const allTables = "*"
+
+// mysqlDatabasePrivileges the privileges that can be granted on a database, as in information_schema
+var mysqlDatabasePrivileges = []string{
+	"ALTER", "ALTER ROUTINE", "CREATE", "CREATE ROUTINE", "CREATE TEMPORARY TABLES", "CREATE VIEW",
+	"DELETE", "DROP", "EVENT", "EXECUTE", "INDEX", "INSERT", "LOCK TABLES", "REFERENCES",
+	"SELECT", "SHOW VIEW", "TRIGGER", "UPDATE",
+}
+
+// mysqlTablePrivileges the privileges that can be granted on a table
+var mysqlTablePrivileges = []string{
+	"ALTER", "CREATE", "CREATE VIEW", "DELETE", "DROP", "INDEX", "INSERT", "REFERENCES",
+	"SELECT", "SHOW VIEW", "TRIGGER", "UPDATE",
+}
+
+// Grant the privileges of a user on a database or on a table of it
+type Grant struct {
+	User     string
+	Database string
+	// Table is allTables for the grant on the database
+	Table           string
+	Privileges      []string
+	WithGrantOption bool
+}
+
+// ReadGrant reads the privileges of the grant from information_schema,
+// the privileges are empty if nothing is granted
+func ReadGrant(ctx context.Context, db *sql.DB, user, database, table string) (*Grant, error) {
+	query := readSchemaPrivilegesStatement
+	args := []any{grantee(user), database}
+	if table != allTables {
+		query = readTablePrivilegesStatement
+		args = append(args, table)
+	}
+
+	log.Println("[DEBUG] MySQL: read grant query: ", query)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	g := &Grant{User: user, Database: database, Table: table, Privileges: make([]string, 0)}
+	for rows.Next() {
+		var privilege, grantable string
+		if err := rows.Scan(&privilege, &grantable); err != nil {
+			return nil, err
+		}
+		g.Privileges = append(g.Privileges, privilege)
+		g.WithGrantOption = g.WithGrantOption || grantable == "YES"
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Strings(g.Privileges)
+	return g, nil
+}
+
+// ApplyGrant revokes the privileges that are not in the new grant and grants the new ones
+func ApplyGrant(ctx context.Context, db *sql.DB, oldGrant, newGrant Grant) error {
+	for _, query := range grantStatements(oldGrant, newGrant) {
+		log.Println("[DEBUG] MySQL: grant query: ", query)
+		if _, err := db.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// grantStatements revokes first. The grant option applies to all the privileges of the target,
+// so all the privileges are granted again when it is added.
+func grantStatements(oldGrant, newGrant Grant) []string {
+	statements := make([]string, 0)
+	on := grantTargetStatement(newGrant)
+	to := grantee(newGrant.User)
+
+	if revoked := privilegesDiff(oldGrant.Privileges, newGrant.Privileges); len(revoked) > 0 {
+		statements = append(statements, fmt.Sprintf("REVOKE %s ON %s FROM %s", strings.Join(revoked, ", "), on, to))
+	}
+
+	if oldGrant.WithGrantOption && !newGrant.WithGrantOption {
+		statements = append(statements, fmt.Sprintf("REVOKE GRANT OPTION ON %s FROM %s", on, to))
+	}
+
+	granted := privilegesDiff(newGrant.Privileges, oldGrant.Privileges)
+	if newGrant.WithGrantOption && !oldGrant.WithGrantOption {
+		granted = newGrant.Privileges
+	}
+
+	if len(granted) > 0 {
+		query := fmt.Sprintf("GRANT %s ON %s TO %s", strings.Join(sortedPrivileges(granted), ", "), on, to)
+		if newGrant.WithGrantOption {
+			query += " WITH GRANT OPTION"
+		}
+		statements = append(statements, query)
+	}
+	return statements
+}
+
+// privilegesDiff returns the privileges of a that are not in b
+func privilegesDiff(a, b []string) []string {
+	diff := make([]string, 0)
+	for _, p := range a {
+		if !slices.Contains(b, p) {
+			diff = append(diff, p)
+		}
+	}
+	return sortedPrivileges(diff)
+}
+
+func sortedPrivileges(privileges []string) []string {
+	sorted := slices.Clone(privileges)
+	sort.Strings(sorted)
+	return sorted
+}
+
+func grantTargetStatement(g Grant) string {
+	if g.Table == allTables {
+		return quoteIdentifier(g.Database) + ".*"
+	}
+	return quoteIdentifier(g.Database) + "." + quoteIdentifier(g.Table)
+}
+
+// grantee returns the account as in information_schema, the users of the service can connect from any host
+func grantee(user string) string {
+	return quoteString(user) + "@'%'"
+}
+
+func quoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+func quoteString(s string) string {
+	return "'" + strings.ReplaceAll(strings.ReplaceAll(s, `\`, `\\`), "'", "''") + "'"
+}
+
+const readSchemaPrivilegesStatement = `
+SELECT PRIVILEGE_TYPE, IS_GRANTABLE FROM information_schema.SCHEMA_PRIVILEGES
+WHERE GRANTEE = ? AND TABLE_SCHEMA = ?`
+
+const readTablePrivilegesStatement = `
+SELECT PRIVILEGE_TYPE, IS_GRANTABLE FROM information_schema.TABLE_PRIVILEGES
+WHERE GRANTEE = ? AND TABLE_SCHEMA = ? AND TABLE_NAME = ?`
@@ -0,0 +1,101 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGrantStatements(t *testing.T) {
+	empty := Grant{User: "reader", Database: "app", Table: allTables}
+	grant := Grant{User: "reader", Database: "app", Table: allTables, Privileges: []string{"SELECT", "INSERT"}}
+
+	// Create
+	assert.Equal(t, []string{
+		"GRANT INSERT, SELECT ON `app`.* TO 'reader'@'%'",
+	}, grantStatements(empty, grant))
+
+	// Adds and removes privileges in place
+	updated := Grant{User: "reader", Database: "app", Table: allTables, Privileges: []string{"SELECT", "SHOW VIEW"}}
+	assert.Equal(t, []string{
+		"REVOKE INSERT ON `app`.* FROM 'reader'@'%'",
+		"GRANT SHOW VIEW ON `app`.* TO 'reader'@'%'",
+	}, grantStatements(grant, updated))
+
+	// The grant option is given with all the privileges
+	withGrantOption := updated
+	withGrantOption.WithGrantOption = true
+	assert.Equal(t, []string{
+		"GRANT SELECT, SHOW VIEW ON `app`.* TO 'reader'@'%' WITH GRANT OPTION",
+	}, grantStatements(updated, withGrantOption))
+	assert.Equal(t, []string{
+		"REVOKE GRANT OPTION ON `app`.* FROM 'reader'@'%'",
+	}, grantStatements(withGrantOption, updated))
+
+	// Delete
+	assert.Equal(t, []string{
+		"REVOKE SELECT, SHOW VIEW ON `app`.* FROM 'reader'@'%'",
+		"REVOKE GRANT OPTION ON `app`.* FROM 'reader'@'%'",
+	}, grantStatements(withGrantOption, empty))
+
+	table := Grant{User: "reader", Database: "app", Table: "events`", Privileges: []string{"SELECT"}}
+	assert.Equal(t, []string{
+		"GRANT SELECT ON `app`.`events``` TO 'reader'@'%'",
+	}, grantStatements(Grant{User: "reader", Database: "app", Table: "events`"}, table))
+}
+
+func TestGrantLocal(t *testing.T) {
+	dsn := os.Getenv("MYSQL_TEST_DSN")
+	if dsn == "" {
+		t.Skip("MYSQL_TEST_DSN is not set")
+	}
+
+	ctx := context.Background()
+	db, err := sql.Open("mysql", dsn)
+	require.NoError(t, err)
+	defer db.Close()
+
+	for _, q := range []string{
+		"DROP DATABASE IF EXISTS grant_test",
+		"DROP USER IF EXISTS 'grant_test_reader'@'%'",
+		"CREATE DATABASE grant_test",
+		"CREATE TABLE grant_test.events (id int)",
+		"CREATE USER 'grant_test_reader'@'%'",
+	} {
+		_, err := db.ExecContext(ctx, q)
+		require.NoError(t, err, q)
+	}
+	defer func() {
+		_, _ = db.ExecContext(ctx, "DROP DATABASE IF EXISTS grant_test")
+		_, _ = db.ExecContext(ctx, "DROP USER IF EXISTS 'grant_test_reader'@'%'")
+	}()
+
+	for _, table := range []string{allTables, "events"} {
+		empty := Grant{User: "grant_test_reader", Database: "grant_test", Table: table, Privileges: []string{}}
+		grant := Grant{User: "grant_test_reader", Database: "grant_test", Table: table, Privileges: []string{"INSERT", "SELECT"}}
+		require.NoError(t, ApplyGrant(ctx, db, empty, grant))
+
+		read, err := ReadGrant(ctx, db, grant.User, grant.Database, table)
+		require.NoError(t, err)
+		assert.Equal(t, grant, *read)
+
+		updated := Grant{
+			User: grant.User, Database: grant.Database, Table: table,
+			Privileges: []string{"SELECT", "UPDATE"}, WithGrantOption: true,
+		}
+		require.NoError(t, ApplyGrant(ctx, db, *read, updated))
+
+		read, err = ReadGrant(ctx, db, grant.User, grant.Database, table)
+		require.NoError(t, err)
+		assert.Equal(t, updated, *read)
+
+		require.NoError(t, ApplyGrant(ctx, db, *read, empty))
+		read, err = ReadGrant(ctx, db, grant.User, grant.Database, table)
+		require.NoError(t, err)
+		assert.Equal(t, empty, *read)
+	}
+}
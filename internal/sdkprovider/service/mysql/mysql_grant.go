@@ -0,0 +1,247 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"golang.org/x/exp/slices"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil/userconfig"
+)
+
+var aivenMySQLGrantSchema = map[string]*schema.Schema{
+	"project":      schemautil.CommonSchemaProjectReference,
+	"service_name": schemautil.CommonSchemaServiceNameReference,
+
+	"user": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: userconfig.Desc("The user the privileges are granted to.").Referenced().ForceNew().Build(),
+	},
+	"database": {
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringNotInSlice([]string{"*"}, false),
+		Description:  userconfig.Desc("The database the privileges are granted on.").Referenced().ForceNew().Build(),
+	},
+	"table": {
+		Type:     schema.TypeString,
+		Optional: true,
+		ForceNew: true,
+		Default:  allTables,
+		Description: userconfig.Desc("The table the privileges are granted on, `*` grants the privileges on the database.").
+			DefaultValue(allTables).ForceNew().Build(),
+	},
+	"privileges": {
+		Type:     schema.TypeSet,
+		Required: true,
+		MinItems: 1,
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validation.StringInSlice(mysqlDatabasePrivileges, false),
+		},
+		Description: userconfig.Desc("The privileges. A table has fewer privileges than a database, " +
+			"the routine, event, temporary table and lock privileges are granted on the database only.").
+			PossibleValues(schemautil.StringSliceToInterfaceSlice(mysqlDatabasePrivileges)...).Build(),
+	},
+	"with_grant_option": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: userconfig.Desc("Allows the user to grant the privileges to other users.").DefaultValue(false).Build(),
+	},
+}
+
+func ResourceMySQLGrant() *schema.Resource {
+	return &schema.Resource{
+		Description: `The MySQL Grant resource allows the management of the privileges of a user on a database or a table of an Aiven MySQL service.
+
+The provider connects to the service with the admin credentials of the service, so the service must be reachable from where Terraform runs.
+The privileges are read from ` + "`information_schema`" + `, the privileges granted outside of Terraform on the same database or table are shown as drift.
+`,
+		CreateContext: resourceMySQLGrantCreate,
+		ReadContext:   resourceMySQLGrantRead,
+		UpdateContext: resourceMySQLGrantUpdate,
+		DeleteContext: resourceMySQLGrantDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts:      schemautil.DefaultResourceTimeouts(),
+		CustomizeDiff: resourceMySQLGrantCustomizeDiff,
+
+		Schema: aivenMySQLGrantSchema,
+	}
+}
+
+// resourceMySQLGrantCustomizeDiff MySQL rejects the database privileges on a table only on apply
+func resourceMySQLGrantCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("table") || d.Get("table").(string) == allTables {
+		return nil
+	}
+
+	invalid := make([]string, 0)
+	for _, p := range d.Get("privileges").(*schema.Set).List() {
+		if !slices.Contains(mysqlTablePrivileges, p.(string)) {
+			invalid = append(invalid, p.(string))
+		}
+	}
+
+	if len(invalid) > 0 {
+		return fmt.Errorf(
+			"privileges %s can't be granted on a table, possible values are: %s",
+			strings.Join(sortedPrivileges(invalid), ", "), strings.Join(mysqlTablePrivileges, ", "),
+		)
+	}
+	return nil
+}
+
+func resourceMySQLGrantCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	grant := expandMySQLGrant(d.Get)
+
+	db, err := connectService(ctx, client, projectName, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer db.Close()
+
+	oldGrant := Grant{User: grant.User, Database: grant.Database, Table: grant.Table}
+	if err := ApplyGrant(ctx, db, oldGrant, grant); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(projectName, serviceName, grant.User, grant.Database, grant.Table))
+
+	return resourceMySQLGrantRead(ctx, d, m)
+}
+
+func resourceMySQLGrantRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	parts, err := schemautil.SplitResourceID(d.Id(), 5)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	projectName, serviceName, user, database, table := parts[0], parts[1], parts[2], parts[3], parts[4]
+
+	// The grants of a deleted user or database are gone too
+	if _, err := client.ServiceUsers.Get(ctx, projectName, serviceName, user); err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+	if _, err := client.Databases.Get(ctx, projectName, serviceName, database); err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	db, err := connectService(ctx, client, projectName, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer db.Close()
+
+	grant, err := ReadGrant(ctx, db, user, database, table)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("project", projectName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("user", grant.User); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("database", grant.Database); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("table", grant.Table); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("privileges", grant.Privileges); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("with_grant_option", grant.WithGrantOption); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceMySQLGrantUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+
+	db, err := connectService(ctx, client, projectName, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer db.Close()
+
+	oldGrant := expandMySQLGrant(func(k string) interface{} {
+		o, _ := d.GetChange(k)
+		return o
+	})
+	if err := ApplyGrant(ctx, db, oldGrant, expandMySQLGrant(d.Get)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceMySQLGrantRead(ctx, d, m)
+}
+
+func resourceMySQLGrantDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+
+	db, err := connectService(ctx, client, projectName, serviceName)
+	if err != nil {
+		if aiven.IsNotFound(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	defer db.Close()
+
+	// Revokes what is granted now, REVOKE fails on the privileges that are not granted
+	grant := expandMySQLGrant(d.Get)
+	current, err := ReadGrant(ctx, db, grant.User, grant.Database, grant.Table)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	newGrant := Grant{User: grant.User, Database: grant.Database, Table: grant.Table}
+	if err := ApplyGrant(ctx, db, *current, newGrant); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// expandMySQLGrant returns the grant of the config, get is d.Get or returns the old values on update
+func expandMySQLGrant(get func(string) interface{}) Grant {
+	privileges := make([]string, 0)
+	for _, p := range get("privileges").(*schema.Set).List() {
+		privileges = append(privileges, p.(string))
+	}
+
+	return Grant{
+		User:            get("user").(string),
+		Database:        get("database").(string),
+		Table:           get("table").(string),
+		Privileges:      sortedPrivileges(privileges),
+		WithGrantOption: get("with_grant_option").(bool),
+	}
+}
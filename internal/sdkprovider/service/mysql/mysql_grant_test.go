@@ -0,0 +1,94 @@
+package mysql_test
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+
+	acc "github.com/aiven/terraform-provider-aiven/internal/acctest"
+)
+
+func TestAccAivenMySQLGrant(t *testing.T) {
+	serviceName := fmt.Sprintf("test-acc-mysql-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	projectName := os.Getenv("AIVEN_PROJECT_NAME")
+	resourceName := "aiven_mysql_grant.foo"
+
+	manifest := func(grant string) string {
+		return fmt.Sprintf(`
+resource "aiven_mysql" "bar" {
+  project                 = "%s"
+  cloud_name              = "google-europe-west1"
+  plan                    = "startup-4"
+  service_name            = "%s"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+}
+
+resource "aiven_mysql_database" "foo" {
+  project       = aiven_mysql.bar.project
+  service_name  = aiven_mysql.bar.service_name
+  database_name = "app"
+}
+
+resource "aiven_mysql_user" "foo" {
+  project      = aiven_mysql.bar.project
+  service_name = aiven_mysql.bar.service_name
+  username     = "reader"
+}
+
+resource "aiven_mysql_grant" "foo" {
+  project      = aiven_mysql.bar.project
+  service_name = aiven_mysql.bar.service_name
+  user         = aiven_mysql_user.foo.username
+  database     = aiven_mysql_database.foo.database_name
+  %s
+}`, projectName, serviceName, grant)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acc.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acc.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: manifest(`
+  table      = "events"
+  privileges = ["EXECUTE"]`),
+				ExpectError: regexp.MustCompile(`privileges EXECUTE can't be granted on a table`),
+			},
+			{
+				Config: manifest(`privileges = ["SELECT", "INSERT"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "table", "*"),
+					resource.TestCheckResourceAttr(resourceName, "privileges.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "with_grant_option", "false"),
+				),
+			},
+			{
+				// Revokes and grants in place
+				Config: manifest(`
+  privileges        = ["SELECT", "SHOW VIEW"]
+  with_grant_option = true`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr(resourceName, "privileges.*", "SELECT"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "privileges.*", "SHOW VIEW"),
+					resource.TestCheckResourceAttr(resourceName, "with_grant_option", "true"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -15,6 +15,7 @@ var (
 
 // GETs the remote config, applies the modifiers and PUTs it again
 // The Config that is passed to the modifiers is guaranteed to be not nil
+// Always GETs the live config, so changes made out of band in the meantime are not overwritten
 func resourceOpenSearchACLModifyRemoteConfig(
 	ctx context.Context,
 	project string,
@@ -35,14 +36,17 @@ func resourceOpenSearchACLModifyRemoteConfig(
 		modifiers[i](&config)
 	}
 
-	_, err = client.OpenSearchACLs.Update(
+	rsp, err := client.OpenSearchACLs.Update(
 		ctx,
 		project,
 		serviceName,
 		aiven.OpenSearchACLRequest{OpenSearchACLConfig: config})
 	if err != nil {
+		invalidateOpenSearchACLConfig(project, serviceName)
 		return err
 	}
+
+	setOpenSearchACLConfig(project, serviceName, rsp.OpenSearchACLConfig)
 	return nil
 }
 
@@ -81,3 +85,9 @@ func resourceOpenSearchACLModifierToggleConfigFields(enabled, extednedACL bool)
 		cfg.ExtendedAcl = extednedACL
 	}
 }
+
+func resourceOpenSearchACLModifierReplaceACLRules(acls []aiven.OpenSearchACL) func(*aiven.OpenSearchACLConfig) {
+	return func(cfg *aiven.OpenSearchACLConfig) {
+		cfg.ACLs = acls
+	}
+}
@@ -0,0 +1,58 @@
+package opensearch
+
+import (
+	"context"
+	"time"
+
+	"github.com/aiven/aiven-go-client/v2"
+
+	"github.com/aiven/terraform-provider-aiven/internal/sdkprovider/ttlcache"
+)
+
+// openSearchACLCacheTTL how long the fetched ACL config of a service is considered fresh
+const openSearchACLCacheTTL = 30 * time.Second
+
+// openSearchACLConfigs ACL configs by service key
+var openSearchACLConfigs = ttlcache.New[aiven.OpenSearchACLConfig](openSearchACLCacheTTL, time.Now)
+
+// getOpenSearchACLConfig returns the service ACL config from the cache, or calls the API if it's missing or expired.
+// Every ACL rule, the rules and the config resources read the same config, so a plan with many rules makes one call.
+// Returns a copy, modifying it doesn't change the cache.
+func getOpenSearchACLConfig(ctx context.Context, client *aiven.Client, project, serviceName string) (*aiven.OpenSearchACLConfig, error) {
+	key := ttlcache.Key(project, serviceName)
+	config, err := openSearchACLConfigs.Get(ctx, key, func(ctx context.Context) (aiven.OpenSearchACLConfig, error) {
+		r, err := client.OpenSearchACLs.Get(ctx, project, serviceName)
+		if err != nil {
+			return aiven.OpenSearchACLConfig{}, err
+		}
+		return r.OpenSearchACLConfig, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return copyOpenSearchACLConfig(config), nil
+}
+
+// setOpenSearchACLConfig stores the config that was just written, so the following reads get it without a call
+func setOpenSearchACLConfig(project, serviceName string, config aiven.OpenSearchACLConfig) {
+	openSearchACLConfigs.Set(ttlcache.Key(project, serviceName), *copyOpenSearchACLConfig(config))
+}
+
+// invalidateOpenSearchACLConfig removes the config from the cache, so the next read gets the fresh state
+func invalidateOpenSearchACLConfig(project, serviceName string) {
+	openSearchACLConfigs.Invalidate(ttlcache.Key(project, serviceName))
+}
+
+// copyOpenSearchACLConfig deep copies the config, the modifiers change ACLs and rules in place
+func copyOpenSearchACLConfig(config aiven.OpenSearchACLConfig) *aiven.OpenSearchACLConfig {
+	result := config
+	result.ACLs = make([]aiven.OpenSearchACL, len(config.ACLs))
+	for i, acl := range config.ACLs {
+		result.ACLs[i] = aiven.OpenSearchACL{
+			Username: acl.Username,
+			Rules:    append([]aiven.OpenSearchACLRule(nil), acl.Rules...),
+		}
+	}
+	return &result
+}
@@ -0,0 +1,50 @@
+package opensearch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandOpenSearchACLRules(t *testing.T) {
+	rules := []interface{}{
+		map[string]interface{}{"username": "b", "index": "logs-*", "permission": "read"},
+		map[string]interface{}{"username": "a", "index": "z", "permission": "admin"},
+		map[string]interface{}{"username": "a", "index": "b", "permission": "readwrite"},
+	}
+
+	expected := []aiven.OpenSearchACL{
+		{Username: "a", Rules: []aiven.OpenSearchACLRule{{Index: "b", Permission: "readwrite"}, {Index: "z", Permission: "admin"}}},
+		{Username: "b", Rules: []aiven.OpenSearchACLRule{{Index: "logs-*", Permission: "read"}}},
+	}
+	acls := expandOpenSearchACLRules(rules)
+	assert.Equal(t, expected, acls)
+
+	flattened := flattenOpenSearchACLRules(acls)
+	assert.Len(t, flattened, 3)
+	assert.Contains(t, flattened, map[string]interface{}{"username": "b", "index": "logs-*", "permission": "read"})
+
+	assert.Empty(t, expandOpenSearchACLRules(nil))
+}
+
+func TestOpenSearchACLConfigCache(t *testing.T) {
+	config := aiven.OpenSearchACLConfig{
+		Enabled: true,
+		ACLs:    []aiven.OpenSearchACL{{Username: "a", Rules: []aiven.OpenSearchACLRule{{Index: "b", Permission: "read"}}}},
+	}
+	setOpenSearchACLConfig("project", "service", config)
+	defer invalidateOpenSearchACLConfig("project", "service")
+
+	// Cached, so the client is not called
+	cached, err := getOpenSearchACLConfig(context.Background(), nil, "project", "service")
+	assert.NoError(t, err)
+	assert.Equal(t, config, *cached)
+
+	// Returns a copy
+	cached.ACLs[0].Rules[0].Permission = "admin"
+	again, err := getOpenSearchACLConfig(context.Background(), nil, "project", "service")
+	assert.NoError(t, err)
+	assert.Equal(t, "read", again.ACLs[0].Rules[0].Permission)
+}
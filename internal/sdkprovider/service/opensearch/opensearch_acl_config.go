@@ -53,7 +53,7 @@ func resourceOpenSearchACLConfigRead(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(err)
 	}
 
-	r, err := client.OpenSearchACLs.Get(ctx, project, serviceName)
+	cfg, err := getOpenSearchACLConfig(ctx, client, project, serviceName)
 	if err != nil {
 		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
 	}
@@ -64,10 +64,10 @@ func resourceOpenSearchACLConfigRead(ctx context.Context, d *schema.ResourceData
 	if err := d.Set("service_name", serviceName); err != nil {
 		return diag.Errorf("error setting ACLs `service_name` for resource %s: %s", d.Id(), err)
 	}
-	if err := d.Set("extended_acl", r.OpenSearchACLConfig.ExtendedAcl); err != nil {
+	if err := d.Set("extended_acl", cfg.ExtendedAcl); err != nil {
 		return diag.Errorf("error setting ACLs `extended_acl` for resource %s: %s", d.Id(), err)
 	}
-	if err := d.Set("enabled", r.OpenSearchACLConfig.Enabled); err != nil {
+	if err := d.Set("enabled", cfg.Enabled); err != nil {
 		return diag.Errorf("error setting ACLs `enable` for resource %s: %s", d.Id(), err)
 	}
 	return nil
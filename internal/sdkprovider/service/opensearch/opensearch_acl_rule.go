@@ -75,11 +75,11 @@ func resourceOpenSearchACLRuleRead(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	r, err := client.OpenSearchACLs.Get(ctx, project, serviceName)
+	cfg, err := getOpenSearchACLConfig(ctx, client, project, serviceName)
 	if err != nil {
 		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
 	}
-	permission, found := resourceOpenSearchACLRuleGetPermissionFromACLResponse(r.OpenSearchACLConfig, username, index)
+	permission, found := resourceOpenSearchACLRuleGetPermissionFromACLResponse(*cfg, username, index)
 	if !found {
 		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
 	}
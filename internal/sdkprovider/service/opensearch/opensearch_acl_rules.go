@@ -0,0 +1,183 @@
+package opensearch
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil/userconfig"
+)
+
+var aivenOpenSearchACLRulesSchema = map[string]*schema.Schema{
+	"project":      schemautil.CommonSchemaProjectReference,
+	"service_name": schemautil.CommonSchemaServiceNameReference,
+	"rule": {
+		Type:     schema.TypeSet,
+		Optional: true,
+		Description: "ACL rules of the service. Rules that are not listed here are removed, " +
+			"rules added outside of Terraform are shown as drift.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"username": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: aivenOpenSearchACLRuleSchema["username"].ValidateFunc,
+					Description:  userconfig.Desc("The username for the ACL entry").MaxLen(40).Referenced().Build(),
+				},
+				"index": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: aivenOpenSearchACLRuleSchema["index"].ValidateFunc,
+					Description:  userconfig.Desc("The index pattern for this ACL entry.").MaxLen(249).Build(),
+				},
+				"permission": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: aivenOpenSearchACLRuleSchema["permission"].ValidateFunc,
+					Description:  aivenOpenSearchACLRuleSchema["permission"].Description,
+				},
+			},
+		},
+	},
+}
+
+func ResourceOpenSearchACLRules() *schema.Resource {
+	return &schema.Resource{
+		Description: "The OpenSearch ACL Rules resource manages all ACL rules of an Aiven OpenSearch service. " +
+			"The rules are written in a single update. Don't use it together with `aiven_opensearch_acl_rule` " +
+			"for the same service, they would remove each other's rules.",
+		CreateContext: resourceOpenSearchACLRulesUpdate,
+		ReadContext:   resourceOpenSearchACLRulesRead,
+		UpdateContext: resourceOpenSearchACLRulesUpdate,
+		DeleteContext: resourceOpenSearchACLRulesDelete,
+		CustomizeDiff: customizeDiffOpenSearchACLRules,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: schemautil.DefaultResourceTimeouts(),
+
+		Schema: aivenOpenSearchACLRulesSchema,
+	}
+}
+
+func resourceOpenSearchACLRulesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	project, serviceName, err := schemautil.SplitResourceID2(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	cfg, err := getOpenSearchACLConfig(ctx, m.(*aiven.Client), project, serviceName)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	if err := d.Set("project", project); err != nil {
+		return diag.Errorf("error setting ACL Rules `project` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.Errorf("error setting ACL Rules `service_name` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("rule", flattenOpenSearchACLRules(cfg.ACLs)); err != nil {
+		return diag.Errorf("error setting ACL Rules `rule` for resource %s: %s", d.Id(), err)
+	}
+	return nil
+}
+
+func resourceOpenSearchACLRulesUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	project := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+
+	acls := expandOpenSearchACLRules(d.Get("rule").(*schema.Set).List())
+	err := resourceOpenSearchACLModifyRemoteConfig(ctx, project, serviceName, client, resourceOpenSearchACLModifierReplaceACLRules(acls))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(project, serviceName))
+
+	return resourceOpenSearchACLRulesRead(ctx, d, m)
+}
+
+func resourceOpenSearchACLRulesDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	project, serviceName, err := schemautil.SplitResourceID2(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	modifier := resourceOpenSearchACLModifierReplaceACLRules(make([]aiven.OpenSearchACL, 0))
+	err = resourceOpenSearchACLModifyRemoteConfig(ctx, project, serviceName, m.(*aiven.Client), modifier)
+	if err != nil && !aiven.IsNotFound(err) {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// customizeDiffOpenSearchACLRules a user can have a single permission per index
+func customizeDiffOpenSearchACLRules(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("rule") {
+		return nil
+	}
+
+	seen := make(map[string]string)
+	for _, v := range d.Get("rule").(*schema.Set).List() {
+		r := v.(map[string]interface{})
+		username, index, permission := r["username"].(string), r["index"].(string), r["permission"].(string)
+		// Unknown values are empty
+		if username == "" || index == "" {
+			continue
+		}
+
+		key := schemautil.BuildResourceID(username, index)
+		if prev, ok := seen[key]; ok {
+			return fmt.Errorf("user %q has more than one permission for index %q: %s, %s", username, index, prev, permission)
+		}
+		seen[key] = permission
+	}
+	return nil
+}
+
+// expandOpenSearchACLRules groups the rules by username, sorted, so the config is written in the same order
+func expandOpenSearchACLRules(rules []interface{}) []aiven.OpenSearchACL {
+	byUser := make(map[string][]aiven.OpenSearchACLRule)
+	for _, v := range rules {
+		r := v.(map[string]interface{})
+		username := r["username"].(string)
+		byUser[username] = append(byUser[username], aiven.OpenSearchACLRule{
+			Index:      r["index"].(string),
+			Permission: r["permission"].(string),
+		})
+	}
+
+	acls := make([]aiven.OpenSearchACL, 0, len(byUser))
+	for username, userRules := range byUser {
+		sort.Slice(userRules, func(i, j int) bool {
+			return userRules[i].Index < userRules[j].Index
+		})
+		acls = append(acls, aiven.OpenSearchACL{Username: username, Rules: userRules})
+	}
+	sort.Slice(acls, func(i, j int) bool {
+		return acls[i].Username < acls[j].Username
+	})
+	return acls
+}
+
+func flattenOpenSearchACLRules(acls []aiven.OpenSearchACL) []map[string]interface{} {
+	rules := make([]map[string]interface{}, 0)
+	for _, acl := range acls {
+		for _, r := range acl.Rules {
+			rules = append(rules, map[string]interface{}{
+				"username":   acl.Username,
+				"index":      r.Index,
+				"permission": r.Permission,
+			})
+		}
+	}
+	return rules
+}
@@ -0,0 +1,187 @@
+package opensearch_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	acc "github.com/aiven/terraform-provider-aiven/internal/acctest"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+)
+
+func TestAccAivenOpenSearchACLRules_basic(t *testing.T) {
+	resourceName := "aiven_opensearch_acl_rules.foo"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	serviceName := fmt.Sprintf("test-acc-sr-aclrules-%s", rName)
+	username := fmt.Sprintf("user-%s", rName)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acc.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acc.TestProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAivenOpenSearchACLRulesResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpenSearchACLRulesResource(rName, `
+  rule {
+    username   = aiven_opensearch_user.foo.username
+    index      = "index-a"
+    permission = "readwrite"
+  }
+
+  rule {
+    username   = aiven_opensearch_user.foo.username
+    index      = "index-b*"
+    permission = "read"
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "project", os.Getenv("AIVEN_PROJECT_NAME")),
+					resource.TestCheckResourceAttr(resourceName, "service_name", serviceName),
+					resource.TestCheckResourceAttr(resourceName, "rule.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "rule.*", map[string]string{
+						"username":   username,
+						"index":      "index-b*",
+						"permission": "read",
+					}),
+				),
+			},
+			{
+				// A rule added out of band is shown as drift
+				PreConfig: func() {
+					err := testAccAddOpenSearchACLRule(os.Getenv("AIVEN_PROJECT_NAME"), serviceName, username, "index-c")
+					if err != nil {
+						t.Fatal(err)
+					}
+					// The provider runs in the test process and caches the ACL config for 30 seconds
+					time.Sleep(30 * time.Second)
+				},
+				Config: testAccOpenSearchACLRulesResource(rName, `
+  rule {
+    username   = aiven_opensearch_user.foo.username
+    index      = "index-a"
+    permission = "readwrite"
+  }
+
+  rule {
+    username   = aiven_opensearch_user.foo.username
+    index      = "index-b*"
+    permission = "read"
+  }`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				// Removes the out of band rule and changes the permission
+				Config: testAccOpenSearchACLRulesResource(rName, `
+  rule {
+    username   = aiven_opensearch_user.foo.username
+    index      = "index-a"
+    permission = "admin"
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "rule.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "rule.*", map[string]string{
+						"username":   username,
+						"index":      "index-a",
+						"permission": "admin",
+					}),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccOpenSearchACLRulesResource(name, rules string) string {
+	return fmt.Sprintf(`
+data "aiven_project" "foo" {
+  project = "%s"
+}
+
+resource "aiven_opensearch" "bar" {
+  project                 = data.aiven_project.foo.project
+  cloud_name              = "google-europe-west1"
+  plan                    = "startup-4"
+  service_name            = "test-acc-sr-aclrules-%s"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+}
+
+resource "aiven_opensearch_user" "foo" {
+  service_name = aiven_opensearch.bar.service_name
+  project      = data.aiven_project.foo.project
+  username     = "user-%s"
+}
+
+resource "aiven_opensearch_acl_config" "foo" {
+  project      = data.aiven_project.foo.project
+  service_name = aiven_opensearch.bar.service_name
+  enabled      = true
+  extended_acl = false
+}
+
+resource "aiven_opensearch_acl_rules" "foo" {
+  project      = data.aiven_project.foo.project
+  service_name = aiven_opensearch.bar.service_name
+  %s
+}`, os.Getenv("AIVEN_PROJECT_NAME"), name, name, rules)
+}
+
+// testAccAddOpenSearchACLRule adds a rule bypassing Terraform
+func testAccAddOpenSearchACLRule(project, serviceName, username, index string) error {
+	c := acc.GetTestAivenClient()
+	ctx := context.Background()
+
+	r, err := c.OpenSearchACLs.Get(ctx, project, serviceName)
+	if err != nil {
+		return err
+	}
+
+	cfg := r.OpenSearchACLConfig
+	cfg.Add(aiven.OpenSearchACL{
+		Username: username,
+		Rules:    []aiven.OpenSearchACLRule{{Index: index, Permission: "read"}},
+	})
+	_, err = c.OpenSearchACLs.Update(ctx, project, serviceName, aiven.OpenSearchACLRequest{OpenSearchACLConfig: cfg})
+	return err
+}
+
+func testAccCheckAivenOpenSearchACLRulesResourceDestroy(s *terraform.State) error {
+	c := acc.GetTestAivenClient()
+
+	ctx := context.Background()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aiven_opensearch_acl_rules" {
+			continue
+		}
+
+		projectName, serviceName, err := schemautil.SplitResourceID2(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		r, err := c.OpenSearchACLs.Get(ctx, projectName, serviceName)
+		if err != nil {
+			if aiven.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		if len(r.OpenSearchACLConfig.ACLs) > 0 {
+			return fmt.Errorf("opensearch acl rules (%s) still exist", rs.Primary.ID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// openSearchSecurityAdminUsername the security plugin admin user, see aiven_opensearch_security_plugin_config
+	openSearchSecurityAdminUsername = "os-sec-admin"
+	// openSearchSecurityAdminPasswordEnvVar provides the admin password on import, it isn't stored by Aiven
+	openSearchSecurityAdminPasswordEnvVar = "AIVEN_OPENSEARCH_SECURITY_ADMIN_PASSWORD"
+	openSearchSecurityAPIPath             = "/_plugins/_security/api"
+)
+
+// openSearchClient calls the OpenSearch REST API on the service with the os-sec-admin credentials,
+// e.g. the security plugin, snapshot and ISM APIs. aiven.Client doesn't proxy these calls, they go to the service itself.
+type openSearchClient struct {
+	baseURL  string
+	password string
+	client   *http.Client
+}
+
+// newOpenSearchClient returns the client for the service, the host and port are read from the service URI
+func newOpenSearchClient(
+	ctx context.Context,
+	client *aiven.Client,
+	project, serviceName, password string,
+) (*openSearchClient, error) {
+	s, err := client.Services.Get(ctx, project, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	host, port := s.URIParams["host"], s.URIParams["port"]
+	if host == "" || port == "" {
+		return nil, fmt.Errorf("service %s/%s has no host and port to call the OpenSearch API", project, serviceName)
+	}
+
+	return &openSearchClient{
+		baseURL:  fmt.Sprintf("https://%s:%s", host, port),
+		password: password,
+		client:   client.Client,
+	}, nil
+}
+
+// do calls the service endpoint, e.g. "/_plugins/_security/api/roles/foo".
+// Returns aiven.Error on non-2xx status, so aiven.IsNotFound can be used.
+func (c *openSearchClient) do(ctx context.Context, method, path string, in, out any) error {
+	var body []byte
+	if in != nil {
+		var err error
+		body, err = json.Marshal(in)
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(openSearchSecurityAdminUsername, c.password)
+
+	rsp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := rsp.Body.Close(); err != nil {
+			log.Printf("[WARNING] cannot close response body: %s", err)
+		}
+	}()
+
+	b, err := io.ReadAll(rsp.Body)
+	if err != nil || rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
+		return aiven.Error{Message: string(b), Status: rsp.StatusCode}
+	}
+
+	if out == nil || len(b) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(b, out)
+}
+
+// openSearchSnapshotRepository a snapshot repository, settings values are strings in the API responses
+type openSearchSnapshotRepository struct {
+	Type     string            `json:"type"`
+	Settings map[string]string `json:"settings"`
+}
+
+func (c *openSearchClient) getSnapshotRepository(ctx context.Context, name string) (*openSearchSnapshotRepository, error) {
+	rsp := make(map[string]*openSearchSnapshotRepository)
+	err := c.do(ctx, http.MethodGet, "/_snapshot/"+url.PathEscape(name), nil, &rsp)
+	if err != nil {
+		return nil, err
+	}
+
+	repo, ok := rsp[name]
+	if !ok || repo == nil {
+		return nil, aiven.Error{Status: http.StatusNotFound, Message: fmt.Sprintf("OpenSearch snapshot repository %q not found", name)}
+	}
+	return repo, nil
+}
+
+func (c *openSearchClient) putSnapshotRepository(ctx context.Context, name string, repo *openSearchSnapshotRepository) error {
+	return c.do(ctx, http.MethodPut, "/_snapshot/"+url.PathEscape(name), repo, nil)
+}
+
+func (c *openSearchClient) deleteSnapshotRepository(ctx context.Context, name string) error {
+	return c.do(ctx, http.MethodDelete, "/_snapshot/"+url.PathEscape(name), nil, nil)
+}
+
+// openSearchISMPolicy an Index State Management policy,
+// SeqNo and PrimaryTerm are required to update it
+type openSearchISMPolicy struct {
+	ID          string          `json:"_id"`
+	SeqNo       int64           `json:"_seq_no"`
+	PrimaryTerm int64           `json:"_primary_term"`
+	Policy      json.RawMessage `json:"policy"`
+}
+
+func (c *openSearchClient) getISMPolicy(ctx context.Context, id string) (*openSearchISMPolicy, error) {
+	rsp := new(openSearchISMPolicy)
+	err := c.do(ctx, http.MethodGet, "/_plugins/_ism/policies/"+url.PathEscape(id), nil, rsp)
+	if err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+// putISMPolicy creates the policy, or updates it when seqNo and primaryTerm of the current version are given
+func (c *openSearchClient) putISMPolicy(ctx context.Context, id string, policy json.RawMessage, seqNo, primaryTerm *int64) error {
+	path := "/_plugins/_ism/policies/" + url.PathEscape(id)
+	if seqNo != nil && primaryTerm != nil {
+		path += fmt.Sprintf("?if_seq_no=%d&if_primary_term=%d", *seqNo, *primaryTerm)
+	}
+
+	in := map[string]json.RawMessage{"policy": policy}
+	return c.do(ctx, http.MethodPut, path, in, nil)
+}
+
+func (c *openSearchClient) deleteISMPolicy(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/_plugins/_ism/policies/"+url.PathEscape(id), nil, nil)
+}
+
+// openSearchSecurityAdminPasswordSchema the os-sec-admin password used to call the OpenSearch API
+var openSearchSecurityAdminPasswordSchema = &schema.Schema{
+	Type:      schema.TypeString,
+	Required:  true,
+	Sensitive: true,
+	Description: "The password for the os-sec-admin user, see `aiven_opensearch_security_plugin_config`. " +
+		"Aiven doesn't store it, so it must be set in the `" + openSearchSecurityAdminPasswordEnvVar +
+		"` environment variable on import.",
+}
+
+// resourceOpenSearchSecurityImport imports by "project/service_name/name",
+// the admin password is taken from the environment, because it can't be read from the API
+func resourceOpenSearchSecurityImport(_ context.Context, d *schema.ResourceData, _ any) ([]*schema.ResourceData, error) {
+	password, ok := os.LookupEnv(openSearchSecurityAdminPasswordEnvVar)
+	if !ok || password == "" {
+		return nil, fmt.Errorf("%s must be set to the os-sec-admin password to import", openSearchSecurityAdminPasswordEnvVar)
+	}
+
+	if err := d.Set("admin_password", password); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
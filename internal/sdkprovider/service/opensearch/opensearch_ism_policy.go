@@ -0,0 +1,238 @@
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/aiven/terraform-provider-aiven/internal/common"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil/userconfig"
+)
+
+var aivenOpenSearchISMPolicySchema = map[string]*schema.Schema{
+	"project":        schemautil.CommonSchemaProjectReference,
+	"service_name":   schemautil.CommonSchemaServiceNameReference,
+	"admin_password": openSearchSecurityAdminPasswordSchema,
+	"policy_id": {
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringLenBetween(1, 255),
+		Description:  userconfig.Desc("The ID of the Index State Management policy.").ForceNew().Build(),
+	},
+	"policy": {
+		Type:         schema.TypeString,
+		Required:     true,
+		ValidateFunc: validation.StringIsJSON,
+		StateFunc:    normalizeOpenSearchISMPolicyString,
+		Description: "The policy as a JSON object: `description`, `default_state`, `states` and `ism_template`, " +
+			"without the `policy` wrapper. Fields set by OpenSearch, e.g. `last_updated_time`, are ignored.",
+	},
+}
+
+func ResourceOpenSearchISMPolicy() *schema.Resource {
+	return &schema.Resource{
+		Description: "The OpenSearch ISM Policy resource manages an Index State Management policy. " +
+			"It calls the OpenSearch API of the service with the os-sec-admin credentials, " +
+			"so `aiven_opensearch_security_plugin_config` must be created first.",
+		CreateContext: resourceOpenSearchISMPolicyCreate,
+		ReadContext:   resourceOpenSearchISMPolicyRead,
+		UpdateContext: resourceOpenSearchISMPolicyUpdate,
+		DeleteContext: resourceOpenSearchISMPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceOpenSearchSecurityImport,
+		},
+		Timeouts: schemautil.DefaultResourceTimeouts(),
+
+		Schema: aivenOpenSearchISMPolicySchema,
+	}
+}
+
+func resourceOpenSearchISMPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	project := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	policyID := d.Get("policy_id").(string)
+
+	c, err := newOpenSearchClient(ctx, m.(*aiven.Client), project, serviceName, d.Get("admin_password").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Without the sequence number the API refuses to overwrite an existing policy
+	if err := c.putISMPolicy(ctx, policyID, json.RawMessage(d.Get("policy").(string)), nil, nil); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(project, serviceName, policyID))
+
+	return resourceOpenSearchISMPolicyRead(ctx, d, m)
+}
+
+func resourceOpenSearchISMPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	project, serviceName, policyID, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c, err := newOpenSearchClient(ctx, m.(*aiven.Client), project, serviceName, d.Get("admin_password").(string))
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	policy, err := c.getISMPolicy(ctx, policyID)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	normalized, err := normalizeOpenSearchISMPolicy(policy.Policy)
+	if err != nil {
+		return diag.Errorf("error reading OpenSearch ISM policy %s: %s", d.Id(), err)
+	}
+
+	if err := d.Set("project", project); err != nil {
+		return diag.Errorf("error setting `project` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.Errorf("error setting `service_name` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("policy_id", policyID); err != nil {
+		return diag.Errorf("error setting `policy_id` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("policy", normalized); err != nil {
+		return diag.Errorf("error setting `policy` for resource %s: %s", d.Id(), err)
+	}
+	return nil
+}
+
+func resourceOpenSearchISMPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	project, serviceName, policyID, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// A new admin password alone doesn't change the policy
+	if d.HasChange("policy") {
+		c, err := newOpenSearchClient(ctx, m.(*aiven.Client), project, serviceName, d.Get("admin_password").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		// The update must refer to the current version of the policy
+		current, err := c.getISMPolicy(ctx, policyID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		err = c.putISMPolicy(
+			ctx, policyID, json.RawMessage(d.Get("policy").(string)), &current.SeqNo, &current.PrimaryTerm,
+		)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceOpenSearchISMPolicyRead(ctx, d, m)
+}
+
+func resourceOpenSearchISMPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	project, serviceName, policyID, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c, err := newOpenSearchClient(ctx, m.(*aiven.Client), project, serviceName, d.Get("admin_password").(string))
+	if common.IsCritical(err) {
+		return diag.FromErr(err)
+	}
+	if err != nil {
+		// The service is gone with its policies
+		return nil
+	}
+
+	err = c.deleteISMPolicy(ctx, policyID)
+	if common.IsCritical(err) {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// openSearchISMDefaultRetry is added by OpenSearch to every action that doesn't set it
+var openSearchISMDefaultRetry = map[string]any{
+	"count":   json.Number("3"),
+	"backoff": "exponential",
+	"delay":   "1m",
+}
+
+// normalizeOpenSearchISMPolicy removes the fields OpenSearch sets on its own,
+// so the policy read from the API equals the policy in the config.
+// The keys are sorted, numbers are kept as they are.
+func normalizeOpenSearchISMPolicy(b []byte) (string, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var policy map[string]any
+	if err := dec.Decode(&policy); err != nil {
+		return "", err
+	}
+
+	for _, k := range []string{"policy_id", "last_updated_time", "schema_version"} {
+		delete(policy, k)
+	}
+
+	for k, v := range policy {
+		if v == nil {
+			delete(policy, k)
+		}
+	}
+
+	// ism_template is an object or a list of objects
+	templates, ok := policy["ism_template"].([]any)
+	if !ok {
+		templates = []any{policy["ism_template"]}
+	}
+	for _, t := range templates {
+		if t, ok := t.(map[string]any); ok {
+			delete(t, "last_updated_time")
+		}
+	}
+
+	states, _ := policy["states"].([]any)
+	for _, s := range states {
+		s, ok := s.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		actions, _ := s["actions"].([]any)
+		for _, a := range actions {
+			a, ok := a.(map[string]any)
+			if ok && reflect.DeepEqual(a["retry"], openSearchISMDefaultRetry) {
+				delete(a, "retry")
+			}
+		}
+	}
+
+	out, err := json.Marshal(policy)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// normalizeOpenSearchISMPolicyString is the StateFunc of the policy, invalid JSON is stored as it is
+func normalizeOpenSearchISMPolicyString(i any) string {
+	v := i.(string)
+
+	if n, err := normalizeOpenSearchISMPolicy([]byte(v)); err == nil {
+		return n
+	}
+
+	return v
+}
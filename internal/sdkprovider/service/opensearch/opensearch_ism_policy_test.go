@@ -0,0 +1,108 @@
+package opensearch_test
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	acc "github.com/aiven/terraform-provider-aiven/internal/acctest"
+)
+
+func TestAccAivenOpenSearchISMPolicy_basic(t *testing.T) {
+	resourceName := "aiven_opensearch_ism_policy.foo"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acc.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acc.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpenSearchISMPolicyResource(rName, "30d"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "project", os.Getenv("AIVEN_PROJECT_NAME")),
+					resource.TestCheckResourceAttr(resourceName, "policy_id", "delete-old-logs"),
+				),
+			},
+			{
+				// Fields added by OpenSearch don't cause drift
+				Config:             testAccOpenSearchISMPolicyResource(rName, "30d"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				// Updates with the current sequence number
+				Config: testAccOpenSearchISMPolicyResource(rName, "7d"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrWith(resourceName, "policy", func(v string) error {
+						if !regexp.MustCompile(`"min_index_age":"7d"`).MatchString(v) {
+							return fmt.Errorf("policy is not updated: %s", v)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				// The admin password isn't stored by Aiven, import reads it from the environment
+				PreConfig:         testAccSetOpenSearchSecurityAdminPassword(t),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccOpenSearchISMPolicyResource(name, minIndexAge string) string {
+	return fmt.Sprintf(`
+data "aiven_project" "foo" {
+  project = "%s"
+}
+
+resource "aiven_opensearch" "bar" {
+  project                 = data.aiven_project.foo.project
+  cloud_name              = "google-europe-west1"
+  plan                    = "startup-4"
+  service_name            = "test-acc-sr-os-ism-%[2]s"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+}
+
+resource "aiven_opensearch_security_plugin_config" "foo" {
+  project        = data.aiven_project.foo.project
+  service_name   = aiven_opensearch.bar.service_name
+  admin_password = "%[3]s"
+}
+
+resource "aiven_opensearch_ism_policy" "foo" {
+  project        = aiven_opensearch_security_plugin_config.foo.project
+  service_name   = aiven_opensearch_security_plugin_config.foo.service_name
+  admin_password = aiven_opensearch_security_plugin_config.foo.admin_password
+  policy_id      = "delete-old-logs"
+  policy = jsonencode({
+    description   = "Deletes old logs"
+    default_state = "hot"
+    states = [
+      {
+        name    = "hot"
+        actions = []
+        transitions = [
+          {
+            state_name = "delete"
+            conditions = { min_index_age = "%[4]s" }
+          }
+        ]
+      },
+      {
+        name        = "delete"
+        actions     = [{ delete = {} }]
+        transitions = []
+      }
+    ]
+    ism_template = [{ index_patterns = ["logs-*"], priority = 100 }]
+  })
+}`, os.Getenv("AIVEN_PROJECT_NAME"), name, openSearchTestPassword, minIndexAge)
+}
@@ -0,0 +1,86 @@
+package opensearch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/aiven/aiven-go-client/v2"
+)
+
+// openSearchSecurityRole a security plugin role, Reserved and Static roles can't be changed
+type openSearchSecurityRole struct {
+	Description        string                               `json:"description,omitempty"`
+	ClusterPermissions []string                             `json:"cluster_permissions"`
+	IndexPermissions   []openSearchSecurityIndexPermission  `json:"index_permissions"`
+	TenantPermissions  []openSearchSecurityTenantPermission `json:"tenant_permissions"`
+	Reserved           bool                                 `json:"reserved,omitempty"`
+	Static             bool                                 `json:"static,omitempty"`
+}
+
+type openSearchSecurityIndexPermission struct {
+	IndexPatterns  []string `json:"index_patterns"`
+	AllowedActions []string `json:"allowed_actions"`
+	DLS            string   `json:"dls,omitempty"`
+	FLS            []string `json:"fls,omitempty"`
+	MaskedFields   []string `json:"masked_fields,omitempty"`
+}
+
+type openSearchSecurityTenantPermission struct {
+	TenantPatterns []string `json:"tenant_patterns"`
+	AllowedActions []string `json:"allowed_actions"`
+}
+
+// openSearchSecurityRoleMapping maps users, backend roles and hosts to a role
+type openSearchSecurityRoleMapping struct {
+	Description  string   `json:"description,omitempty"`
+	Users        []string `json:"users"`
+	BackendRoles []string `json:"backend_roles"`
+	Hosts        []string `json:"hosts"`
+	Reserved     bool     `json:"reserved,omitempty"`
+}
+
+func (c *openSearchClient) getRole(ctx context.Context, name string) (*openSearchSecurityRole, error) {
+	rsp := make(map[string]*openSearchSecurityRole)
+	err := c.do(ctx, http.MethodGet, openSearchSecurityAPIPath+"/roles/"+url.PathEscape(name), nil, &rsp)
+	if err != nil {
+		return nil, err
+	}
+
+	role, ok := rsp[name]
+	if !ok || role == nil {
+		return nil, aiven.Error{Status: http.StatusNotFound, Message: fmt.Sprintf("OpenSearch security role %q not found", name)}
+	}
+	return role, nil
+}
+
+func (c *openSearchClient) putRole(ctx context.Context, name string, role *openSearchSecurityRole) error {
+	return c.do(ctx, http.MethodPut, openSearchSecurityAPIPath+"/roles/"+url.PathEscape(name), role, nil)
+}
+
+func (c *openSearchClient) deleteRole(ctx context.Context, name string) error {
+	return c.do(ctx, http.MethodDelete, openSearchSecurityAPIPath+"/roles/"+url.PathEscape(name), nil, nil)
+}
+
+func (c *openSearchClient) getRoleMapping(ctx context.Context, role string) (*openSearchSecurityRoleMapping, error) {
+	rsp := make(map[string]*openSearchSecurityRoleMapping)
+	err := c.do(ctx, http.MethodGet, openSearchSecurityAPIPath+"/rolesmapping/"+url.PathEscape(role), nil, &rsp)
+	if err != nil {
+		return nil, err
+	}
+
+	mapping, ok := rsp[role]
+	if !ok || mapping == nil {
+		return nil, aiven.Error{Status: http.StatusNotFound, Message: fmt.Sprintf("OpenSearch security role mapping %q not found", role)}
+	}
+	return mapping, nil
+}
+
+func (c *openSearchClient) putRoleMapping(ctx context.Context, role string, mapping *openSearchSecurityRoleMapping) error {
+	return c.do(ctx, http.MethodPut, openSearchSecurityAPIPath+"/rolesmapping/"+url.PathEscape(role), mapping, nil)
+}
+
+func (c *openSearchClient) deleteRoleMapping(ctx context.Context, role string) error {
+	return c.do(ctx, http.MethodDelete, openSearchSecurityAPIPath+"/rolesmapping/"+url.PathEscape(role), nil, nil)
+}
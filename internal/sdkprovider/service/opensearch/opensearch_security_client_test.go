@@ -0,0 +1,190 @@
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newOpenSearchSecurityStandIn emulates the security plugin API, stores the documents by path
+func newOpenSearchSecurityStandIn(t *testing.T, password string) *httptest.Server {
+	var mu sync.Mutex
+	docs := make(map[string]json.RawMessage)
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		username, pass, ok := r.BasicAuth()
+		if !ok || username != "os-sec-admin" || pass != password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		path := strings.TrimPrefix(r.URL.Path, "/_plugins/_security/api/")
+		name := path[strings.LastIndex(path, "/")+1:]
+		switch r.Method {
+		case http.MethodGet:
+			doc, ok := docs[path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"status":"NOT_FOUND","message":"Resource '` + name + `' not found."}`))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]json.RawMessage{name: doc})
+		case http.MethodPut:
+			b, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			docs[path] = b
+			_, _ = w.Write([]byte(`{"status":"CREATED"}`))
+		case http.MethodDelete:
+			if _, ok := docs[path]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			delete(docs, path)
+			_, _ = w.Write([]byte(`{"status":"OK"}`))
+		}
+	}))
+}
+
+func TestOpenSearchSecurityClientRole(t *testing.T) {
+	server := newOpenSearchSecurityStandIn(t, "secret")
+	defer server.Close()
+
+	ctx := context.Background()
+	c := &openSearchClient{baseURL: server.URL, password: "secret", client: server.Client()}
+
+	_, err := c.getRole(ctx, "logs-reader")
+	assert.True(t, aiven.IsNotFound(err))
+
+	d := schema.TestResourceDataRaw(t, aivenOpenSearchSecurityRoleSchema, map[string]interface{}{
+		"role_name":           "logs-reader",
+		"description":         "Reads logs",
+		"cluster_permissions": []interface{}{"cluster_monitor"},
+		"index_permissions": []interface{}{
+			map[string]interface{}{
+				"index_patterns":  []interface{}{"logs-*"},
+				"allowed_actions": []interface{}{"read"},
+				"dls":             `{"term": {"public": true}}`,
+				"masked_fields":   []interface{}{"ip"},
+			},
+		},
+	})
+	require.NoError(t, c.putRole(ctx, "logs-reader", expandOpenSearchSecurityRole(d)))
+
+	role, err := c.getRole(ctx, "logs-reader")
+	require.NoError(t, err)
+	assert.Equal(t, &openSearchSecurityRole{
+		Description:        "Reads logs",
+		ClusterPermissions: []string{"cluster_monitor"},
+		IndexPermissions: []openSearchSecurityIndexPermission{{
+			IndexPatterns:  []string{"logs-*"},
+			AllowedActions: []string{"read"},
+			DLS:            `{"term": {"public": true}}`,
+			MaskedFields:   []string{"ip"},
+		}},
+		TenantPermissions: []openSearchSecurityTenantPermission{},
+	}, role)
+
+	// Flattened role is the same as the config
+	read := schema.TestResourceDataRaw(t, aivenOpenSearchSecurityRoleSchema, map[string]interface{}{})
+	require.NoError(t, flattenOpenSearchSecurityRole(read, role))
+	for _, k := range []string{"cluster_permissions", "index_permissions.0.index_patterns", "index_permissions.0.masked_fields", "index_permissions.0.fls"} {
+		assert.True(t, d.Get(k).(*schema.Set).Equal(read.Get(k)), k)
+	}
+	assert.Equal(t, d.Get("index_permissions.0.dls"), read.Get("index_permissions.0.dls"))
+
+	require.NoError(t, c.deleteRole(ctx, "logs-reader"))
+	assert.True(t, aiven.IsNotFound(c.deleteRole(ctx, "logs-reader")))
+}
+
+func TestOpenSearchSecurityClientRoleMapping(t *testing.T) {
+	server := newOpenSearchSecurityStandIn(t, "secret")
+	defer server.Close()
+
+	ctx := context.Background()
+	c := &openSearchClient{baseURL: server.URL, password: "secret", client: server.Client()}
+
+	d := schema.TestResourceDataRaw(t, aivenOpenSearchSecurityRoleMappingSchema, map[string]interface{}{
+		"role_name":     "logs-reader",
+		"users":         []interface{}{"alice", "bob"},
+		"backend_roles": []interface{}{"analysts"},
+	})
+	require.NoError(t, c.putRoleMapping(ctx, "logs-reader", expandOpenSearchSecurityRoleMapping(d)))
+
+	mapping, err := c.getRoleMapping(ctx, "logs-reader")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"alice", "bob"}, mapping.Users)
+	assert.Equal(t, []string{"analysts"}, mapping.BackendRoles)
+	assert.Equal(t, []string{}, mapping.Hosts)
+
+	// Wrong password
+	wrong := &openSearchClient{baseURL: server.URL, password: "wrong", client: server.Client()}
+	_, err = wrong.getRoleMapping(ctx, "logs-reader")
+	var e aiven.Error
+	require.ErrorAs(t, err, &e)
+	assert.Equal(t, http.StatusUnauthorized, e.Status)
+}
+
+func TestNormalizeOpenSearchISMPolicy(t *testing.T) {
+	config := `{
+  "description": "Deletes old logs",
+  "default_state": "hot",
+  "states": [
+    {"name": "hot", "actions": [], "transitions": [{"state_name": "delete", "conditions": {"min_index_age": "30d"}}]},
+    {"name": "delete", "actions": [{"delete": {}}], "transitions": []}
+  ],
+  "ism_template": [{"index_patterns": ["logs-*"], "priority": 100}]
+}`
+
+	// The same policy read from the API
+	read := `{
+  "policy_id": "logs",
+  "description": "Deletes old logs",
+  "last_updated_time": 1706000000000,
+  "schema_version": 19,
+  "error_notification": null,
+  "default_state": "hot",
+  "states": [
+    {"name": "hot", "actions": [], "transitions": [{"state_name": "delete", "conditions": {"min_index_age": "30d"}}]},
+    {"name": "delete", "actions": [{"retry": {"count": 3, "backoff": "exponential", "delay": "1m"}, "delete": {}}], "transitions": []}
+  ],
+  "ism_template": [{"index_patterns": ["logs-*"], "priority": 100, "last_updated_time": 1706000000000}]
+}`
+
+	expected, err := normalizeOpenSearchISMPolicy([]byte(config))
+	require.NoError(t, err)
+
+	actual, err := normalizeOpenSearchISMPolicy([]byte(read))
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	// A custom retry is a change
+	custom := strings.Replace(read, `"count": 3`, `"count": 5`, 1)
+	actual, err = normalizeOpenSearchISMPolicy([]byte(custom))
+	require.NoError(t, err)
+	assert.NotEqual(t, expected, actual)
+
+	// Invalid JSON is stored as it is
+	assert.Equal(t, "{", normalizeOpenSearchISMPolicyString("{"))
+}
+
+func TestFlattenOpenSearchSnapshotRepositorySettings(t *testing.T) {
+	settings, secureSettings := flattenOpenSearchSnapshotRepositorySettings(
+		map[string]string{"bucket": "snapshots", "region": "eu-west-1", "access_key": "key"},
+		map[string]interface{}{"access_key": "old", "secret_key": "secret"},
+	)
+	assert.Equal(t, map[string]string{"bucket": "snapshots", "region": "eu-west-1"}, settings)
+	assert.Equal(t, map[string]string{"access_key": "key", "secret_key": "secret"}, secureSettings)
+}
@@ -0,0 +1,266 @@
+package opensearch
+
+import (
+	"context"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/aiven/terraform-provider-aiven/internal/common"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil/userconfig"
+)
+
+// openSearchSecurityStringSet a set of strings, e.g. permissions or patterns
+func openSearchSecurityStringSet(required bool, description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Required:    required,
+		Optional:    !required,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: description,
+	}
+}
+
+var aivenOpenSearchSecurityRoleSchema = map[string]*schema.Schema{
+	"project":        schemautil.CommonSchemaProjectReference,
+	"service_name":   schemautil.CommonSchemaServiceNameReference,
+	"admin_password": openSearchSecurityAdminPasswordSchema,
+	"role_name": {
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringLenBetween(1, 255),
+		Description:  userconfig.Desc("The name of the role.").ForceNew().Build(),
+	},
+	"description": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "The role description.",
+	},
+	"cluster_permissions": openSearchSecurityStringSet(false, "Cluster-wide permissions and action groups, e.g. `cluster_monitor`."),
+	"index_permissions": {
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "Index permissions of the role.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"index_patterns":  openSearchSecurityStringSet(true, "Index patterns the permissions apply to, e.g. `logs-*`."),
+				"allowed_actions": openSearchSecurityStringSet(true, "Allowed actions and action groups, e.g. `read`."),
+				"dls": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Document-level security query, a JSON query that limits the documents the role can read.",
+				},
+				"fls":           openSearchSecurityStringSet(false, "Field-level security, the fields the role can read, or can't read if prefixed with `~`."),
+				"masked_fields": openSearchSecurityStringSet(false, "Fields which values are returned hashed."),
+			},
+		},
+	},
+	"tenant_permissions": {
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "OpenSearch Dashboards tenant permissions of the role.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"tenant_patterns": openSearchSecurityStringSet(true, "Tenant patterns the permissions apply to."),
+				"allowed_actions": openSearchSecurityStringSet(true, "Allowed actions, `kibana_all_read` or `kibana_all_write`."),
+			},
+		},
+	},
+}
+
+func ResourceOpenSearchSecurityRole() *schema.Resource {
+	return &schema.Resource{
+		Description: "The OpenSearch Security Role resource manages a role of the OpenSearch Security Plugin. " +
+			"It calls the security plugin API of the service with the os-sec-admin credentials, " +
+			"so `aiven_opensearch_security_plugin_config` must be created first.",
+		CreateContext: resourceOpenSearchSecurityRoleCreate,
+		ReadContext:   resourceOpenSearchSecurityRoleRead,
+		UpdateContext: resourceOpenSearchSecurityRoleUpdate,
+		DeleteContext: resourceOpenSearchSecurityRoleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceOpenSearchSecurityImport,
+		},
+		Timeouts: schemautil.DefaultResourceTimeouts(),
+
+		Schema: aivenOpenSearchSecurityRoleSchema,
+	}
+}
+
+func resourceOpenSearchSecurityRoleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	project := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	roleName := d.Get("role_name").(string)
+
+	c, err := newOpenSearchClient(ctx, m.(*aiven.Client), project, serviceName, d.Get("admin_password").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// PUT overwrites, so an existing role must not be taken over silently
+	_, err = c.getRole(ctx, roleName)
+	if common.IsCritical(err) {
+		return diag.FromErr(err)
+	}
+	if err == nil {
+		return diag.Errorf("OpenSearch security role %q already exists, import it instead", roleName)
+	}
+
+	if err := c.putRole(ctx, roleName, expandOpenSearchSecurityRole(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(project, serviceName, roleName))
+
+	return resourceOpenSearchSecurityRoleRead(ctx, d, m)
+}
+
+func resourceOpenSearchSecurityRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	project, serviceName, roleName, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c, err := newOpenSearchClient(ctx, m.(*aiven.Client), project, serviceName, d.Get("admin_password").(string))
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	role, err := c.getRole(ctx, roleName)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	if err := d.Set("project", project); err != nil {
+		return diag.Errorf("error setting `project` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.Errorf("error setting `service_name` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("role_name", roleName); err != nil {
+		return diag.Errorf("error setting `role_name` for resource %s: %s", d.Id(), err)
+	}
+	if err := flattenOpenSearchSecurityRole(d, role); err != nil {
+		return diag.Errorf("error setting OpenSearch security role for resource %s: %s", d.Id(), err)
+	}
+	return nil
+}
+
+func resourceOpenSearchSecurityRoleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	project, serviceName, roleName, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// A new admin password alone doesn't change the role
+	if d.HasChangeExcept("admin_password") {
+		c, err := newOpenSearchClient(ctx, m.(*aiven.Client), project, serviceName, d.Get("admin_password").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := c.putRole(ctx, roleName, expandOpenSearchSecurityRole(d)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceOpenSearchSecurityRoleRead(ctx, d, m)
+}
+
+func resourceOpenSearchSecurityRoleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	project, serviceName, roleName, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c, err := newOpenSearchClient(ctx, m.(*aiven.Client), project, serviceName, d.Get("admin_password").(string))
+	if common.IsCritical(err) {
+		return diag.FromErr(err)
+	}
+	if err != nil {
+		// The service is gone with its roles
+		return nil
+	}
+
+	err = c.deleteRole(ctx, roleName)
+	if common.IsCritical(err) {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func expandOpenSearchSecurityRole(d *schema.ResourceData) *openSearchSecurityRole {
+	role := &openSearchSecurityRole{
+		Description:        d.Get("description").(string),
+		ClusterPermissions: expandOpenSearchSecurityStringSet(d.Get("cluster_permissions")),
+		IndexPermissions:   make([]openSearchSecurityIndexPermission, 0),
+		TenantPermissions:  make([]openSearchSecurityTenantPermission, 0),
+	}
+
+	for _, v := range d.Get("index_permissions").([]interface{}) {
+		p := v.(map[string]interface{})
+		role.IndexPermissions = append(role.IndexPermissions, openSearchSecurityIndexPermission{
+			IndexPatterns:  expandOpenSearchSecurityStringSet(p["index_patterns"]),
+			AllowedActions: expandOpenSearchSecurityStringSet(p["allowed_actions"]),
+			DLS:            p["dls"].(string),
+			FLS:            expandOpenSearchSecurityStringSet(p["fls"]),
+			MaskedFields:   expandOpenSearchSecurityStringSet(p["masked_fields"]),
+		})
+	}
+
+	for _, v := range d.Get("tenant_permissions").([]interface{}) {
+		p := v.(map[string]interface{})
+		role.TenantPermissions = append(role.TenantPermissions, openSearchSecurityTenantPermission{
+			TenantPatterns: expandOpenSearchSecurityStringSet(p["tenant_patterns"]),
+			AllowedActions: expandOpenSearchSecurityStringSet(p["allowed_actions"]),
+		})
+	}
+	return role
+}
+
+func flattenOpenSearchSecurityRole(d *schema.ResourceData, role *openSearchSecurityRole) error {
+	indexPermissions := make([]map[string]interface{}, len(role.IndexPermissions))
+	for i, p := range role.IndexPermissions {
+		indexPermissions[i] = map[string]interface{}{
+			"index_patterns":  p.IndexPatterns,
+			"allowed_actions": p.AllowedActions,
+			"dls":             p.DLS,
+			"fls":             p.FLS,
+			"masked_fields":   p.MaskedFields,
+		}
+	}
+
+	tenantPermissions := make([]map[string]interface{}, len(role.TenantPermissions))
+	for i, p := range role.TenantPermissions {
+		tenantPermissions[i] = map[string]interface{}{
+			"tenant_patterns": p.TenantPatterns,
+			"allowed_actions": p.AllowedActions,
+		}
+	}
+
+	if err := d.Set("description", role.Description); err != nil {
+		return err
+	}
+	if err := d.Set("cluster_permissions", role.ClusterPermissions); err != nil {
+		return err
+	}
+	if err := d.Set("index_permissions", indexPermissions); err != nil {
+		return err
+	}
+	if err := d.Set("tenant_permissions", tenantPermissions); err != nil {
+		return err
+	}
+	return nil
+}
+
+// expandOpenSearchSecurityStringSet returns the set as a slice, empty set is an empty slice, as the API expects
+func expandOpenSearchSecurityStringSet(v interface{}) []string {
+	set, ok := v.(*schema.Set)
+	if !ok {
+		return make([]string, 0)
+	}
+	return schemautil.FlattenToString(set.List())
+}
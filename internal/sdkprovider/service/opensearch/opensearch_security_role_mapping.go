@@ -0,0 +1,174 @@
+package opensearch
+
+import (
+	"context"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/aiven/terraform-provider-aiven/internal/common"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil/userconfig"
+)
+
+var aivenOpenSearchSecurityRoleMappingSchema = map[string]*schema.Schema{
+	"project":        schemautil.CommonSchemaProjectReference,
+	"service_name":   schemautil.CommonSchemaServiceNameReference,
+	"admin_password": openSearchSecurityAdminPasswordSchema,
+	"role_name": {
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringLenBetween(1, 255),
+		Description:  userconfig.Desc("The name of the role to map, a built-in role or `aiven_opensearch_security_role`.").ForceNew().Build(),
+	},
+	"description": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "The role mapping description.",
+	},
+	"users":         openSearchSecurityStringSet(false, "Users that get the role."),
+	"backend_roles": openSearchSecurityStringSet(false, "Backend roles that get the role, e.g. SAML or OpenID groups."),
+	"hosts":         openSearchSecurityStringSet(false, "Hosts that get the role."),
+}
+
+func ResourceOpenSearchSecurityRoleMapping() *schema.Resource {
+	return &schema.Resource{
+		Description: "The OpenSearch Security Role Mapping resource maps users, backend roles and hosts " +
+			"to a role of the OpenSearch Security Plugin. " +
+			"It calls the security plugin API of the service with the os-sec-admin credentials, " +
+			"so `aiven_opensearch_security_plugin_config` must be created first.",
+		CreateContext: resourceOpenSearchSecurityRoleMappingCreate,
+		ReadContext:   resourceOpenSearchSecurityRoleMappingRead,
+		UpdateContext: resourceOpenSearchSecurityRoleMappingUpdate,
+		DeleteContext: resourceOpenSearchSecurityRoleMappingDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceOpenSearchSecurityImport,
+		},
+		Timeouts: schemautil.DefaultResourceTimeouts(),
+
+		Schema: aivenOpenSearchSecurityRoleMappingSchema,
+	}
+}
+
+func resourceOpenSearchSecurityRoleMappingCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	project := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	roleName := d.Get("role_name").(string)
+
+	c, err := newOpenSearchClient(ctx, m.(*aiven.Client), project, serviceName, d.Get("admin_password").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// PUT overwrites, so an existing mapping must not be taken over silently
+	_, err = c.getRoleMapping(ctx, roleName)
+	if common.IsCritical(err) {
+		return diag.FromErr(err)
+	}
+	if err == nil {
+		return diag.Errorf("OpenSearch security role mapping %q already exists, import it instead", roleName)
+	}
+
+	if err := c.putRoleMapping(ctx, roleName, expandOpenSearchSecurityRoleMapping(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(project, serviceName, roleName))
+
+	return resourceOpenSearchSecurityRoleMappingRead(ctx, d, m)
+}
+
+func resourceOpenSearchSecurityRoleMappingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	project, serviceName, roleName, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c, err := newOpenSearchClient(ctx, m.(*aiven.Client), project, serviceName, d.Get("admin_password").(string))
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	mapping, err := c.getRoleMapping(ctx, roleName)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	if err := d.Set("project", project); err != nil {
+		return diag.Errorf("error setting `project` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.Errorf("error setting `service_name` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("role_name", roleName); err != nil {
+		return diag.Errorf("error setting `role_name` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("description", mapping.Description); err != nil {
+		return diag.Errorf("error setting `description` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("users", mapping.Users); err != nil {
+		return diag.Errorf("error setting `users` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("backend_roles", mapping.BackendRoles); err != nil {
+		return diag.Errorf("error setting `backend_roles` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("hosts", mapping.Hosts); err != nil {
+		return diag.Errorf("error setting `hosts` for resource %s: %s", d.Id(), err)
+	}
+	return nil
+}
+
+func resourceOpenSearchSecurityRoleMappingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	project, serviceName, roleName, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// A new admin password alone doesn't change the mapping
+	if d.HasChangeExcept("admin_password") {
+		c, err := newOpenSearchClient(ctx, m.(*aiven.Client), project, serviceName, d.Get("admin_password").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := c.putRoleMapping(ctx, roleName, expandOpenSearchSecurityRoleMapping(d)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceOpenSearchSecurityRoleMappingRead(ctx, d, m)
+}
+
+func resourceOpenSearchSecurityRoleMappingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	project, serviceName, roleName, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c, err := newOpenSearchClient(ctx, m.(*aiven.Client), project, serviceName, d.Get("admin_password").(string))
+	if common.IsCritical(err) {
+		return diag.FromErr(err)
+	}
+	if err != nil {
+		// The service is gone with its role mappings
+		return nil
+	}
+
+	err = c.deleteRoleMapping(ctx, roleName)
+	if common.IsCritical(err) {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func expandOpenSearchSecurityRoleMapping(d *schema.ResourceData) *openSearchSecurityRoleMapping {
+	return &openSearchSecurityRoleMapping{
+		Description:  d.Get("description").(string),
+		Users:        expandOpenSearchSecurityStringSet(d.Get("users")),
+		BackendRoles: expandOpenSearchSecurityStringSet(d.Get("backend_roles")),
+		Hosts:        expandOpenSearchSecurityStringSet(d.Get("hosts")),
+	}
+}
@@ -0,0 +1,121 @@
+package opensearch_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	acc "github.com/aiven/terraform-provider-aiven/internal/acctest"
+)
+
+func TestAccAivenOpenSearchSecurityRole_basic(t *testing.T) {
+	roleResourceName := "aiven_opensearch_security_role.foo"
+	mappingResourceName := "aiven_opensearch_security_role_mapping.foo"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acc.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acc.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpenSearchSecurityRoleResource(rName, "read"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(roleResourceName, "project", os.Getenv("AIVEN_PROJECT_NAME")),
+					resource.TestCheckResourceAttr(roleResourceName, "role_name", "logs-reader"),
+					resource.TestCheckResourceAttr(roleResourceName, "description", "Reads logs"),
+					resource.TestCheckResourceAttr(roleResourceName, "index_permissions.#", "1"),
+					resource.TestCheckResourceAttr(roleResourceName, "index_permissions.0.dls", `{"term":{"public":true}}`),
+					resource.TestCheckTypeSetElemAttr(roleResourceName, "index_permissions.0.allowed_actions.*", "read"),
+					resource.TestCheckResourceAttr(mappingResourceName, "role_name", "logs-reader"),
+					resource.TestCheckTypeSetElemAttr(mappingResourceName, "users.*", fmt.Sprintf("user-%s", rName)),
+				),
+			},
+			{
+				Config: testAccOpenSearchSecurityRoleResource(rName, "search"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(roleResourceName, "index_permissions.0.allowed_actions.#", "1"),
+					resource.TestCheckTypeSetElemAttr(roleResourceName, "index_permissions.0.allowed_actions.*", "search"),
+				),
+			},
+			{
+				// The admin password isn't stored by Aiven, import reads it from the environment
+				PreConfig:         testAccSetOpenSearchSecurityAdminPassword(t),
+				ResourceName:      roleResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig:         testAccSetOpenSearchSecurityAdminPassword(t),
+				ResourceName:      mappingResourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testAccSetOpenSearchSecurityAdminPassword sets the import password,
+// t.Setenv can't be used in parallel tests
+func testAccSetOpenSearchSecurityAdminPassword(t *testing.T) func() {
+	return func() {
+		if err := os.Setenv("AIVEN_OPENSEARCH_SECURITY_ADMIN_PASSWORD", openSearchTestPassword); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func testAccOpenSearchSecurityRoleResource(name, action string) string {
+	return fmt.Sprintf(`
+data "aiven_project" "foo" {
+  project = "%s"
+}
+
+resource "aiven_opensearch" "bar" {
+  project                 = data.aiven_project.foo.project
+  cloud_name              = "google-europe-west1"
+  plan                    = "startup-4"
+  service_name            = "test-acc-sr-os-sec-role-%[2]s"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+}
+
+resource "aiven_opensearch_user" "foo" {
+  service_name = aiven_opensearch.bar.service_name
+  project      = data.aiven_project.foo.project
+  username     = "user-%[2]s"
+}
+
+resource "aiven_opensearch_security_plugin_config" "foo" {
+  project        = data.aiven_project.foo.project
+  service_name   = aiven_opensearch.bar.service_name
+  admin_password = "%[3]s"
+
+  depends_on = [aiven_opensearch.bar, aiven_opensearch_user.foo]
+}
+
+resource "aiven_opensearch_security_role" "foo" {
+  project             = aiven_opensearch_security_plugin_config.foo.project
+  service_name        = aiven_opensearch_security_plugin_config.foo.service_name
+  admin_password      = aiven_opensearch_security_plugin_config.foo.admin_password
+  role_name           = "logs-reader"
+  description         = "Reads logs"
+  cluster_permissions = ["cluster_monitor"]
+
+  index_permissions {
+    index_patterns  = ["logs-*"]
+    allowed_actions = ["%[4]s"]
+    dls             = jsonencode({ term = { public = true } })
+  }
+}
+
+resource "aiven_opensearch_security_role_mapping" "foo" {
+  project        = aiven_opensearch_security_role.foo.project
+  service_name   = aiven_opensearch_security_role.foo.service_name
+  admin_password = aiven_opensearch_security_role.foo.admin_password
+  role_name      = aiven_opensearch_security_role.foo.role_name
+  users          = [aiven_opensearch_user.foo.username]
+}`, os.Getenv("AIVEN_PROJECT_NAME"), name, openSearchTestPassword, action)
+}
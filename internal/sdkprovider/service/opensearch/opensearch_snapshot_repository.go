@@ -0,0 +1,222 @@
+package opensearch
+
+import (
+	"context"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/aiven/terraform-provider-aiven/internal/common"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil/userconfig"
+)
+
+var openSearchSnapshotRepositoryTypes = []string{"s3", "gcs", "azure"}
+
+var aivenOpenSearchSnapshotRepositorySchema = map[string]*schema.Schema{
+	"project":        schemautil.CommonSchemaProjectReference,
+	"service_name":   schemautil.CommonSchemaServiceNameReference,
+	"admin_password": openSearchSecurityAdminPasswordSchema,
+	"repository_name": {
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringLenBetween(1, 255),
+		Description:  userconfig.Desc("The name of the snapshot repository.").ForceNew().Build(),
+	},
+	"type": {
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringInSlice(openSearchSnapshotRepositoryTypes, false),
+		Description: userconfig.Desc("The repository type.").
+			ForceNew().
+			PossibleValues(schemautil.StringSliceToInterfaceSlice(openSearchSnapshotRepositoryTypes)...).
+			Build(),
+	},
+	"settings": {
+		Type:        schema.TypeMap,
+		Required:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "The repository settings, e.g. `bucket`, `base_path` and `region`.",
+	},
+	"secure_settings": {
+		Type:      schema.TypeMap,
+		Optional:  true,
+		Sensitive: true,
+		Elem:      &schema.Schema{Type: schema.TypeString},
+		Description: "The repository settings hidden in the plan output, e.g. `access_key` and `secret_key`. " +
+			"They are sent along with `settings`.",
+	},
+}
+
+func ResourceOpenSearchSnapshotRepository() *schema.Resource {
+	return &schema.Resource{
+		Description: "The OpenSearch Snapshot Repository resource manages a custom S3, GCS or Azure snapshot repository. " +
+			"It calls the OpenSearch API of the service with the os-sec-admin credentials, " +
+			"so `aiven_opensearch_security_plugin_config` must be created first.",
+		CreateContext: resourceOpenSearchSnapshotRepositoryCreate,
+		ReadContext:   resourceOpenSearchSnapshotRepositoryRead,
+		UpdateContext: resourceOpenSearchSnapshotRepositoryUpdate,
+		DeleteContext: resourceOpenSearchSnapshotRepositoryDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceOpenSearchSecurityImport,
+		},
+		Timeouts: schemautil.DefaultResourceTimeouts(),
+
+		Schema: aivenOpenSearchSnapshotRepositorySchema,
+	}
+}
+
+func resourceOpenSearchSnapshotRepositoryCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	project := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	name := d.Get("repository_name").(string)
+
+	c, err := newOpenSearchClient(ctx, m.(*aiven.Client), project, serviceName, d.Get("admin_password").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// PUT overwrites, so an existing repository must not be taken over silently
+	_, err = c.getSnapshotRepository(ctx, name)
+	if common.IsCritical(err) {
+		return diag.FromErr(err)
+	}
+	if err == nil {
+		return diag.Errorf("OpenSearch snapshot repository %q already exists, import it instead", name)
+	}
+
+	if err := c.putSnapshotRepository(ctx, name, expandOpenSearchSnapshotRepository(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(project, serviceName, name))
+
+	return resourceOpenSearchSnapshotRepositoryRead(ctx, d, m)
+}
+
+func resourceOpenSearchSnapshotRepositoryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	project, serviceName, name, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c, err := newOpenSearchClient(ctx, m.(*aiven.Client), project, serviceName, d.Get("admin_password").(string))
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	repo, err := c.getSnapshotRepository(ctx, name)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	if err := d.Set("project", project); err != nil {
+		return diag.Errorf("error setting `project` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.Errorf("error setting `service_name` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("repository_name", name); err != nil {
+		return diag.Errorf("error setting `repository_name` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("type", repo.Type); err != nil {
+		return diag.Errorf("error setting `type` for resource %s: %s", d.Id(), err)
+	}
+
+	settings, secureSettings := flattenOpenSearchSnapshotRepositorySettings(
+		repo.Settings, d.Get("secure_settings").(map[string]interface{}),
+	)
+	if err := d.Set("settings", settings); err != nil {
+		return diag.Errorf("error setting `settings` for resource %s: %s", d.Id(), err)
+	}
+	if err := d.Set("secure_settings", secureSettings); err != nil {
+		return diag.Errorf("error setting `secure_settings` for resource %s: %s", d.Id(), err)
+	}
+	return nil
+}
+
+func resourceOpenSearchSnapshotRepositoryUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	project, serviceName, name, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// A new admin password alone doesn't change the repository
+	if d.HasChangeExcept("admin_password") {
+		c, err := newOpenSearchClient(ctx, m.(*aiven.Client), project, serviceName, d.Get("admin_password").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := c.putSnapshotRepository(ctx, name, expandOpenSearchSnapshotRepository(d)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceOpenSearchSnapshotRepositoryRead(ctx, d, m)
+}
+
+func resourceOpenSearchSnapshotRepositoryDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	project, serviceName, name, err := schemautil.SplitResourceID3(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	c, err := newOpenSearchClient(ctx, m.(*aiven.Client), project, serviceName, d.Get("admin_password").(string))
+	if common.IsCritical(err) {
+		return diag.FromErr(err)
+	}
+	if err != nil {
+		// The service is gone with its repositories
+		return nil
+	}
+
+	// Snapshots in the bucket are kept, only the repository is unregistered
+	err = c.deleteSnapshotRepository(ctx, name)
+	if common.IsCritical(err) {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func expandOpenSearchSnapshotRepository(d *schema.ResourceData) *openSearchSnapshotRepository {
+	repo := &openSearchSnapshotRepository{
+		Type:     d.Get("type").(string),
+		Settings: make(map[string]string),
+	}
+
+	for _, k := range []string{"settings", "secure_settings"} {
+		for name, v := range d.Get(k).(map[string]interface{}) {
+			repo.Settings[name] = v.(string)
+		}
+	}
+	return repo
+}
+
+// flattenOpenSearchSnapshotRepositorySettings splits the settings read from the API,
+// the keys set in secure_settings stay there. Secrets that aren't returned are kept as they are
+func flattenOpenSearchSnapshotRepositorySettings(
+	in map[string]string,
+	secure map[string]interface{},
+) (map[string]string, map[string]string) {
+	settings := make(map[string]string)
+	secureSettings := make(map[string]string)
+	for k, v := range in {
+		if _, ok := secure[k]; ok {
+			secureSettings[k] = v
+			continue
+		}
+		settings[k] = v
+	}
+
+	for k, v := range secure {
+		if _, ok := secureSettings[k]; !ok {
+			secureSettings[k] = v.(string)
+		}
+	}
+	return settings, secureSettings
+}
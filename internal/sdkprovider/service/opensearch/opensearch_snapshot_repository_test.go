@@ -0,0 +1,96 @@
+package opensearch_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	acc "github.com/aiven/terraform-provider-aiven/internal/acctest"
+)
+
+func TestAccAivenOpenSearchSnapshotRepository_basic(t *testing.T) {
+	bucket := os.Getenv("AIVEN_OPENSEARCH_SNAPSHOT_S3_BUCKET")
+	if bucket == "" || os.Getenv("AWS_ACCESS_KEY_ID") == "" || os.Getenv("AWS_SECRET_ACCESS_KEY") == "" {
+		t.Skip("AIVEN_OPENSEARCH_SNAPSHOT_S3_BUCKET, AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment variables are required to run this test")
+	}
+
+	resourceName := "aiven_opensearch_snapshot_repository.foo"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acc.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acc.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpenSearchSnapshotRepositoryResource(rName, bucket, "opensearch"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "project", os.Getenv("AIVEN_PROJECT_NAME")),
+					resource.TestCheckResourceAttr(resourceName, "repository_name", "s3-snapshots"),
+					resource.TestCheckResourceAttr(resourceName, "type", "s3"),
+					resource.TestCheckResourceAttr(resourceName, "settings.bucket", bucket),
+					resource.TestCheckResourceAttr(resourceName, "settings.base_path", "opensearch"),
+				),
+			},
+			{
+				// Settings changed in place
+				Config: testAccOpenSearchSnapshotRepositoryResource(rName, bucket, "opensearch-"+rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "settings.base_path", "opensearch-"+rName),
+				),
+			},
+			{
+				// The admin password isn't stored by Aiven, import reads it from the environment.
+				// The secrets are not returned by the API
+				PreConfig:               testAccSetOpenSearchSecurityAdminPassword(t),
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"secure_settings"},
+			},
+		},
+	})
+}
+
+func testAccOpenSearchSnapshotRepositoryResource(name, bucket, basePath string) string {
+	return fmt.Sprintf(`
+data "aiven_project" "foo" {
+  project = "%s"
+}
+
+resource "aiven_opensearch" "bar" {
+  project                 = data.aiven_project.foo.project
+  cloud_name              = "google-europe-west1"
+  plan                    = "startup-4"
+  service_name            = "test-acc-sr-os-snap-%[2]s"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+}
+
+resource "aiven_opensearch_security_plugin_config" "foo" {
+  project        = data.aiven_project.foo.project
+  service_name   = aiven_opensearch.bar.service_name
+  admin_password = "%[3]s"
+}
+
+resource "aiven_opensearch_snapshot_repository" "foo" {
+  project         = aiven_opensearch_security_plugin_config.foo.project
+  service_name    = aiven_opensearch_security_plugin_config.foo.service_name
+  admin_password  = aiven_opensearch_security_plugin_config.foo.admin_password
+  repository_name = "s3-snapshots"
+  type            = "s3"
+
+  settings = {
+    bucket    = "%[4]s"
+    base_path = "%[5]s"
+  }
+
+  secure_settings = {
+    access_key = "%[6]s"
+    secret_key = "%[7]s"
+  }
+}`, os.Getenv("AIVEN_PROJECT_NAME"), name, openSearchTestPassword, bucket, basePath,
+		os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY"))
+}
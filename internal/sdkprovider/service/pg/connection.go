@@ -0,0 +1,98 @@
+package pg
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/aiven/aiven-go-client/v2"
+	_ "github.com/lib/pq" // registers the "postgres" driver
+)
+
+// connectServiceDatabase opens a connection to the database with the admin credentials of the service.
+// The server certificate is verified with the CA of the project. The caller must close it.
+func connectServiceDatabase(ctx context.Context, client *aiven.Client, projectName, serviceName, database string) (*sql.DB, error) {
+	s, err := client.Services.Get(ctx, projectName, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(s.ConnectionInfo.PostgresParams) == 0 {
+		return nil, fmt.Errorf("service %s/%s has no PostgreSQL connection info", projectName, serviceName)
+	}
+
+	ca, err := client.CA.Get(ctx, projectName)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get the CA certificate of project %s: %w", projectName, err)
+	}
+
+	rootCert, err := writeCACertificate(ca)
+	if err != nil {
+		return nil, fmt.Errorf("cannot write the CA certificate of project %s: %w", projectName, err)
+	}
+
+	params := s.ConnectionInfo.PostgresParams[0]
+	uri := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(params.User, params.Password),
+		Host:     net.JoinHostPort(params.Host, params.Port),
+		Path:     "/" + database,
+		RawQuery: url.Values{"sslmode": {"verify-full"}, "sslrootcert": {rootCert}}.Encode(),
+	}
+
+	db, err := sql.Open("postgres", uri.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("cannot connect to %s/%s database %q: %w", projectName, serviceName, database, err)
+	}
+	return db, nil
+}
+
+// writeCACertificate returns the path of the file with the CA certificate.
+// The driver reads the root certificate from a file on every connection, the inline one requires a client certificate.
+// The file is named by the certificate hash, so it is written once and shared by the connections to the project.
+// An existing file is used only if it has the same certificate, the temporary directory might be shared
+func writeCACertificate(ca string) (string, error) {
+	if !x509.NewCertPool().AppendCertsFromPEM([]byte(ca)) {
+		return "", fmt.Errorf("cannot parse the CA certificate")
+	}
+
+	sum := sha256.Sum256([]byte(ca))
+	path := filepath.Join(os.TempDir(), "aiven-ca-"+hex.EncodeToString(sum[:])+".pem")
+	b, err := os.ReadFile(path)
+	switch {
+	case err == nil && string(b) == ca:
+		return path, nil
+	case err != nil && !errors.Is(err, fs.ErrNotExist):
+		return "", err
+	}
+
+	// Written to a temporary file and renamed, so a concurrent connection never reads a partial file
+	f, err := os.CreateTemp(os.TempDir(), "aiven-ca-*.pem")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.WriteString(ca); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+	return path, os.Rename(f.Name(), path)
+}
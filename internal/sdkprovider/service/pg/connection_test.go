@@ -0,0 +1,61 @@
+package pg
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCACertificate(t *testing.T) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Project CA"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestWriteCACertificate(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+	ca := testCACertificate(t)
+
+	path, err := writeCACertificate(ca)
+	require.NoError(t, err)
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, ca, string(b))
+
+	// The same file is shared
+	again, err := writeCACertificate(ca)
+	require.NoError(t, err)
+	assert.Equal(t, path, again)
+
+	// A file with another certificate is replaced
+	require.NoError(t, os.WriteFile(path, []byte(testCACertificate(t)), 0o600))
+	_, err = writeCACertificate(ca)
+	require.NoError(t, err)
+	b, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, ca, string(b))
+
+	_, err = writeCACertificate("not a certificate")
+	assert.EqualError(t, err, "cannot parse the CA certificate")
+}
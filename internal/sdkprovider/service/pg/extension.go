@@ -0,0 +1,184 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/lib/pq"
+	"golang.org/x/exp/slices"
+
+	"github.com/aiven/terraform-provider-aiven/internal/common"
+)
+
+// Extension an extension installed in a database
+type Extension struct {
+	Name    string
+	Schema  string
+	Version string
+}
+
+// pgAvailableExtension an extension Aiven allows to install, with its versions.
+// aiven.Client doesn't support these yet, see common.DoRequest
+type pgAvailableExtension struct {
+	Name     string   `json:"name"`
+	Versions []string `json:"versions"`
+}
+
+type pgAvailableExtensionsResponse struct {
+	PG []struct {
+		Version    string                 `json:"version"`
+		Extensions []pgAvailableExtension `json:"extensions"`
+	} `json:"pg"`
+}
+
+// listPGAvailableExtensions returns the extensions Aiven allows by the PostgreSQL major version
+func listPGAvailableExtensions(ctx context.Context, client *aiven.Client, projectName string) (map[string][]pgAvailableExtension, error) {
+	path := fmt.Sprintf("/project/%s/pg/available-extensions", url.PathEscape(projectName))
+	rsp := new(pgAvailableExtensionsResponse)
+	err := common.DoRequest(ctx, client, http.MethodGet, path, nil, rsp)
+	if err != nil {
+		return nil, err
+	}
+
+	extensions := make(map[string][]pgAvailableExtension, len(rsp.PG))
+	for _, pg := range rsp.PG {
+		extensions[pg.Version] = pg.Extensions
+	}
+	return extensions, nil
+}
+
+// servicePGVersion returns the PostgreSQL major version of the service,
+// the user config doesn't have it if it was never set, then it is taken from the metadata
+func servicePGVersion(s *aiven.Service) (string, error) {
+	if v, ok := s.UserConfig["pg_version"].(string); ok && v != "" {
+		return v, nil
+	}
+
+	if metadata, ok := s.Metadata.(map[string]interface{}); ok {
+		if v, ok := metadata["pg_version"].(string); ok && v != "" {
+			return strings.Split(v, ".")[0], nil
+		}
+	}
+	return "", fmt.Errorf("cannot get the PostgreSQL version of service %s", s.Name)
+}
+
+// validateExtension returns an error if Aiven doesn't allow the extension or its version for the PostgreSQL version
+func validateExtension(available map[string][]pgAvailableExtension, pgVersion, name, version string) error {
+	extensions, ok := available[pgVersion]
+	if !ok {
+		return fmt.Errorf("no extensions are available for PostgreSQL %s", pgVersion)
+	}
+
+	i := slices.IndexFunc(extensions, func(e pgAvailableExtension) bool { return e.Name == name })
+	if i < 0 {
+		names := make([]string, 0, len(extensions))
+		for _, e := range extensions {
+			names = append(names, e.Name)
+		}
+		slices.Sort(names)
+		return fmt.Errorf(
+			"extension %q is not available for PostgreSQL %s, available extensions: %s",
+			name, pgVersion, strings.Join(names, ", "),
+		)
+	}
+
+	if version != "" && !slices.Contains(extensions[i].Versions, version) {
+		return fmt.Errorf(
+			"version %q of extension %q is not available for PostgreSQL %s, available versions: %s",
+			version, name, pgVersion, strings.Join(extensions[i].Versions, ", "),
+		)
+	}
+	return nil
+}
+
+// ValidateExtension checks the extension against the extensions Aiven allows for the PostgreSQL version of the service
+func ValidateExtension(ctx context.Context, client *aiven.Client, projectName, serviceName, name, version string) error {
+	s, err := client.Services.Get(ctx, projectName, serviceName)
+	if err != nil {
+		return err
+	}
+
+	pgVersion, err := servicePGVersion(s)
+	if err != nil {
+		return err
+	}
+
+	available, err := listPGAvailableExtensions(ctx, client, projectName)
+	if err != nil {
+		return err
+	}
+	return validateExtension(available, pgVersion, name, version)
+}
+
+// CreateExtension installs the extension, the schema and the version are the defaults of the extension if not set
+func CreateExtension(ctx context.Context, db *sql.DB, e Extension) error {
+	return execStatement(ctx, db, "create extension", createExtensionStatement(e))
+}
+
+// UpdateExtension updates the extension to the version, or to the default version if not set
+func UpdateExtension(ctx context.Context, db *sql.DB, name, version string) error {
+	return execStatement(ctx, db, "update extension", updateExtensionStatement(name, version))
+}
+
+// SetExtensionSchema moves the objects of the extension to the schema, only relocatable extensions can be moved
+func SetExtensionSchema(ctx context.Context, db *sql.DB, name, schemaName string) error {
+	query := fmt.Sprintf("ALTER EXTENSION %s SET SCHEMA %s", pq.QuoteIdentifier(name), pq.QuoteIdentifier(schemaName))
+	return execStatement(ctx, db, "set extension schema", query)
+}
+
+// ReadExtension returns the installed extension.
+// Returns aiven.Error with 404 status if the extension is not installed.
+func ReadExtension(ctx context.Context, db *sql.DB, name string) (*Extension, error) {
+	log.Println("[DEBUG] PG: read extension query: ", readExtensionStatement)
+	e := &Extension{Name: name}
+	err := db.QueryRowContext(ctx, readExtensionStatement, name).Scan(&e.Schema, &e.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, aiven.Error{Status: http.StatusNotFound, Message: fmt.Sprintf("extension %q not found", name)}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// DropExtension doesn't cascade, the objects that depend on the extension must be dropped first
+func DropExtension(ctx context.Context, db *sql.DB, name string) error {
+	return execStatement(ctx, db, "drop extension", "DROP EXTENSION IF EXISTS "+pq.QuoteIdentifier(name))
+}
+
+func createExtensionStatement(e Extension) string {
+	query := "CREATE EXTENSION " + pq.QuoteIdentifier(e.Name)
+	if e.Schema != "" {
+		query += " SCHEMA " + pq.QuoteIdentifier(e.Schema)
+	}
+	if e.Version != "" {
+		query += " VERSION " + pq.QuoteLiteral(e.Version)
+	}
+	return query
+}
+
+func updateExtensionStatement(name, version string) string {
+	query := fmt.Sprintf("ALTER EXTENSION %s UPDATE", pq.QuoteIdentifier(name))
+	if version != "" {
+		query += " TO " + pq.QuoteLiteral(version)
+	}
+	return query
+}
+
+const readExtensionStatement = `
+SELECT n.nspname, e.extversion
+FROM pg_extension e JOIN pg_namespace n ON n.oid = e.extnamespace
+WHERE e.extname = $1`
+
+func execStatement(ctx context.Context, db *sql.DB, kind, query string) error {
+	log.Printf("[DEBUG] PG: %s query: %s", kind, query)
+	_, err := db.ExecContext(ctx, query)
+	return err
+}
@@ -0,0 +1,138 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateExtension(t *testing.T) {
+	available := map[string][]pgAvailableExtension{
+		"15": {
+			{Name: "vector", Versions: []string{"0.5.0", "0.5.1"}},
+			{Name: "pg_stat_statements", Versions: []string{"1.10"}},
+		},
+	}
+
+	cases := []struct {
+		name, pgVersion, extension, version, err string
+	}{
+		{name: "default version", pgVersion: "15", extension: "vector"},
+		{name: "allowed version", pgVersion: "15", extension: "vector", version: "0.5.1"},
+		{
+			name:      "unknown version",
+			pgVersion: "15", extension: "vector", version: "0.4.0",
+			err: `version "0.4.0" of extension "vector" is not available for PostgreSQL 15, available versions: 0.5.0, 0.5.1`,
+		},
+		{
+			name:      "unknown extension",
+			pgVersion: "15", extension: "plpython3u",
+			err: `extension "plpython3u" is not available for PostgreSQL 15, available extensions: pg_stat_statements, vector`,
+		},
+		{
+			name:      "unknown pg version",
+			pgVersion: "12", extension: "vector",
+			err: "no extensions are available for PostgreSQL 12",
+		},
+	}
+
+	for _, opt := range cases {
+		t.Run(opt.name, func(t *testing.T) {
+			err := validateExtension(available, opt.pgVersion, opt.extension, opt.version)
+			if opt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, opt.err)
+			}
+		})
+	}
+}
+
+func TestServicePGVersion(t *testing.T) {
+	v, err := servicePGVersion(&aiven.Service{UserConfig: map[string]interface{}{"pg_version": "15"}})
+	require.NoError(t, err)
+	assert.Equal(t, "15", v)
+
+	v, err = servicePGVersion(&aiven.Service{Metadata: map[string]interface{}{"pg_version": "14.9"}})
+	require.NoError(t, err)
+	assert.Equal(t, "14", v)
+
+	_, err = servicePGVersion(&aiven.Service{Name: "pg"})
+	assert.EqualError(t, err, "cannot get the PostgreSQL version of service pg")
+}
+
+func TestExtensionStatements(t *testing.T) {
+	assert.Equal(t, `CREATE EXTENSION "vector"`, createExtensionStatement(Extension{Name: "vector"}))
+	assert.Equal(
+		t,
+		`CREATE EXTENSION "vector" SCHEMA "ext" VERSION '0.5.1'`,
+		createExtensionStatement(Extension{Name: "vector", Schema: "ext", Version: "0.5.1"}),
+	)
+	assert.Equal(t, `ALTER EXTENSION "vector" UPDATE`, updateExtensionStatement("vector", ""))
+	assert.Equal(t, `ALTER EXTENSION "vector" UPDATE TO '0.5.1'`, updateExtensionStatement("vector", "0.5.1"))
+	assert.Equal(t, `CREATE SCHEMA "app"`, createSchemaStatement("app", ""))
+	assert.Equal(t, `CREATE SCHEMA "app" AUTHORIZATION "owner"`, createSchemaStatement("app", "owner"))
+}
+
+func TestExtensionsLocal(t *testing.T) {
+	uri := os.Getenv("PG_TEST_URI")
+	if uri == "" {
+		t.Skip("PG_TEST_URI is not set")
+	}
+
+	ctx := context.Background()
+	db, err := sql.Open("postgres", uri)
+	require.NoError(t, err)
+	defer db.Close()
+
+	for _, q := range []string{
+		`DROP EXTENSION IF EXISTS pg_trgm`,
+		`DROP SCHEMA IF EXISTS extension_test`,
+		`DROP SCHEMA IF EXISTS extension_test_moved`,
+	} {
+		_, err := db.ExecContext(ctx, q)
+		require.NoError(t, err, q)
+	}
+
+	require.NoError(t, CreateSchema(ctx, db, "extension_test", ""))
+	require.NoError(t, CreateSchema(ctx, db, "extension_test_moved", ""))
+	defer func() {
+		_, _ = db.ExecContext(ctx, `DROP EXTENSION IF EXISTS pg_trgm`)
+		_, _ = db.ExecContext(ctx, `DROP SCHEMA IF EXISTS extension_test`)
+		_, _ = db.ExecContext(ctx, `DROP SCHEMA IF EXISTS extension_test_moved`)
+	}()
+
+	var user string
+	require.NoError(t, db.QueryRowContext(ctx, `SELECT current_user`).Scan(&user))
+	owner, err := ReadSchemaOwner(ctx, db, "extension_test")
+	require.NoError(t, err)
+	assert.Equal(t, user, owner)
+
+	require.NoError(t, CreateExtension(ctx, db, Extension{Name: "pg_trgm", Schema: "extension_test"}))
+	e, err := ReadExtension(ctx, db, "pg_trgm")
+	require.NoError(t, err)
+	assert.Equal(t, "extension_test", e.Schema)
+	assert.NotEmpty(t, e.Version)
+
+	require.NoError(t, SetExtensionSchema(ctx, db, "pg_trgm", "extension_test_moved"))
+	require.NoError(t, UpdateExtension(ctx, db, "pg_trgm", e.Version))
+	e, err = ReadExtension(ctx, db, "pg_trgm")
+	require.NoError(t, err)
+	assert.Equal(t, "extension_test_moved", e.Schema)
+
+	// The schema of the extension has objects, it is not dropped
+	assert.Error(t, DropSchema(ctx, db, "extension_test_moved"))
+
+	require.NoError(t, DropExtension(ctx, db, "pg_trgm"))
+	_, err = ReadExtension(ctx, db, "pg_trgm")
+	assert.True(t, aiven.IsNotFound(err))
+
+	require.NoError(t, DropSchema(ctx, db, "extension_test"))
+	_, err = ReadSchemaOwner(ctx, db, "extension_test")
+	assert.True(t, aiven.IsNotFound(err))
+}
@@ -0,0 +1,357 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/lib/pq"
+)
+
+// allObjects the name of a table or a sequence grant that applies to all the objects of the schema
+const allObjects = "*"
+
+const (
+	grantKindDatabase         = "database"
+	grantKindSchema           = "schema"
+	grantKindTable            = "table"
+	grantKindSequence         = "sequence"
+	grantKindDefaultPrivilege = "default"
+)
+
+var (
+	pgDatabasePrivileges = []string{"CONNECT", "CREATE", "TEMPORARY"}
+	pgSchemaPrivileges   = []string{"USAGE", "CREATE"}
+	pgTablePrivileges    = []string{"SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER"}
+	pgSequencePrivileges = []string{"USAGE", "SELECT", "UPDATE"}
+
+	// pgDefaultPrivilegeObjectTypes the object types of ALTER DEFAULT PRIVILEGES by pg_default_acl.defaclobjtype
+	pgDefaultPrivilegeObjectTypes = map[string]string{
+		"r": "tables",
+		"S": "sequences",
+		"f": "functions",
+		"T": "types",
+		"n": "schemas",
+	}
+	pgDefaultPrivilegeObjectTypeNames = []string{"tables", "sequences", "functions", "types", "schemas"}
+	pgDefaultPrivileges               = []string{"SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER", "USAGE", "EXECUTE", "CREATE"}
+)
+
+// GrantTarget the object the privileges are granted on.
+// Name is the table or the sequence, or the object type of the default privileges.
+// Owner is the role the default privileges apply to the objects of, empty is the connected user.
+type GrantTarget struct {
+	Kind   string
+	Owner  string
+	Schema string
+	Name   string
+}
+
+// Grants the privileges of a role in a database by the object
+type Grants map[GrantTarget][]string
+
+// sortedTargets the statements are in the same order for the same grants
+func (g Grants) sortedTargets() []GrantTarget {
+	targets := make([]GrantTarget, 0, len(g))
+	for t := range g {
+		targets = append(targets, t)
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		a, b := targets[i], targets[j]
+		return strings.Join([]string{a.Kind, a.Owner, a.Schema, a.Name}, "\x00") <
+			strings.Join([]string{b.Kind, b.Owner, b.Schema, b.Name}, "\x00")
+	})
+	return targets
+}
+
+// add appends the privilege, the privileges are sorted
+func (g Grants) add(t GrantTarget, privilege string) {
+	for _, p := range g[t] {
+		if p == privilege {
+			return
+		}
+	}
+	g[t] = append(g[t], privilege)
+	sort.Strings(g[t])
+}
+
+// ReadGrants reads the privileges of the role in the database the connection is open to.
+// allObjectsGrants are the "*" grants of the state, see collapseAllObjects.
+// Returns aiven.Error with 404 status if the role doesn't exist.
+func ReadGrants(ctx context.Context, db *sql.DB, database, role string, allObjectsGrants Grants) (Grants, error) {
+	var roleOID, currentUser string
+	err := db.QueryRowContext(ctx, readRoleStatement, role).Scan(&roleOID, &currentUser)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, aiven.Error{Status: http.StatusNotFound, Message: fmt.Sprintf("role %q not found", role)}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	grants := make(Grants)
+	err = queryRows(ctx, db, func(rows *sql.Rows) error {
+		var privilege string
+		if err := rows.Scan(&privilege); err != nil {
+			return err
+		}
+		grants.add(GrantTarget{Kind: grantKindDatabase}, privilege)
+		return nil
+	}, readDatabasePrivilegesStatement, database, roleOID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = queryRows(ctx, db, func(rows *sql.Rows) error {
+		var schema, privilege string
+		if err := rows.Scan(&schema, &privilege); err != nil {
+			return err
+		}
+		grants.add(GrantTarget{Kind: grantKindSchema, Schema: schema}, privilege)
+		return nil
+	}, readSchemaPrivilegesStatement, roleOID)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make(map[GrantTarget][]string)
+	err = queryRows(ctx, db, func(rows *sql.Rows) error {
+		var schema, name, kind string
+		var privilege sql.NullString
+		if err := rows.Scan(&schema, &name, &kind, &privilege); err != nil {
+			return err
+		}
+
+		t := GrantTarget{Kind: kind, Schema: schema, Name: name}
+		if _, ok := objects[t]; !ok {
+			objects[t] = make([]string, 0)
+		}
+		if privilege.Valid {
+			objects[t] = append(objects[t], privilege.String)
+		}
+		return nil
+	}, readObjectPrivilegesStatement, roleOID)
+	if err != nil {
+		return nil, err
+	}
+
+	for t, privileges := range collapseAllObjects(objects, allObjectsGrants) {
+		for _, p := range privileges {
+			grants.add(t, p)
+		}
+	}
+
+	err = queryRows(ctx, db, func(rows *sql.Rows) error {
+		var owner, schema, objectType, privilege string
+		if err := rows.Scan(&owner, &schema, &objectType, &privilege); err != nil {
+			return err
+		}
+		if owner == currentUser {
+			owner = ""
+		}
+		t := GrantTarget{Kind: grantKindDefaultPrivilege, Owner: owner, Schema: schema, Name: pgDefaultPrivilegeObjectTypes[objectType]}
+		grants.add(t, privilege)
+		return nil
+	}, readDefaultPrivilegesStatement, roleOID)
+	if err != nil {
+		return nil, err
+	}
+	return grants, nil
+}
+
+// collapseAllObjects returns the privileges of the objects. The privileges every object of a schema
+// with a "*" grant has are returned as the "*" grant, the objects have the rest of their privileges only.
+// The "*" grant of a schema without objects can't be read, so it is kept as it is.
+func collapseAllObjects(objects map[GrantTarget][]string, allObjectsGrants Grants) Grants {
+	common := make(map[GrantTarget]map[string]bool)
+	for t, privileges := range objects {
+		all := GrantTarget{Kind: t.Kind, Schema: t.Schema, Name: allObjects}
+		if _, ok := allObjectsGrants[all]; !ok {
+			continue
+		}
+
+		has := make(map[string]bool, len(privileges))
+		for _, p := range privileges {
+			has[p] = true
+		}
+
+		c, ok := common[all]
+		if !ok {
+			common[all] = has
+			continue
+		}
+		for p := range c {
+			if !has[p] {
+				delete(c, p)
+			}
+		}
+	}
+
+	grants := make(Grants)
+	for all, privileges := range allObjectsGrants {
+		c, ok := common[all]
+		if !ok {
+			for _, p := range privileges {
+				grants.add(all, p)
+			}
+			continue
+		}
+		for p := range c {
+			grants.add(all, p)
+		}
+	}
+
+	for t, privileges := range objects {
+		c := common[GrantTarget{Kind: t.Kind, Schema: t.Schema, Name: allObjects}]
+		for _, p := range privileges {
+			if !c[p] {
+				grants.add(t, p)
+			}
+		}
+	}
+	return grants
+}
+
+// ApplyGrants revokes the privileges that are not in the new grants and grants the new ones
+func ApplyGrants(ctx context.Context, db *sql.DB, database, role string, oldGrants, newGrants Grants) error {
+	for _, query := range grantStatements(database, role, oldGrants, newGrants) {
+		log.Println("[DEBUG] PG: grant query: ", query)
+		if _, err := db.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// grantStatements revokes first. A revoke on an object takes the privileges of the "*" grant of its schema too,
+// so the "*" grant is granted again in full.
+func grantStatements(database, role string, oldGrants, newGrants Grants) []string {
+	statements := make([]string, 0)
+	revoked := make(map[GrantTarget]bool)
+	for _, t := range oldGrants.sortedTargets() {
+		if revoke := privilegesDiff(oldGrants[t], newGrants[t]); len(revoke) > 0 {
+			statements = append(statements, revokeStatement(database, role, t, revoke))
+			revoked[GrantTarget{Kind: t.Kind, Schema: t.Schema, Name: allObjects}] = true
+		}
+	}
+	for _, t := range newGrants.sortedTargets() {
+		grant := privilegesDiff(newGrants[t], oldGrants[t])
+		if t.Name == allObjects && revoked[t] {
+			grant = newGrants[t]
+		}
+		if len(grant) > 0 {
+			statements = append(statements, grantStatement(database, role, t, grant))
+		}
+	}
+	return statements
+}
+
+// privilegesDiff returns the privileges of a that are not in b
+func privilegesDiff(a, b []string) []string {
+	res := make([]string, 0)
+	for _, p := range a {
+		found := false
+		for _, o := range b {
+			if p == o {
+				found = true
+				break
+			}
+		}
+		if !found {
+			res = append(res, p)
+		}
+	}
+	return res
+}
+
+// grantTargetStatement returns the ALTER DEFAULT PRIVILEGES prefix and the ON clause of the target
+func grantTargetStatement(database string, t GrantTarget) (prefix, on string) {
+	switch t.Kind {
+	case grantKindDatabase:
+		return "", "DATABASE " + pq.QuoteIdentifier(database)
+	case grantKindSchema:
+		return "", "SCHEMA " + pq.QuoteIdentifier(t.Schema)
+	case grantKindTable, grantKindSequence:
+		if t.Name == allObjects {
+			return "", fmt.Sprintf("ALL %sS IN SCHEMA %s", strings.ToUpper(t.Kind), pq.QuoteIdentifier(t.Schema))
+		}
+		return "", fmt.Sprintf("%s %s.%s", strings.ToUpper(t.Kind), pq.QuoteIdentifier(t.Schema), pq.QuoteIdentifier(t.Name))
+	}
+
+	b := new(strings.Builder)
+	b.WriteString("ALTER DEFAULT PRIVILEGES ")
+	if t.Owner != "" {
+		b.WriteString("FOR ROLE " + pq.QuoteIdentifier(t.Owner) + " ")
+	}
+	if t.Schema != "" {
+		b.WriteString("IN SCHEMA " + pq.QuoteIdentifier(t.Schema) + " ")
+	}
+	return b.String(), strings.ToUpper(t.Name)
+}
+
+// grantStatement the privileges are keywords, they are validated in the schema
+func grantStatement(database, role string, t GrantTarget, privileges []string) string {
+	prefix, on := grantTargetStatement(database, t)
+	return fmt.Sprintf("%sGRANT %s ON %s TO %s", prefix, strings.Join(privileges, ", "), on, pq.QuoteIdentifier(role))
+}
+
+func revokeStatement(database, role string, t GrantTarget, privileges []string) string {
+	prefix, on := grantTargetStatement(database, t)
+	return fmt.Sprintf("%sREVOKE %s ON %s FROM %s", prefix, strings.Join(privileges, ", "), on, pq.QuoteIdentifier(role))
+}
+
+func queryRows(ctx context.Context, db *sql.DB, scan func(rows *sql.Rows) error, query string, args ...any) error {
+	log.Println("[DEBUG] PG: read grants query: ", query)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+const readRoleStatement = `SELECT oid::text, current_user FROM pg_roles WHERE rolname = $1`
+
+// The privileges the owner has are not grants, the objects owned by the role are skipped
+const readDatabasePrivilegesStatement = `
+SELECT a.privilege_type
+FROM pg_database d, aclexplode(COALESCE(d.datacl, acldefault('d', d.datdba))) a
+WHERE d.datname = $1 AND a.grantee = $2::oid AND d.datdba <> $2::oid`
+
+const readSchemaPrivilegesStatement = `
+SELECT n.nspname, a.privilege_type
+FROM pg_namespace n, aclexplode(COALESCE(n.nspacl, acldefault('n', n.nspowner))) a
+WHERE a.grantee = $1::oid AND n.nspowner <> $1::oid
+  AND n.nspname NOT LIKE 'pg\_%' AND n.nspname <> 'information_schema'`
+
+// readObjectPrivilegesStatement returns every table and sequence, the ones without privileges have a NULL privilege
+const readObjectPrivilegesStatement = `
+SELECT n.nspname, c.relname, CASE WHEN c.relkind = 'S' THEN 'sequence' ELSE 'table' END, a.privilege_type
+FROM pg_class c
+JOIN pg_namespace n ON n.oid = c.relnamespace
+LEFT JOIN LATERAL (
+  SELECT privilege_type
+  FROM aclexplode(COALESCE(c.relacl, acldefault((CASE WHEN c.relkind = 'S' THEN 's' ELSE 'r' END)::"char", c.relowner)))
+  WHERE grantee = $1::oid
+) a ON true
+WHERE c.relkind IN ('r', 'p', 'v', 'm', 'f', 'S') AND c.relowner <> $1::oid
+  AND n.nspname NOT LIKE 'pg\_%' AND n.nspname <> 'information_schema'`
+
+const readDefaultPrivilegesStatement = `
+SELECT r.rolname, COALESCE(n.nspname, ''), d.defaclobjtype, a.privilege_type
+FROM pg_default_acl d
+JOIN pg_roles r ON r.oid = d.defaclrole
+LEFT JOIN pg_namespace n ON n.oid = d.defaclnamespace,
+aclexplode(d.defaclacl) a
+WHERE a.grantee = $1::oid`
@@ -0,0 +1,51 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/lib/pq"
+)
+
+// CreateSchema creates the schema, owned by the admin user the provider connects as if the owner is not set
+func CreateSchema(ctx context.Context, db *sql.DB, name, owner string) error {
+	return execStatement(ctx, db, "create schema", createSchemaStatement(name, owner))
+}
+
+// AlterSchemaOwner the admin user must be a member of the new owner role
+func AlterSchemaOwner(ctx context.Context, db *sql.DB, name, owner string) error {
+	query := fmt.Sprintf("ALTER SCHEMA %s OWNER TO %s", pq.QuoteIdentifier(name), pq.QuoteIdentifier(owner))
+	return execStatement(ctx, db, "alter schema", query)
+}
+
+// ReadSchemaOwner returns the owner of the schema.
+// Returns aiven.Error with 404 status if the schema doesn't exist.
+func ReadSchemaOwner(ctx context.Context, db *sql.DB, name string) (string, error) {
+	log.Println("[DEBUG] PG: read schema query: ", readSchemaStatement)
+	var owner string
+	err := db.QueryRowContext(ctx, readSchemaStatement, name).Scan(&owner)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", aiven.Error{Status: http.StatusNotFound, Message: fmt.Sprintf("schema %q not found", name)}
+	}
+	return owner, err
+}
+
+// DropSchema doesn't cascade, a schema that has objects is not dropped
+func DropSchema(ctx context.Context, db *sql.DB, name string) error {
+	return execStatement(ctx, db, "drop schema", "DROP SCHEMA IF EXISTS "+pq.QuoteIdentifier(name))
+}
+
+func createSchemaStatement(name, owner string) string {
+	query := "CREATE SCHEMA " + pq.QuoteIdentifier(name)
+	if owner != "" {
+		query += " AUTHORIZATION " + pq.QuoteIdentifier(owner)
+	}
+	return query
+}
+
+const readSchemaStatement = `SELECT pg_get_userbyid(nspowner) FROM pg_namespace WHERE nspname = $1`
@@ -0,0 +1,222 @@
+package pg
+
+import (
+	"context"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil/userconfig"
+)
+
+var aivenPGExtensionSchema = map[string]*schema.Schema{
+	"project":      schemautil.CommonSchemaProjectReference,
+	"service_name": schemautil.CommonSchemaServiceNameReference,
+
+	"database": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: userconfig.Desc("The database the extension is installed in.").Referenced().ForceNew().Build(),
+	},
+	"name": {
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+		Description: userconfig.Desc("The name of the extension, for example `pgvector` is installed as `vector`. " +
+			"Must be one of the extensions Aiven allows for the PostgreSQL version of the service.").ForceNew().Build(),
+	},
+	"schema": {
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+		Description: "The schema the objects of the extension are created in. " +
+			"If not set, the default schema of the extension or the current schema. " +
+			"Only relocatable extensions can be moved to another schema.",
+	},
+	"version": {
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+		Description: "The version of the extension. If not set, the default version. " +
+			"A change updates the extension with `ALTER EXTENSION ... UPDATE`.",
+	},
+}
+
+func ResourcePGExtension() *schema.Resource {
+	return &schema.Resource{
+		Description: `The PG Extension resource allows the installation of extensions in a database of an Aiven PostgreSQL service.
+
+The provider connects to the database with the admin credentials of the service, so the service must be reachable from where Terraform runs.
+The extension and its version are validated on plan against the extensions Aiven allows for the PostgreSQL version of the service.
+The extension is dropped without ` + "`CASCADE`" + `, the objects that depend on it must be dropped first.
+`,
+		CreateContext: resourcePGExtensionCreate,
+		ReadContext:   resourcePGExtensionRead,
+		UpdateContext: resourcePGExtensionUpdate,
+		DeleteContext: resourcePGExtensionDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: schemautil.DefaultResourceTimeouts(),
+
+		Schema:        aivenPGExtensionSchema,
+		CustomizeDiff: resourcePGExtensionCustomizeDiff,
+	}
+}
+
+// resourcePGExtensionCustomizeDiff a disallowed extension fails on plan.
+// Skipped if the values are not known yet, or the service doesn't exist yet, e.g. it is created in the same apply
+func resourcePGExtensionCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" && !d.HasChange("version") {
+		return nil
+	}
+
+	for _, k := range []string{"project", "service_name", "name"} {
+		if !d.NewValueKnown(k) {
+			return nil
+		}
+	}
+
+	// The version is computed, it is unknown on plan when it isn't set, then it is the default version
+	config := d.GetRawConfig()
+	if config.IsNull() {
+		return nil
+	}
+
+	version := ""
+	if !config.GetAttr("version").IsNull() {
+		if !d.NewValueKnown("version") {
+			return nil
+		}
+		version = d.Get("version").(string)
+	}
+
+	client := m.(*aiven.Client)
+	err := ValidateExtension(ctx, client, d.Get("project").(string), d.Get("service_name").(string), d.Get("name").(string), version)
+	if aiven.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func resourcePGExtensionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	database := d.Get("database").(string)
+	extension := Extension{
+		Name:    d.Get("name").(string),
+		Schema:  d.Get("schema").(string),
+		Version: d.Get("version").(string),
+	}
+
+	db, err := connectServiceDatabase(ctx, client, projectName, serviceName, database)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer db.Close()
+
+	if err := CreateExtension(ctx, db, extension); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(projectName, serviceName, database, extension.Name))
+
+	return resourcePGExtensionRead(ctx, d, m)
+}
+
+func resourcePGExtensionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, database, name, err := schemautil.SplitResourceID4(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	db, err := connectServiceDatabase(ctx, client, projectName, serviceName, database)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(pgNotFoundError(err), d))
+	}
+	defer db.Close()
+
+	extension, err := ReadExtension(ctx, db, name)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	if err := d.Set("project", projectName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("database", database); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("name", extension.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("schema", extension.Schema); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("version", extension.Version); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourcePGExtensionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, database, name, err := schemautil.SplitResourceID4(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	db, err := connectServiceDatabase(ctx, client, projectName, serviceName, database)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer db.Close()
+
+	if d.HasChange("schema") {
+		if err := SetExtensionSchema(ctx, db, name, d.Get("schema").(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("version") {
+		if err := UpdateExtension(ctx, db, name, d.Get("version").(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourcePGExtensionRead(ctx, d, m)
+}
+
+func resourcePGExtensionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, database, name, err := schemautil.SplitResourceID4(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	db, err := connectServiceDatabase(ctx, client, projectName, serviceName, database)
+	if err != nil {
+		if isPGNotFound(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	defer db.Close()
+
+	if err := DropExtension(ctx, db, name); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
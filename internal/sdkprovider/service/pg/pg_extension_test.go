@@ -0,0 +1,91 @@
+package pg_test
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	acc "github.com/aiven/terraform-provider-aiven/internal/acctest"
+)
+
+func TestAccAivenPGExtension(t *testing.T) {
+	serviceName := fmt.Sprintf("test-acc-pg-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	projectName := os.Getenv("AIVEN_PROJECT_NAME")
+	resourceName := "aiven_pg_extension.foo"
+
+	manifest := func(extension string) string {
+		return fmt.Sprintf(`
+resource "aiven_pg" "bar" {
+  project                 = "%s"
+  cloud_name              = "google-europe-west1"
+  plan                    = "startup-4"
+  service_name            = "%s"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+}
+
+resource "aiven_pg_database" "foo" {
+  project       = aiven_pg.bar.project
+  service_name  = aiven_pg.bar.service_name
+  database_name = "app"
+}
+
+resource "aiven_pg_schema" "foo" {
+  project      = aiven_pg.bar.project
+  service_name = aiven_pg.bar.service_name
+  database     = aiven_pg_database.foo.database_name
+  name         = "extensions"
+}
+
+resource "aiven_pg_extension" "foo" {
+  project      = aiven_pg.bar.project
+  service_name = aiven_pg.bar.service_name
+  database     = aiven_pg_database.foo.database_name
+  %s
+}`, projectName, serviceName, extension)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acc.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acc.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: manifest(`name = "vector"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "vector"),
+					resource.TestCheckResourceAttr(resourceName, "schema", "public"),
+					resource.TestCheckResourceAttrSet(resourceName, "version"),
+				),
+			},
+			{
+				// Fails on plan, the service exists
+				Config:      manifest(`name = "not_an_extension"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`extension "not_an_extension" is not available for PostgreSQL`),
+			},
+			{
+				Config:      manifest(`name = "vector"` + "\n" + `version = "0.0.1"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`version "0.0.1" of extension "vector" is not available for PostgreSQL`),
+			},
+			{
+				// Moves the extension to the schema
+				Config: manifest(`
+  name   = "vector"
+  schema = aiven_pg_schema.foo.name`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "schema", "extensions"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,399 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil/userconfig"
+)
+
+func pgPrivilegesSchema(privileges []string) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Required: true,
+		MinItems: 1,
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validation.StringInSlice(privileges, false),
+		},
+		Description: userconfig.Desc("The privileges.").PossibleValues(schemautil.StringSliceToInterfaceSlice(privileges)...).Build(),
+	}
+}
+
+// pgObjectGrantSchema the grant on a table or a sequence
+func pgObjectGrantSchema(kind string, privileges []string) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Description: "The privileges on the " + kind + "s. " +
+			"A grant on `*` applies to all the existing " + kind + "s of the schema, `GRANT ... ON ALL " +
+			strings.ToUpper(kind) + "S IN SCHEMA`, the grants on the other " + kind + "s of the schema are in addition to it. " +
+			"The " + kind + "s created later don't have the privileges and are shown as drift, " +
+			"use `default_privileges` to grant the privileges on them.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"schema": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "The schema of the " + kind + ".",
+				},
+				"name": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "The name of the " + kind + ", or `*` for all the " + kind + "s of the schema.",
+				},
+				"privileges": pgPrivilegesSchema(privileges),
+			},
+		},
+	}
+}
+
+var aivenPGGrantSchema = map[string]*schema.Schema{
+	"project":      schemautil.CommonSchemaProjectReference,
+	"service_name": schemautil.CommonSchemaServiceNameReference,
+
+	"database": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: userconfig.Desc("The database the privileges are granted in.").Referenced().ForceNew().Build(),
+	},
+	"role": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: userconfig.Desc("The role or the user the privileges are granted to.").Referenced().ForceNew().Build(),
+	},
+	"database_privileges": {
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validation.StringInSlice(pgDatabasePrivileges, false),
+		},
+		Description: userconfig.Desc("The privileges on the database.").
+			PossibleValues(schemautil.StringSliceToInterfaceSlice(pgDatabasePrivileges)...).Build(),
+	},
+	"schema": {
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Description: "The privileges on the schemas.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "The name of the schema.",
+				},
+				"privileges": pgPrivilegesSchema(pgSchemaPrivileges),
+			},
+		},
+	},
+	"table":    pgObjectGrantSchema(grantKindTable, pgTablePrivileges),
+	"sequence": pgObjectGrantSchema(grantKindSequence, pgSequencePrivileges),
+	"default_privileges": {
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Description: "The privileges on the objects created later, `ALTER DEFAULT PRIVILEGES`.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"owner": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Description: "The role that creates the objects. " +
+						"If not set, the admin user the provider connects as.",
+				},
+				"schema": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Description: "The schema of the objects. If not set, the objects of all the schemas. " +
+						"Can't be set for the `schemas` object type.",
+				},
+				"object_type": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice(pgDefaultPrivilegeObjectTypeNames, false),
+					Description: userconfig.Desc("The type of the objects.").
+						PossibleValues(schemautil.StringSliceToInterfaceSlice(pgDefaultPrivilegeObjectTypeNames)...).Build(),
+				},
+				"privileges": pgPrivilegesSchema(pgDefaultPrivileges),
+			},
+		},
+	},
+}
+
+func ResourcePGGrant() *schema.Resource {
+	return &schema.Resource{
+		Description: `The PG Grant resource allows the management of the privileges of a role in a database of an Aiven PostgreSQL service.
+
+The provider connects to the database with the admin credentials of the service, so the service must be reachable from where Terraform runs.
+The resource manages all the privileges of the role in the database, the privileges granted outside of Terraform are shown as drift.
+The privileges of the objects the role owns are not grants, they are not managed.
+On import, the grants on ` + "`*`" + ` are read as the grants on each object.
+`,
+		CreateContext: resourcePGGrantCreate,
+		ReadContext:   resourcePGGrantRead,
+		UpdateContext: resourcePGGrantUpdate,
+		DeleteContext: resourcePGGrantDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: schemautil.DefaultResourceTimeouts(),
+
+		Schema:        aivenPGGrantSchema,
+		CustomizeDiff: resourcePGGrantCustomizeDiff,
+	}
+}
+
+// resourcePGGrantCustomizeDiff the default privileges PostgreSQL rejects fail on plan
+func resourcePGGrantCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	return validateDefaultPrivileges(d.Get("default_privileges").(*schema.Set).List())
+}
+
+// validateDefaultPrivileges the default privileges on schemas can't be limited to a schema,
+// ALTER DEFAULT PRIVILEGES IN SCHEMA ... ON SCHEMAS is an error
+func validateDefaultPrivileges(list []interface{}) error {
+	for _, v := range list {
+		p := v.(map[string]interface{})
+		if p["object_type"].(string) == "schemas" && p["schema"].(string) != "" {
+			return fmt.Errorf("default_privileges with object_type %q can't have schema %q", "schemas", p["schema"])
+		}
+	}
+	return nil
+}
+
+func resourcePGGrantCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	database := d.Get("database").(string)
+	role := d.Get("role").(string)
+
+	db, err := connectServiceDatabase(ctx, client, projectName, serviceName, database)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer db.Close()
+
+	if err := ApplyGrants(ctx, db, database, role, make(Grants), expandGrants(d.Get)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(projectName, serviceName, database, role))
+
+	return resourcePGGrantRead(ctx, d, m)
+}
+
+func resourcePGGrantRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, database, role, err := schemautil.SplitResourceID4(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	db, err := connectServiceDatabase(ctx, client, projectName, serviceName, database)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(pgNotFoundError(err), d))
+	}
+	defer db.Close()
+
+	// The "*" grants of the state tell which schemas are granted as a whole
+	allObjectsGrants := make(Grants)
+	for t, privileges := range expandGrants(d.Get) {
+		if t.Name == allObjects {
+			allObjectsGrants[t] = privileges
+		}
+	}
+
+	grants, err := ReadGrants(ctx, db, database, role, allObjectsGrants)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(pgNotFoundError(err), d))
+	}
+
+	if err := d.Set("project", projectName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("database", database); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("role", role); err != nil {
+		return diag.FromErr(err)
+	}
+	for k, v := range flattenGrants(grants) {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
+
+func resourcePGGrantUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, database, role, err := schemautil.SplitResourceID4(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	db, err := connectServiceDatabase(ctx, client, projectName, serviceName, database)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer db.Close()
+
+	oldGrants := expandGrants(func(k string) interface{} {
+		o, _ := d.GetChange(k)
+		return o
+	})
+	if err := ApplyGrants(ctx, db, database, role, oldGrants, expandGrants(d.Get)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourcePGGrantRead(ctx, d, m)
+}
+
+func resourcePGGrantDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, database, role, err := schemautil.SplitResourceID4(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	db, err := connectServiceDatabase(ctx, client, projectName, serviceName, database)
+	if err != nil {
+		if isPGNotFound(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	defer db.Close()
+
+	// The privileges of a dropped role or on a dropped object are gone already
+	err = ApplyGrants(ctx, db, database, role, expandGrants(d.Get), make(Grants))
+	if err != nil && !isPGNotFound(err) {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// isPGNotFound returns true for a missing database, role or object
+func isPGNotFound(err error) bool {
+	var e *pq.Error
+	if !errors.As(err, &e) {
+		return false
+	}
+
+	// undefined_object, invalid_catalog_name, undefined_table, invalid_schema_name
+	switch e.Code {
+	case "42704", "3D000", "42P01", "3F000":
+		return true
+	}
+	return false
+}
+
+// pgNotFoundError returns aiven.Error with 404 status for a missing database or role, so the resource is removed
+func pgNotFoundError(err error) error {
+	if isPGNotFound(err) {
+		return aiven.Error{Status: http.StatusNotFound, Message: err.Error()}
+	}
+	return err
+}
+
+// expandGrants returns the grants of the config, get is d.Get or returns the old values on update
+func expandGrants(get func(string) interface{}) Grants {
+	grants := make(Grants)
+	for _, p := range get("database_privileges").(*schema.Set).List() {
+		grants.add(GrantTarget{Kind: grantKindDatabase}, p.(string))
+	}
+
+	addPrivileges := func(t GrantTarget, privileges interface{}) {
+		for _, p := range privileges.(*schema.Set).List() {
+			grants.add(t, p.(string))
+		}
+	}
+
+	for _, v := range get("schema").(*schema.Set).List() {
+		s := v.(map[string]interface{})
+		addPrivileges(GrantTarget{Kind: grantKindSchema, Schema: s["name"].(string)}, s["privileges"])
+	}
+
+	for _, kind := range []string{grantKindTable, grantKindSequence} {
+		for _, v := range get(kind).(*schema.Set).List() {
+			o := v.(map[string]interface{})
+			addPrivileges(GrantTarget{Kind: kind, Schema: o["schema"].(string), Name: o["name"].(string)}, o["privileges"])
+		}
+	}
+
+	for _, v := range get("default_privileges").(*schema.Set).List() {
+		p := v.(map[string]interface{})
+		t := GrantTarget{
+			Kind:   grantKindDefaultPrivilege,
+			Owner:  p["owner"].(string),
+			Schema: p["schema"].(string),
+			Name:   p["object_type"].(string),
+		}
+		addPrivileges(t, p["privileges"])
+	}
+	return grants
+}
+
+// flattenGrants returns the values of the fields by the field name
+func flattenGrants(grants Grants) map[string]interface{} {
+	databasePrivileges := make([]string, 0)
+	schemas := make([]map[string]interface{}, 0)
+	objects := map[string][]map[string]interface{}{
+		grantKindTable:    make([]map[string]interface{}, 0),
+		grantKindSequence: make([]map[string]interface{}, 0),
+	}
+	defaultPrivileges := make([]map[string]interface{}, 0)
+
+	for _, t := range grants.sortedTargets() {
+		privileges := grants[t]
+		switch t.Kind {
+		case grantKindDatabase:
+			databasePrivileges = append(databasePrivileges, privileges...)
+		case grantKindSchema:
+			schemas = append(schemas, map[string]interface{}{
+				"name":       t.Schema,
+				"privileges": privileges,
+			})
+		case grantKindTable, grantKindSequence:
+			objects[t.Kind] = append(objects[t.Kind], map[string]interface{}{
+				"schema":     t.Schema,
+				"name":       t.Name,
+				"privileges": privileges,
+			})
+		case grantKindDefaultPrivilege:
+			defaultPrivileges = append(defaultPrivileges, map[string]interface{}{
+				"owner":       t.Owner,
+				"schema":      t.Schema,
+				"object_type": strings.ToLower(t.Name),
+				"privileges":  privileges,
+			})
+		}
+	}
+
+	return map[string]interface{}{
+		"database_privileges": databasePrivileges,
+		"schema":              schemas,
+		"table":               objects[grantKindTable],
+		"sequence":            objects[grantKindSequence],
+		"default_privileges":  defaultPrivileges,
+	}
+}
@@ -0,0 +1,124 @@
+package pg_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+
+	acc "github.com/aiven/terraform-provider-aiven/internal/acctest"
+)
+
+func TestAccAivenPGGrant(t *testing.T) {
+	serviceName := fmt.Sprintf("test-acc-pg-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	projectName := os.Getenv("AIVEN_PROJECT_NAME")
+	resourceName := "aiven_pg_grant.foo"
+
+	manifest := func(grants string) string {
+		return fmt.Sprintf(`
+resource "aiven_pg" "bar" {
+  project                 = "%s"
+  cloud_name              = "google-europe-west1"
+  plan                    = "startup-4"
+  service_name            = "%s"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+}
+
+resource "aiven_pg_database" "foo" {
+  project       = aiven_pg.bar.project
+  service_name  = aiven_pg.bar.service_name
+  database_name = "app"
+}
+
+resource "aiven_pg_user" "foo" {
+  project      = aiven_pg.bar.project
+  service_name = aiven_pg.bar.service_name
+  username     = "reader"
+}
+
+resource "aiven_pg_grant" "foo" {
+  project      = aiven_pg.bar.project
+  service_name = aiven_pg.bar.service_name
+  database     = aiven_pg_database.foo.database_name
+  role         = aiven_pg_user.foo.username
+  %s
+}`, projectName, serviceName, grants)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acc.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acc.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: manifest(`
+  database_privileges = ["CONNECT", "TEMPORARY"]
+
+  schema {
+    name       = "public"
+    privileges = ["USAGE"]
+  }
+
+  table {
+    schema     = "public"
+    name       = "*"
+    privileges = ["SELECT"]
+  }
+
+  default_privileges {
+    schema      = "public"
+    object_type = "tables"
+    privileges  = ["SELECT"]
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "database_privileges.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "schema.*", map[string]string{
+						"name": "public",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "table.*", map[string]string{
+						"schema": "public",
+						"name":   "*",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "default_privileges.*", map[string]string{
+						"schema":      "public",
+						"object_type": "tables",
+					}),
+				),
+			},
+			{
+				// Revokes the removed privileges in place
+				Config: manifest(`
+  database_privileges = ["CONNECT"]
+
+  default_privileges {
+    object_type = "sequences"
+    privileges  = ["USAGE", "SELECT"]
+  }`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "database_privileges.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "schema.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "table.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "default_privileges.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "default_privileges.*", map[string]string{
+						"owner":        "",
+						"object_type":  "sequences",
+						"privileges.#": "2",
+					}),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,161 @@
+package pg
+
+import (
+	"context"
+
+	"github.com/aiven/aiven-go-client/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil"
+	"github.com/aiven/terraform-provider-aiven/internal/schemautil/userconfig"
+)
+
+var aivenPGSchemaSchema = map[string]*schema.Schema{
+	"project":      schemautil.CommonSchemaProjectReference,
+	"service_name": schemautil.CommonSchemaServiceNameReference,
+
+	"database": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: userconfig.Desc("The database the schema is created in.").Referenced().ForceNew().Build(),
+	},
+	"name": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: userconfig.Desc("The name of the schema.").ForceNew().Build(),
+	},
+	"owner": {
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+		Description: userconfig.Desc("The role that owns the schema. " +
+			"If not set, the admin user the provider connects as.").Referenced().Build(),
+	},
+}
+
+func ResourcePGSchema() *schema.Resource {
+	return &schema.Resource{
+		Description: `The PG Schema resource allows the creation and management of schemas in a database of an Aiven PostgreSQL service.
+
+The provider connects to the database with the admin credentials of the service, so the service must be reachable from where Terraform runs.
+The schema is dropped without ` + "`CASCADE`" + `, a schema that has objects is not dropped.
+`,
+		CreateContext: resourcePGSchemaCreate,
+		ReadContext:   resourcePGSchemaRead,
+		UpdateContext: resourcePGSchemaUpdate,
+		DeleteContext: resourcePGSchemaDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: schemautil.DefaultResourceTimeouts(),
+
+		Schema: aivenPGSchemaSchema,
+	}
+}
+
+func resourcePGSchemaCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	database := d.Get("database").(string)
+	name := d.Get("name").(string)
+
+	db, err := connectServiceDatabase(ctx, client, projectName, serviceName, database)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer db.Close()
+
+	if err := CreateSchema(ctx, db, name, d.Get("owner").(string)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(schemautil.BuildResourceID(projectName, serviceName, database, name))
+
+	return resourcePGSchemaRead(ctx, d, m)
+}
+
+func resourcePGSchemaRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, database, name, err := schemautil.SplitResourceID4(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	db, err := connectServiceDatabase(ctx, client, projectName, serviceName, database)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(pgNotFoundError(err), d))
+	}
+	defer db.Close()
+
+	owner, err := ReadSchemaOwner(ctx, db, name)
+	if err != nil {
+		return diag.FromErr(schemautil.ResourceReadHandleNotFound(err, d))
+	}
+
+	if err := d.Set("project", projectName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("database", database); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("name", name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("owner", owner); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourcePGSchemaUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, database, name, err := schemautil.SplitResourceID4(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	db, err := connectServiceDatabase(ctx, client, projectName, serviceName, database)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer db.Close()
+
+	if err := AlterSchemaOwner(ctx, db, name, d.Get("owner").(string)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourcePGSchemaRead(ctx, d, m)
+}
+
+func resourcePGSchemaDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*aiven.Client)
+
+	projectName, serviceName, database, name, err := schemautil.SplitResourceID4(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	db, err := connectServiceDatabase(ctx, client, projectName, serviceName, database)
+	if err != nil {
+		if isPGNotFound(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	defer db.Close()
+
+	if err := DropSchema(ctx, db, name); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package pg_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+
+	acc "github.com/aiven/terraform-provider-aiven/internal/acctest"
+)
+
+func TestAccAivenPGSchema(t *testing.T) {
+	serviceName := fmt.Sprintf("test-acc-pg-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	projectName := os.Getenv("AIVEN_PROJECT_NAME")
+	resourceName := "aiven_pg_schema.foo"
+
+	manifest := func(owner string) string {
+		return fmt.Sprintf(`
+resource "aiven_pg" "bar" {
+  project                 = "%s"
+  cloud_name              = "google-europe-west1"
+  plan                    = "startup-4"
+  service_name            = "%s"
+  maintenance_window_dow  = "monday"
+  maintenance_window_time = "10:00:00"
+}
+
+resource "aiven_pg_database" "foo" {
+  project       = aiven_pg.bar.project
+  service_name  = aiven_pg.bar.service_name
+  database_name = "app"
+}
+
+resource "aiven_pg_user" "foo" {
+  project      = aiven_pg.bar.project
+  service_name = aiven_pg.bar.service_name
+  username     = "app_owner"
+}
+
+resource "aiven_pg_schema" "foo" {
+  project      = aiven_pg.bar.project
+  service_name = aiven_pg.bar.service_name
+  database     = aiven_pg_database.foo.database_name
+  name         = "app"
+  %s
+}`, projectName, serviceName, owner)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { acc.TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: acc.TestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: manifest(""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "app"),
+					resource.TestCheckResourceAttr(resourceName, "owner", "avnadmin"),
+				),
+			},
+			{
+				// Changes the owner in place
+				Config: manifest(`owner = aiven_pg_user.foo.username`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "owner", "app_owner"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,93 @@
+// Package ttlcache caches the state of a service fetched at once, so a plan with many resources makes one call per service.
+package ttlcache
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache stores a value per key for the ttl.
+// The concurrent reads of the same key wait for a single fetch, the reads of the other keys don't wait for it.
+// Errors are not cached.
+type Cache[T any] struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[string]*entry[T]
+}
+
+type entry[T any] struct {
+	// sync.Mutex is held during the fetch of the key only
+	sync.Mutex
+	value     T
+	ok        bool
+	fetchedAt time.Time
+}
+
+// New returns a cache, now is replaced in unit tests
+func New[T any](ttl time.Duration, now func() time.Time) *Cache[T] {
+	return &Cache[T]{
+		ttl:     ttl,
+		now:     now,
+		entries: make(map[string]*entry[T]),
+	}
+}
+
+func (c *Cache[T]) entry(key string) *entry[T] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		e = new(entry[T])
+		c.entries[key] = e
+	}
+	return e
+}
+
+// Get returns the value of the key, or calls fetch if it is missing or expired
+func (c *Cache[T]) Get(ctx context.Context, key string, fetch func(ctx context.Context) (T, error)) (T, error) {
+	e := c.entry(key)
+	e.Lock()
+	defer e.Unlock()
+
+	if e.ok && c.now().Sub(e.fetchedAt) < c.ttl {
+		return e.value, nil
+	}
+
+	v, err := fetch(ctx)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	e.value, e.ok, e.fetchedAt = v, true, c.now()
+	return v, nil
+}
+
+// Set stores the value that was just written, so the following reads get it without a fetch
+func (c *Cache[T]) Set(key string, v T) {
+	e := c.entry(key)
+	e.Lock()
+	defer e.Unlock()
+
+	e.value, e.ok, e.fetchedAt = v, true, c.now()
+}
+
+// Invalidate drops the value of the key, the next read fetches it again.
+// Waits for a fetch of the key in progress, which might have read the state before the change
+func (c *Cache[T]) Invalidate(key string) {
+	e := c.entry(key)
+	e.Lock()
+	defer e.Unlock()
+
+	var zero T
+	e.value, e.ok = zero, false
+}
+
+// Key builds a path-like key, so "a"+"bc" and "ab"+"c" don't collide
+func Key(parts ...string) string {
+	return strings.Join(parts, "/")
+}
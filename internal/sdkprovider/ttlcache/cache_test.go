@@ -0,0 +1,113 @@
+package ttlcache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache(t *testing.T) {
+	now := time.Now()
+	cache := New[[]string](time.Minute, func() time.Time { return now })
+
+	var calls int32
+	fetch := func(context.Context) ([]string, error) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(time.Millisecond * 10) // we need some lag to simulate races
+		return []string{"a"}, nil
+	}
+
+	// Concurrent reads of the same key share a single fetch
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := cache.Get(context.Background(), Key("project", "service"), fetch)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"a"}, v)
+		}()
+	}
+	wg.Wait()
+	assert.EqualValues(t, 1, calls)
+
+	// Another key is fetched on its own
+	_, err := cache.Get(context.Background(), Key("project", "other"), fetch)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, calls)
+
+	// Invalidated after a change
+	cache.Invalidate(Key("project", "service"))
+	_, err = cache.Get(context.Background(), Key("project", "service"), fetch)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, calls)
+
+	// Still fresh
+	now = now.Add(time.Minute - time.Second)
+	_, err = cache.Get(context.Background(), Key("project", "service"), fetch)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, calls)
+
+	// Expired
+	now = now.Add(time.Second)
+	_, err = cache.Get(context.Background(), Key("project", "service"), fetch)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 4, calls)
+
+	// The written value is served without a fetch
+	cache.Set(Key("project", "new"), []string{"b"})
+	v, err := cache.Get(context.Background(), Key("project", "new"), fetch)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b"}, v)
+	assert.EqualValues(t, 4, calls)
+}
+
+func TestCacheOtherKeyDoesNotWait(t *testing.T) {
+	cache := New[int](time.Minute, time.Now)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	go func() {
+		_, _ = cache.Get(context.Background(), "slow", func(context.Context) (int, error) {
+			close(started)
+			<-release
+			return 1, nil
+		})
+	}()
+	<-started
+
+	// The slow fetch holds its own key only
+	v, err := cache.Get(context.Background(), "fast", func(context.Context) (int, error) {
+		return 2, nil
+	})
+	close(release)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, v)
+}
+
+func TestCacheError(t *testing.T) {
+	cache := New[*int](time.Minute, time.Now)
+
+	_, err := cache.Get(context.Background(), "key", func(context.Context) (*int, error) {
+		return nil, fmt.Errorf("query failed")
+	})
+	assert.EqualError(t, err, "query failed")
+
+	// Errors are not cached
+	one := 1
+	v, err := cache.Get(context.Background(), "key", func(context.Context) (*int, error) {
+		return &one, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &one, v)
+}
+
+func TestKey(t *testing.T) {
+	assert.NotEqual(t, Key("a", "bc"), Key("ab", "c"))
+	assert.Equal(t, "a/bc", Key("a", "bc"))
+}
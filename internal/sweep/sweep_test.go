@@ -46,17 +46,25 @@ func knownMissginSweepers() []string {
 		"aiven_influxdb_database",
 		"aiven_mysql_user",
 		"aiven_kafka_acl",
+		"aiven_kafka_native_acl",
+		"aiven_kafka_quota",
+		"aiven_kafka_consumer_group_offsets",
 		"aiven_pg_database",
+		"aiven_pg_grant",
+		"aiven_pg_extension",
+		"aiven_pg_schema",
 		"aiven_kafka_user",
 		"aiven_redis_user",
 		"aiven_opensearch_acl_config",
 		"aiven_mysql_database",
+		"aiven_mysql_grant",
 		"aiven_m3db_user",
 		"aiven_kafka_topic",
 		"aiven_kafka_schema",
 		"aiven_azure_privatelink_connection_approval",
 		"aiven_flink_application_version",
 		"aiven_opensearch_acl_rule",
+		"aiven_opensearch_acl_rules",
 		"aiven_mirrormaker_replication_flow",
 		"aiven_project_user",
 		"aiven_clickhouse_user",
@@ -68,7 +76,16 @@ func knownMissginSweepers() []string {
 		"aiven_opensearch_user",
 		"aiven_kafka_schema_configuration",
 		"aiven_clickhouse_grant",
+		"aiven_clickhouse_row_policy",
+		"aiven_clickhouse_settings_profile",
+		"aiven_clickhouse_quota",
+		"aiven_clickhouse_table",
+		"aiven_clickhouse_view",
 		"aiven_opensearch_security_plugin_config",
+		"aiven_opensearch_security_role",
+		"aiven_opensearch_security_role_mapping",
+		"aiven_opensearch_snapshot_repository",
+		"aiven_opensearch_ism_policy",
 		"aiven_flink_application",
 	}
 }